@@ -33,22 +33,9 @@ type appState struct {
 
 // loadConfigFile reads config file into a.Config if file is present.
 func (a *appState) loadConfigFile(ctx context.Context) error {
-	if _, err := os.Stat(a.configPath); err != nil {
-		// don't return error if file doesn't exist
-		return nil
-	}
-
-	// read the config file bytes
-	file, err := os.ReadFile(a.configPath)
-	if err != nil {
-		return fmt.Errorf("error reading file: %w", err)
-	}
-
-	// unmarshall them into the wrapper struct
-	cfgWrapper := &ConfigInputWrapper{}
-	err = yaml.Unmarshal(file, cfgWrapper)
-	if err != nil {
-		return fmt.Errorf("error unmarshalling config: %w", err)
+	cfgWrapper, substitutions, version, err := a.readConfigFile()
+	if err != nil || cfgWrapper == nil {
+		return err
 	}
 
 	// retrieve the runtime configuration from the disk configuration.
@@ -63,25 +50,104 @@ func (a *appState) loadConfigFile(ctx context.Context) error {
 	}
 
 	// save runtime configuration in app state
+	newCfg.substitutions = substitutions
+	newCfg.fileVersion = version
 	a.config = newCfg
 
 	return nil
 }
 
-func (a *appState) performConfigLockingOperation(ctx context.Context, operation func() error) error {
+// reloadConfigFile reads the config file for a reload of the running relayer,
+// the providers are built by the relayer for the new and changed chains only
+// and the running config is left as is
+func (a *appState) reloadConfigFile() (*relayer.LoadedConfig, error) {
+	cfgWrapper, _, _, err := a.readConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	if cfgWrapper == nil {
+		return nil, fmt.Errorf("config file not found: %s", a.configPath)
+	}
+	// the cluster key and the kms are only read on start
+	return cfgWrapper.loadedConfig(a, a.config.Global.ClusterMode, a.kms)
+}
+
+// readConfigFile reads, migrates, resolves and validates the config file,
+// the wrapper is nil if the file does not exist
+func (a *appState) readConfigFile() (*ConfigInputWrapper, []configSubstitution, int, error) {
+	if _, err := os.Stat(a.configPath); err != nil {
+		// don't return error if file doesn't exist
+		return nil, nil, 0, nil
+	}
+
+	// read the config file bytes
+	file, err := os.ReadFile(a.configPath)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("error reading file: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(file, &root); err != nil {
+		return nil, nil, 0, fmt.Errorf("error unmarshalling config: %w", err)
+	}
+
+	// upgrade the older versions in memory, config migrate rewrites the file
+	version, changes, err := migrateConfigNode(&root)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if version < configVersion {
+		a.log.Warn("config file is of an older version, run config migrate to upgrade it",
+			zap.Int("version", version),
+			zap.Int("current", configVersion),
+			zap.Int("changes", len(changes)),
+		)
+	}
+
+	// resolve the environment variables, secret files and overrides
+	substitutions, err := resolveConfigNode(&root)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("error resolving config: %w", err)
+	}
+
+	// check the fields against the schema, the unknown fields are ignored
+	unknown, err := validateConfigSchema(&root)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	warnUnknownFields(a.log, unknown)
+
+	// unmarshall them into the wrapper struct
+	cfgWrapper := &ConfigInputWrapper{}
+	if err := root.Decode(cfgWrapper); err != nil {
+		return nil, nil, 0, fmt.Errorf("error unmarshalling config: %w", err)
+	}
+	return cfgWrapper, substitutions, version, nil
+}
+
+// lockConfig acquires the lock of the config file, the returned func releases it
+func (a *appState) lockConfig() (func(), error) {
 	lockFilePath := path.Join(a.homePath, "config.lock")
 	fileLock := flock.New(lockFilePath)
 	_, err := fileLock.TryLock()
 	if err != nil {
-		return fmt.Errorf("failed to acquire config lock: %w", err)
+		return nil, fmt.Errorf("failed to acquire config lock: %w", err)
 	}
-	defer func() {
+	return func() {
 		if err := fileLock.Unlock(); err != nil {
 			a.log.Error("error unlocking config file lock, please manually delete",
 				zap.String("filepath", lockFilePath),
 			)
 		}
-	}()
+	}, nil
+}
+
+func (a *appState) performConfigLockingOperation(ctx context.Context, operation func() error) error {
+	unlock, err := a.lockConfig()
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	// load config from file and validate it. don't want to miss
 	// any changes that may have been made while unlocked.
@@ -99,17 +165,9 @@ func (a *appState) performConfigLockingOperation(ctx context.Context, operation
 		return fmt.Errorf("error parsing chain config: %w", err)
 	}
 
-	// marshal the new config
-	out, err := yaml.Marshal(a.config.Wrapped())
-	if err != nil {
-		return err
-	}
-
-	cfgPath := a.configPath
-
 	// Overwrite the config file.
-	if err := os.WriteFile(cfgPath, out, 0o600); err != nil {
-		return fmt.Errorf("failed to write config file at %s: %w", cfgPath, err)
+	if err := a.config.Save(a.configPath); err != nil {
+		return fmt.Errorf("failed to write config file at %s: %w", a.configPath, err)
 	}
 
 	return nil
@@ -2,11 +2,11 @@ package cmd
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 
@@ -28,6 +28,11 @@ func chainsCmd(a *appState) *cobra.Command {
 		chainsListCmd(a),
 		chainsAddCmd(a),
 		chainsDeleteCmd(a),
+		chainsCircuitCmd(a),
+		chainsPauseCmd(a),
+		chainsResumeCmd(a),
+		chainsPausesCmd(a),
+		chainsShardCmd(a),
 	)
 
 	return cmd
@@ -82,7 +87,7 @@ $ %s ch l`, appName, appName)),
 				i := 0
 				for _, c := range a.config.Chains {
 					i++
-					fmt.Fprintf(cmd.OutOrStdout(), "%d: %-20s -> type(%s)\n", i, c.NID(), c.ChainProvider.Type())
+					fmt.Fprintf(cmd.OutOrStdout(), "%d: %-20s -> type(%s)%s\n", i, c.NID(), c.ChainProvider.Type(), a.shardOwnership(c.NID()))
 				}
 				return nil
 			}
@@ -91,77 +96,201 @@ $ %s ch l`, appName, appName)),
 	return yamlFlag(a.viper, jsonFlag(a.viper, cmd))
 }
 
+func chainsCircuitCmd(a *appState) *cobra.Command {
+	db := newDBState()
+	cmd := &cobra.Command{
+		Use:   "circuit",
+		Short: "Shows the circuit breaker state of the chains",
+		Args:  withUsage(cobra.NoArgs),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s chains circuit
+$ %s ch circuit --chain 0x2.icon`, appName, appName)),
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			return db.closeSocket()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := db.getSocket(a)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			statuses, err := client.GetCircuitStatus(db.chain)
+			if err != nil {
+				return err
+			}
+			printLabels("Chain", "State", "Failures", "OpenedAt")
+			for _, status := range statuses {
+				openedAt := "-"
+				if !status.OpenedAt.IsZero() {
+					openedAt = status.OpenedAt.Format(time.RFC3339)
+				}
+				printValues(status.Chain, status.State, status.Failures, openedAt)
+			}
+			return nil
+		},
+	}
+	db.messageChainFlag(cmd, false)
+	return cmd
+}
+
 func chainsAddCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "add [chain-name...]",
+		Use:     "add [chain-name]",
 		Aliases: []string{"a"},
-		Short: "Add a new chain to the configuration file by fetching chain metadata from \n" +
-			" passing a file (-f) ",
-		Args: withUsage(cobra.MinimumNArgs(0)),
-		Example: fmt.Sprintf(` $ %s chains add cosmoshub
- $ %s chains add --file chains/ibc0.json ibc0`, appName, appName),
+		Short:   "Add a new chain to the configuration file from a file (-f) or from prompts (-i)",
+		Long: `Adds a chain from a json or yaml file (--file), or from prompts asking for the
+required fields (--interactive), the network is detected from the endpoints.
+With --type alone, prints a commented chain file of the type to edit and add
+with --file.`,
+		Args: withUsage(cobra.MaximumNArgs(1)),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s chains add --type evm > avalanche.yaml
+$ %s chains add --file avalanche.yaml avalanche
+$ %s chains add --file chains/ibc0.json ibc0
+$ %s chains add --interactive --type evm avalanche`, appName, appName, appName, appName)),
+		// the chain files and the templates are read without building the providers of the config
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			log, err := newRootLogger(a.viper.GetString("log-format"), a.viper.GetBool("debug"))
+			if err != nil {
+				return err
+			}
+			a.log = log
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			file, err := cmd.Flags().GetString(flagFile)
 			if err != nil {
-				return fmt.Errorf("File is not present")
+				return err
+			}
+			chainType, err := cmd.Flags().GetString(flagType)
+			if err != nil {
+				return err
+			}
+			interactive, err := cmd.Flags().GetBool(flagInteractive)
+			if err != nil {
+				return err
 			}
 
-			if ok := a.config; ok == nil {
-				return fmt.Errorf("config not initialized, consider running `rly config init`")
+			switch {
+			case file != "" && interactive:
+				return fmt.Errorf("can't pass both --file and --interactive, must pick one")
+			case file == "" && !interactive:
+				if chainType == "" {
+					return fmt.Errorf("one of --file, --interactive or --type is required")
+				}
+				template, err := chainTemplate(chainType)
+				if err != nil {
+					return err
+				}
+				_, err = cmd.OutOrStdout().Write(template)
+				return err
 			}
 
-			return a.performConfigLockingOperation(cmd.Context(), func() error {
-				// default behavior fetch from chain registry
-				// still allow for adding config from url or file
-				switch {
-				case file != "":
-					var chainName string
-					switch len(args) {
-					case 0:
-						chainName = strings.Split(filepath.Base(file), ".")[0]
-					case 1:
-						chainName = args[0]
-					default:
-						return errors.New("one chain name is required")
-					}
-					if err := addChainFromFile(a, chainName, file); err != nil {
-						return err
-					}
+			if _, err := os.Stat(a.configPath); err != nil {
+				return fmt.Errorf("config not initialized, consider running `%s config init`", appName)
+			}
 
-				default:
-					return fmt.Errorf("file not present")
+			var (
+				chainName string
+				pcw       *ProviderConfigWrapper
+			)
+			if len(args) > 0 {
+				chainName = args[0]
+			}
+			if file != "" {
+				if chainName == "" {
+					chainName = strings.Split(filepath.Base(file), ".")[0]
 				}
-				return nil
-			})
+				if pcw, err = readChainFile(file); err != nil {
+					return err
+				}
+			} else {
+				if pcw, chainName, err = askChain(cmd, chainType, chainName); err != nil {
+					return err
+				}
+			}
+
+			if err := a.performConfigLockingOperation(cmd.Context(), func() error {
+				for _, c := range a.config.Chains {
+					if c.ChainProvider.Name() == chainName {
+						return fmt.Errorf("chain %s already exists in config", chainName)
+					}
+				}
+				return addChainConfig(cmd.Context(), a, chainName, pcw)
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "chain %s added to %s\n", chainName, a.configPath)
+			return nil
 		},
 	}
 
-	cmd = fileFlag(a.viper, cmd)
-	return cmd
+	return interactiveFlag(a.viper, chainTypeFlag(a.viper, fileFlag(a.viper, cmd)))
 }
 
-func addChainFromFile(a *appState, chainName string, file string) error {
-	// If the user passes in a file, attempt to read the chain config from that file
-	var pcw ProviderConfigWrapper
-	if _, err := os.Stat(file); err != nil {
-		return err
+// askChain prompts for the name, the type and the fields of the chain which are not given
+func askChain(cmd *cobra.Command, chainType, chainName string) (*ProviderConfigWrapper, string, error) {
+	p := newPrompter(cmd.InOrStdin(), cmd.ErrOrStderr())
+	var err error
+	for chainName == "" {
+		if chainName, err = p.ask("chain name", ""); err != nil {
+			return nil, "", err
+		}
+	}
+	for {
+		if _, ok := chainConfigs[chainType]; ok {
+			break
+		}
+		if chainType != "" {
+			fmt.Fprintf(p.out, "unknown chain type %s\n", chainType)
+		}
+		if chainType, err = p.ask("chain type ("+strings.Join(chainTypes(), ", ")+")", ""); err != nil {
+			return nil, "", err
+		}
 	}
+	cfg, err := p.askChain(cmd.Context(), chainType, chainName)
+	if err != nil {
+		return nil, "", err
+	}
+	// the problems are reported and the chain added, the missing values can be edited in the config
+	if checker, ok := cfg.(provider.ConfigChecker); ok {
+		for _, err := range checker.CheckConfig() {
+			fmt.Fprintf(p.out, "warning: %v\n", err)
+		}
+	}
+	return &ProviderConfigWrapper{Type: chainType, Value: cfg}, chainName, nil
+}
 
+// readChainFile reads a chain from a yaml file, or from a json file for the other extensions
+func readChainFile(file string) (*ProviderConfigWrapper, error) {
 	byt, err := os.ReadFile(file)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err = jsoniter.Unmarshal(byt, &pcw); err != nil {
-		return err
+	switch filepath.Ext(file) {
+	case ".yaml", ".yml":
+		var w ProviderConfigYAMLWrapper
+		if err := yaml.Unmarshal(byt, &w); err != nil {
+			return nil, err
+		}
+		return &ProviderConfigWrapper{Type: w.Type, Value: w.Value.(provider.Config)}, nil
+	default:
+		var pcw ProviderConfigWrapper
+		if err := jsoniter.Unmarshal(byt, &pcw); err != nil {
+			return nil, err
+		}
+		return &pcw, nil
 	}
+}
 
-	prov, err := pcw.Value.NewProvider(context.Background(),
+func addChainConfig(ctx context.Context, a *appState, chainName string, pcw *ProviderConfigWrapper) error {
+	prov, err := pcw.Value.NewProvider(ctx,
 		a.log.With(zap.String("provider_type", pcw.Type)),
 		a.homePath, a.debug, chainName,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to build ChainProvider for %s: %w", file, err)
+		return fmt.Errorf("failed to build ChainProvider for %s: %w", chainName, err)
 	}
 	prov.Config().(provider.ClusterConfig).SetClusterMode(a.config.Global.ClusterMode.Enabled)
 
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 
 	jsoniter "github.com/json-iterator/go"
@@ -24,8 +25,17 @@ import (
 	"github.com/icon-project/centralized-relay/relayer"
 	"github.com/icon-project/centralized-relay/relayer/chains/evm"
 	"github.com/icon-project/centralized-relay/relayer/chains/icon"
+	"github.com/icon-project/centralized-relay/relayer/chains/plugin"
+	"github.com/icon-project/centralized-relay/relayer/circuit"
+	"github.com/icon-project/centralized-relay/relayer/election"
+	"github.com/icon-project/centralized-relay/relayer/fee"
 	"github.com/icon-project/centralized-relay/relayer/kms"
+	"github.com/icon-project/centralized-relay/relayer/policy"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/quorum"
+	"github.com/icon-project/centralized-relay/relayer/scheduler"
+	"github.com/icon-project/centralized-relay/relayer/shard"
+	"github.com/icon-project/centralized-relay/relayer/socket"
 	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -42,6 +52,10 @@ func configCmd(a *appState) *cobra.Command {
 	cmd.AddCommand(
 		configShowCmd(a),
 		configInitCmd(a),
+		configReloadCmd(a),
+		configValidateCmd(a),
+		configSchemaCmd(a),
+		configMigrateCmd(a),
 	)
 	return cmd
 }
@@ -51,7 +65,7 @@ func configShowCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "show",
 		Aliases: []string{"s", "list", "l"},
-		Short:   "Prints current configuration",
+		Short:   "Prints the effective configuration with the secrets redacted",
 		Args:    withUsage(cobra.NoArgs),
 		Example: strings.TrimSpace(fmt.Sprintf(`
 $ %s config show --home %s
@@ -78,18 +92,27 @@ $ %s cfg list`, appName, a.homePath, appName)),
 			if err != nil {
 				return err
 			}
+			// the values are shown after the environment overrides, with the secrets redacted
+			root, err := a.config.redacted()
+			if err != nil {
+				return err
+			}
 			switch {
 			case yml && jsn:
 				return fmt.Errorf("can't pass both --json and --yaml, must pick one")
 			case jsn:
-				out, err := jsoniter.Marshal(a.config.Wrapped())
+				var effective interface{}
+				if err := root.Decode(&effective); err != nil {
+					return err
+				}
+				out, err := jsoniter.Marshal(effective)
 				if err != nil {
 					return err
 				}
 				fmt.Fprintln(cmd.OutOrStdout(), string(out))
 				return nil
 			default:
-				out, err := yaml.Marshal(a.config.Wrapped())
+				out, err := yaml.Marshal(root)
 				if err != nil {
 					return err
 				}
@@ -102,6 +125,52 @@ $ %s cfg list`, appName, a.homePath, appName)),
 	return yamlFlag(a.viper, jsonFlag(a.viper, cmd))
 }
 
+// Command for reloading the config of the running relayer
+func configReloadCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reload",
+		Short: "Reloads the chains of the running relayer from the config file",
+		Args:  withUsage(cobra.NoArgs),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s config reload`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := socket.NewClient()
+			if err != nil {
+				return fmt.Errorf("relayer is not running: %w", err)
+			}
+			defer client.Close()
+			report, err := client.ReloadConfig()
+			if err != nil {
+				return err
+			}
+			printLabels("Chain", "Change", "Fields")
+			for _, nid := range report.Added {
+				printValues(nid, "added", "-")
+			}
+			for _, nid := range report.Removed {
+				printValues(nid, "removed", "-")
+			}
+			for _, change := range report.Updated {
+				printValues(change.Chain, "updated", strings.Join(change.Fields, ","))
+			}
+			for _, change := range report.Changed {
+				printValues(change.Chain, "restarted", strings.Join(change.Fields, ","))
+			}
+			for _, nid := range report.Unchanged {
+				printValues(nid, "unchanged", "-")
+			}
+			if len(report.Global) > 0 {
+				printValues("global", "updated", strings.Join(report.Global, ","))
+			}
+			if len(report.RestartRequired) > 0 {
+				printValues("global", "restart required", strings.Join(report.RestartRequired, ","))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
 // Command for initializing an empty config at the --home location
 func configInitCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
@@ -141,6 +210,64 @@ type GlobalConfig struct {
 	Timeout     string         `yaml:"timeout" json:"timeout"`
 	KMSKeyID    string         `yaml:"kms-key-id" json:"kms-key-id"`
 	ClusterMode *ClusterConfig `yaml:"cluster-mode" json:"cluster-mode"`
+	FeeManager  *fee.Config    `yaml:"fee-manager,omitempty" json:"fee-manager,omitempty"`
+	// Profitability checks the fee paid against the delivery cost before routing
+	Profitability *fee.ProfitabilityConfig `yaml:"profitability,omitempty" json:"profitability,omitempty"`
+	// CircuitBreaker stops routing to the chains which keep failing
+	CircuitBreaker *circuit.Config `yaml:"circuit-breaker,omitempty" json:"circuit-breaker,omitempty"`
+	// Policy allows, denies, delays or prioritises the messages by rules
+	Policy *policy.Config `yaml:"policy,omitempty" json:"policy,omitempty"`
+	// Scheduler orders the routing by priority classes
+	Scheduler *scheduler.Config `yaml:"scheduler,omitempty" json:"scheduler,omitempty"`
+	// Election runs the relayer in active/passive mode, only the leader routes
+	Election *election.Config `yaml:"election,omitempty" json:"election,omitempty"`
+	// Shard limits the routing to the routes owned by the instance
+	Shard *shard.Config `yaml:"shard,omitempty" json:"shard,omitempty"`
+	// Quorum confirms the reads on several endpoints of the chains
+	Quorum *quorum.Config `yaml:"quorum,omitempty" json:"quorum,omitempty"`
+}
+
+// configureRelayer enables the optional relayer services set in the global config
+func (c *GlobalConfig) configureRelayer(rly *relayer.Relayer) error {
+	if c.FeeManager != nil {
+		if err := rly.SetFeeManager(c.FeeManager); err != nil {
+			return err
+		}
+	}
+	if c.Profitability != nil {
+		if err := rly.SetProfitabilityCheck(c.Profitability); err != nil {
+			return err
+		}
+	}
+	if c.CircuitBreaker != nil {
+		rly.SetCircuitBreaker(c.CircuitBreaker)
+	}
+	if c.Policy != nil {
+		if err := rly.SetPolicy(c.Policy); err != nil {
+			return err
+		}
+	}
+	if c.Scheduler != nil {
+		if err := rly.SetScheduler(c.Scheduler); err != nil {
+			return err
+		}
+	}
+	if c.Election != nil {
+		if err := rly.SetElection(c.Election); err != nil {
+			return err
+		}
+	}
+	if c.Shard != nil {
+		if err := rly.SetShard(c.Shard); err != nil {
+			return err
+		}
+	}
+	if c.Quorum != nil {
+		if err := rly.SetQuorum(c.Quorum); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // SetClusterMode sets the cluster mode for the global config
@@ -208,16 +335,51 @@ func newDefaultGlobalConfig() *GlobalConfig {
 type Config struct {
 	Global *GlobalConfig  `yaml:"global" json:"global"`
 	Chains relayer.Chains `yaml:"chains" json:"chains"`
+	// substitutions are the values resolved from the environment and files when loaded
+	substitutions []configSubstitution
+	// fileVersion is the version of the config file before its migration
+	fileVersion int
+	// loaded is the config of the file compared by the reloads
+	loaded *relayer.LoadedConfig
 }
 
+// Save writes the config file, a file of an older version is backed up
+// before it is rewritten in the current version
 func (c *Config) Save(cfgPath string) error {
-	out, err := yaml.Marshal(c.Wrapped())
+	out, err := c.fileYAML()
 	if err != nil {
 		return err
 	}
+	if c.fileVersion < configVersion {
+		if _, err := backupConfigFile(cfgPath, c.fileVersion); err != nil {
+			return err
+		}
+		c.fileVersion = configVersion
+	}
 	return os.WriteFile(cfgPath, out, 0o600)
 }
 
+// fileYAML returns the config written to the config file, the values resolved
+// from the environment and files are written back as references
+func (c *Config) fileYAML() ([]byte, error) {
+	var root yaml.Node
+	if err := root.Encode(c.Wrapped()); err != nil {
+		return nil, err
+	}
+	restoreSubstitutions(&root, c.substitutions)
+	return yaml.Marshal(&root)
+}
+
+// redacted returns the effective config with the secrets redacted
+func (c *Config) redacted() (*yaml.Node, error) {
+	var root yaml.Node
+	if err := root.Encode(c.Wrapped()); err != nil {
+		return nil, err
+	}
+	redactConfig(&root, c.substitutions)
+	return &root, nil
+}
+
 // validateConfig is used to validate the GlobalConfig values
 func (c *Config) validateConfig() error {
 	// validating config
@@ -226,12 +388,14 @@ func (c *Config) validateConfig() error {
 
 // ConfigOutputWrapper is an intermediary type for writing the config to disk and stdout
 type ConfigOutputWrapper struct {
+	Version         int             `yaml:"version" json:"version"`
 	Global          *GlobalConfig   `yaml:"global" json:"global"`
 	ProviderConfigs ProviderConfigs `yaml:"chains" json:"chains"`
 }
 
 // ConfigInputWrapper is an intermediary type for parsing the config.yaml file
 type ConfigInputWrapper struct {
+	Version         int                                   `yaml:"version"`
 	Global          *GlobalConfig                         `yaml:"global"`
 	ProviderConfigs map[string]*ProviderConfigYAMLWrapper `yaml:"chains"`
 }
@@ -270,30 +434,105 @@ func (c *ConfigInputWrapper) RuntimeConfig(ctx context.Context, a *appState) (*C
 		}
 		c.Global.ClusterMode.privateKey = privKey
 	}
+	// the values of the file are read before the providers fill in the defaults
+	loaded, err := c.loadedConfig(a, c.Global.ClusterMode, kmsProvider)
+	if err != nil {
+		return nil, err
+	}
 	for chainName, pcfg := range c.ProviderConfigs {
-		prov, err := pcfg.Value.(provider.Config).NewProvider(ctx,
-			a.log.With(zap.Stringp("provider_type", &pcfg.Type)),
-			a.homePath, a.debug, chainName,
-		)
+		chain, err := a.buildChain(ctx, chainName, pcfg, c.Global.ClusterMode, kmsProvider)
 		if err != nil {
-			return nil, fmt.Errorf("failed to build ChainProviders: %w chain: %s", err, chainName)
-		}
-		prov.Config().(provider.ClusterConfig).SetClusterMode(c.Global.ClusterMode.Enabled)
-		a.kms = kmsProvider
-		if err := prov.Init(ctx, a.homePath, kmsProvider); err != nil {
-			return nil, fmt.Errorf("failed to initialize provider: %w", err)
+			return nil, err
 		}
-		chain := relayer.NewChain(a.log, prov, a.debug)
 		chains[chain.ChainProvider.NID()] = chain
 	}
+	a.kms = kmsProvider
 	a.cluster = c.Global.ClusterMode
 
 	return &Config{
 		Global: c.Global,
 		Chains: chains,
+		loaded: loaded,
 	}, nil
 }
 
+// buildChain builds and initializes the provider of the chain
+func (a *appState) buildChain(ctx context.Context, chainName string, pcfg *ProviderConfigYAMLWrapper, cluster *ClusterConfig, kmsProvider kms.KMS) (*relayer.Chain, error) {
+	prov, err := pcfg.Value.(provider.Config).NewProvider(ctx,
+		a.log.With(zap.Stringp("provider_type", &pcfg.Type)),
+		a.homePath, a.debug, chainName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ChainProviders: %w chain: %s", err, chainName)
+	}
+	prov.Config().(provider.ClusterConfig).SetClusterMode(cluster.Enabled)
+	if err := prov.Init(ctx, a.homePath, kmsProvider); err != nil {
+		return nil, fmt.Errorf("failed to initialize provider: %w", err)
+	}
+	return relayer.NewChain(a.log, prov, a.debug), nil
+}
+
+// loadedConfig returns the config of the file for the reloads, the providers
+// are only built when the chains are added or their config changed
+func (c *ConfigInputWrapper) loadedConfig(a *appState, cluster *ClusterConfig, kmsProvider kms.KMS) (*relayer.LoadedConfig, error) {
+	global, err := relayer.ConfigFields(c.Global)
+	if err != nil {
+		return nil, err
+	}
+	loaded := &relayer.LoadedConfig{
+		Chains:         make(map[string]*relayer.ChainConfig, len(c.ProviderConfigs)),
+		Global:         global,
+		CircuitBreaker: c.Global.CircuitBreaker,
+	}
+	for chainName, pcfg := range c.ProviderConfigs {
+		fields, err := relayer.ConfigFields(pcfg.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config of chain %s: %w", chainName, err)
+		}
+		nid, _ := fields["nid"].(string)
+		loaded.Chains[nid] = &relayer.ChainConfig{
+			Type:   pcfg.Type,
+			Config: pcfg.Value.(provider.Config),
+			Fields: fields,
+			Build: func(ctx context.Context) (*relayer.Chain, error) {
+				return a.buildChain(ctx, chainName, pcfg, cluster, kmsProvider)
+			},
+		}
+	}
+	return loaded, nil
+}
+
+// chainConfigs are the configs of the chain types of the config file
+// NOTE: Add new ProviderConfig types here with the key set equal to the type of ChainProvider
+var chainConfigs = map[string]any{
+	"icon":    icon.Config{},
+	"evm":     evm.Config{},
+	"cosmos":  wasm.Config{},
+	"solana":  solana.Config{},
+	"stellar": steller.Config{},
+	"sui":     sui.Config{},
+	"plugin":  plugin.Config{},
+}
+
+// chainTypes returns the sorted chain types
+func chainTypes() []string {
+	types := make([]string, 0, len(chainConfigs))
+	for t := range chainConfigs {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// newChainConfig returns an empty config of the chain type
+func newChainConfig(chainType string) (provider.Config, error) {
+	cfg, ok := chainConfigs[chainType]
+	if !ok {
+		return nil, fmt.Errorf("%s is an invalid chain type, must be one of %s", chainType, strings.Join(chainTypes(), ", "))
+	}
+	return reflect.New(reflect.TypeOf(cfg)).Interface().(provider.Config), nil
+}
+
 type ProviderConfigs map[string]*ProviderConfigWrapper
 
 // ProviderConfigWrapper is an intermediary type for parsing arbitrary ProviderConfigs from json files and writing to json/yaml files
@@ -309,25 +548,22 @@ type ProviderConfigYAMLWrapper struct {
 }
 
 // UnmarshalJSON adds support for unmarshalling data from an arbitrary ProviderConfig
-// NOTE: Add new ProviderConfig types in the map here with the key set equal to the type of ChainProvider (e.g. cosmos, substrate, etc.)
+// of the types of chainConfigs
 func (pcw *ProviderConfigWrapper) UnmarshalJSON(data []byte) error {
-	customTypes := map[string]reflect.Type{
-		"icon":   reflect.TypeOf(icon.Config{}),
-		"evm":    reflect.TypeOf(evm.Config{}),
-		"cosmos": reflect.TypeOf(wasm.Config{}),
-		"sui":    reflect.TypeOf(sui.Config{}),
+	customTypes := make(map[string]reflect.Type, len(chainConfigs))
+	for chainType, cfg := range chainConfigs {
+		customTypes[chainType] = reflect.TypeOf(cfg)
 	}
 	val, err := UnmarshalJSONProviderConfig(data, customTypes)
 	if err != nil {
 		return err
 	}
-	pc := val.(provider.Config)
-	pcw.Value = pc
+	pcw.Type = val.Type
+	pcw.Value = val.Value
 	return nil
 }
 
 // UnmarshalYAML adds support for unmarshalling data from arbitrary ProviderConfig entries found in the config file
-// NOTE: Add logic for new ProviderConfig types in a switch case here
 func (iw *ProviderConfigYAMLWrapper) UnmarshalYAML(n *yaml.Node) error {
 	type inputWrapper ProviderConfigYAMLWrapper
 	type T struct {
@@ -340,50 +576,34 @@ func (iw *ProviderConfigYAMLWrapper) UnmarshalYAML(n *yaml.Node) error {
 		return err
 	}
 
-	switch iw.Type {
-	case "icon":
-		iw.Value = new(icon.Config)
-	case "evm":
-		iw.Value = new(evm.Config)
-	case "cosmos":
-		iw.Value = new(wasm.Config)
-	case "solana":
-		iw.Value = new(solana.Config)
-	case "stellar":
-		iw.Value = new(steller.Config)
-	case "sui":
-		iw.Value = new(sui.Config)
-	default:
-		return fmt.Errorf("%s is an invalid chain type, check your config file", iw.Type)
+	cfg, err := newChainConfig(iw.Type)
+	if err != nil {
+		return fmt.Errorf("%w, check your config file", err)
 	}
+	iw.Value = cfg
 
 	return obj.Wrapper.Decode(iw.Value)
 }
 
 // UnmarshalJSONProviderConfig contains the custom unmarshalling logic for ProviderConfig structs
-func UnmarshalJSONProviderConfig(data []byte, customTypes map[string]reflect.Type) (any, error) {
-	m := map[string]any{
-		"icon":   reflect.TypeOf(icon.Config{}),
-		"evm":    reflect.TypeOf(evm.Config{}),
-		"cosmos": reflect.TypeOf(wasm.Config{}),
-		"sui":    reflect.TypeOf(sui.Config{}),
+func UnmarshalJSONProviderConfig(data []byte, customTypes map[string]reflect.Type) (*ProviderConfigWrapper, error) {
+	var m struct {
+		Type  string              `json:"type"`
+		Value jsoniter.RawMessage `json:"value"`
 	}
 	if err := jsoniter.Unmarshal(data, &m); err != nil {
 		return nil, err
 	}
 
-	typeName := m["type"].(string)
-	var provCfg provider.Config
-	if ty, found := customTypes[typeName]; found {
-		provCfg = reflect.New(ty).Interface().(provider.Config)
+	ty, found := customTypes[m.Type]
+	if !found {
+		return nil, fmt.Errorf("%q is an invalid chain type", m.Type)
 	}
-
-	valueBytes, err := jsoniter.Marshal(m["value"])
-	if err != nil {
+	provCfg := reflect.New(ty).Interface().(provider.Config)
+	if err := jsoniter.Unmarshal(m.Value, provCfg); err != nil {
 		return nil, err
 	}
-
-	return provCfg, jsoniter.Unmarshal(valueBytes, &provCfg)
+	return &ProviderConfigWrapper{Type: m.Type, Value: provCfg}, nil
 }
 
 // Note: chainId and chainName is basically the same
@@ -397,7 +617,7 @@ func (c *Config) Wrapped() *ConfigOutputWrapper {
 		}
 		providers[chain.ChainProvider.Name()] = pcfgw
 	}
-	return &ConfigOutputWrapper{Global: c.Global, ProviderConfigs: providers}
+	return &ConfigOutputWrapper{Version: configVersion, Global: c.Global, ProviderConfigs: providers}
 }
 
 func defaultConfigYAML() []byte {
@@ -406,13 +626,14 @@ func defaultConfigYAML() []byte {
 
 func DefaultConfig() *Config {
 	return &Config{
-		Global: newDefaultGlobalConfig(),
-		Chains: make(relayer.Chains),
+		Global:      newDefaultGlobalConfig(),
+		Chains:      make(relayer.Chains),
+		fileVersion: configVersion,
 	}
 }
 
 func (c Config) MustYAML() []byte {
-	out, err := yaml.Marshal(c)
+	out, err := yaml.Marshal(c.Wrapped())
 	if err != nil {
 		panic(err)
 	}
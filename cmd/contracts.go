@@ -15,6 +15,7 @@ type contractState struct {
 	network string
 	msgFee  uint64
 	resFee  uint64
+	apply   bool
 }
 
 func newContractState(a *appState) *contractState {
@@ -42,7 +43,7 @@ func contractCMD(a *appState) *cobra.Command {
 		},
 	}
 
-	feeCmd.AddCommand(state.getFee(), state.setFee(), state.claimFee())
+	feeCmd.AddCommand(state.getFee(), state.setFee(), state.claimFee(), state.feeReport())
 
 	deployCmd := &cobra.Command{
 		Use:   "deploy",
@@ -142,3 +143,33 @@ func (c *contractState) claimFee() *cobra.Command {
 	}
 	return claimFeeCmd
 }
+
+// feeReport shows the fee proposals of the fee manager
+func (c *contractState) feeReport() *cobra.Command {
+	feeReportCmd := &cobra.Command{
+		Use:     "report",
+		Short:   "Report the fees proposed from the delivery cost of the routes",
+		Aliases: []string{"r"},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s contract fee report
+$ %s contract fee report --apply`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := c.getSocket(c.app)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			proposals, err := client.FeeReport(c.apply)
+			if err != nil {
+				return err
+			}
+			printLabels("Src", "Dst", "Current", "MsgFee", "ResFee", "Samples", "Applied", "Reason")
+			for _, p := range proposals {
+				printValues(p.Src, p.Dst, p.CurrentFee, p.MsgFee, p.ResFee, p.MsgSamples, p.Applied, p.Reason)
+			}
+			return nil
+		},
+	}
+	feeReportCmd.Flags().BoolVar(&c.apply, "apply", false, "submit the proposed fees outside the hysteresis")
+	return feeReportCmd
+}
@@ -64,7 +64,7 @@ func dbCmd(a *appState) *cobra.Command {
 		Short:   "Get messages stored in the database",
 		Aliases: []string{"m"},
 	}
-	messagesCmd.AddCommand(db.messagesList(a), db.messagesRelay(a), db.messagesRm(a), db.revertMessage(a))
+	messagesCmd.AddCommand(db.messagesList(a), db.messagesRelay(a), db.messagesRm(a), db.revertMessage(a), db.messagesSkipped(a), db.messagesUnpark(a), db.messagesQueue(a))
 
 	blockCmd := &cobra.Command{
 		Use:     "block",
@@ -109,6 +109,88 @@ func (d *dbState) messagesList(app *appState) *cobra.Command {
 	return list
 }
 
+func (d *dbState) messagesSkipped(app *appState) *cobra.Command {
+	skipped := &cobra.Command{
+		Use:   "skipped",
+		Short: "List messages skipped by the profitability check",
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			return d.closeSocket()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := d.getSocket(app)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			decisions, err := client.GetSkippedMessages(d.chain)
+			if err != nil {
+				return err
+			}
+			printLabels("Sn", "Src", "Dst", "Action", "FeePaid", "Cost")
+			for _, d := range decisions {
+				printValues(d.Sn, d.Src, d.Dst, d.Action, d.FeePaid, d.Cost)
+			}
+			return nil
+		},
+	}
+	d.messageChainFlag(skipped, false)
+	return skipped
+}
+
+func (d *dbState) messagesUnpark(app *appState) *cobra.Command {
+	unpark := &cobra.Command{
+		Use:   "unpark",
+		Short: "Move a parked message back to the routing queue, it is routed without the profitability check",
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			return d.closeSocket()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := d.getSocket(app)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			msg, err := client.UnparkMessage(d.chain, new(big.Int).SetUint64(d.sn))
+			if err != nil {
+				return err
+			}
+			printLabels("Sn", "Src", "Dst", "Height", "Event")
+			printValues(msg.Sn, msg.Src, msg.Dst, msg.MessageHeight, msg.EventType)
+			return nil
+		},
+	}
+	d.messageMsgIDFlag(unpark, true)
+	d.messageChainFlag(unpark, true)
+	return unpark
+}
+
+func (d *dbState) messagesQueue(app *appState) *cobra.Command {
+	queue := &cobra.Command{
+		Use:   "queue",
+		Short: "Queue depth of the routing priority classes",
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			return d.closeSocket()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := d.getSocket(app)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			depths, err := client.GetQueueDepth()
+			if err != nil {
+				return err
+			}
+			printLabels("Class", "Priority", "Depth")
+			for _, d := range depths {
+				printValues(d.Class, d.Priority, d.Depth)
+			}
+			return nil
+		},
+	}
+	return queue
+}
+
 func (d *dbState) messagesRelay(app *appState) *cobra.Command {
 	rly := &cobra.Command{
 		Use:     "relay",
@@ -273,6 +355,9 @@ func (d *dbState) getRelayer(app *appState) (*relayer.Relayer, error) {
 		fmt.Printf("failed to create relayer: %s\n", err)
 		return nil, err
 	}
+	if err := app.config.Global.configureRelayer(rly); err != nil {
+		return nil, err
+	}
 	return rly, nil
 }
 
@@ -0,0 +1,405 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/icon-project/centralized-relay/relayer/jsonschema"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// envOverridePrefix prefixes the environment variables overriding the config values
+	envOverridePrefix = "CR"
+	// fileSuffix marks the keys whose value is read from the file they name
+	fileSuffix = "_file"
+	redacted   = "[redacted]"
+)
+
+var (
+	envPattern = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+	// sensitiveKey matches the keys whose values are redacted by config show
+	sensitiveKey = regexp.MustCompile(`(?i)(password|secret|token|api[-_]?key|private|credential|authorization)`)
+)
+
+// configSubstitution is a value of the config file replaced when it is loaded,
+// the raw value is written back when the config is saved
+type configSubstitution struct {
+	path     []string
+	raw      string
+	style    yaml.Style
+	resolved string
+	// fileKey is the key of the _file indirection the value was read from
+	fileKey string
+	// added is set for the keys added by a CR_ override, they are left out
+	// of the saved file
+	added bool
+}
+
+// resolveConfigNode interpolates the ${ENV} references, reads the values of
+// the keys ending with _file and applies the CR_ environment overrides
+func resolveConfigNode(root *yaml.Node) ([]configSubstitution, error) {
+	overrides := viper.New()
+	overrides.SetEnvPrefix(envOverridePrefix)
+	overrides.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	overrides.AutomaticEnv()
+
+	r := &configResolver{overrides: overrides, applied: make(map[string]bool)}
+	if err := r.walk(documentContent(root), nil); err != nil {
+		return nil, err
+	}
+	r.addOverrides(documentContent(root))
+	return r.substitutions, nil
+}
+
+type configResolver struct {
+	overrides     *viper.Viper
+	substitutions []configSubstitution
+	// applied holds the environment variables of the overridden values
+	applied map[string]bool
+}
+
+func (r *configResolver) walk(n *yaml.Node, path []string) error {
+	switch n.Kind {
+	case yaml.MappingNode:
+		keys := make(map[string]bool)
+		for i := 0; i < len(n.Content); i += 2 {
+			keys[n.Content[i].Value] = true
+		}
+		for i := 0; i < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			var fileKey string
+			if strings.HasSuffix(key.Value, fileSuffix) && value.Kind == yaml.ScalarNode {
+				target := strings.TrimSuffix(key.Value, fileSuffix)
+				if keys[target] {
+					return fmt.Errorf("line %d: both %s and %s are set", key.Line, target, key.Value)
+				}
+				data, err := os.ReadFile(value.Value)
+				if err != nil {
+					return fmt.Errorf("line %d: %s: %w", key.Line, key.Value, err)
+				}
+				fileKey = key.Value
+				r.substitutions = append(r.substitutions, configSubstitution{
+					path:     childPath(path, target),
+					raw:      value.Value,
+					style:    value.Style,
+					resolved: strings.TrimSpace(string(data)),
+					fileKey:  fileKey,
+				})
+				key.Value = target
+				setScalar(value, strings.TrimSpace(string(data)))
+			}
+			if err := r.walk(value, childPath(path, key.Value)); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range n.Content {
+			if err := r.walk(item, childPath(path, strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		raw, style := n.Value, n.Style
+		value, err := interpolate(n.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %s: %w", n.Line, strings.Join(path, "."), err)
+		}
+		if key := overrideKey(path); key != "" && r.overrides.IsSet(key) {
+			value = r.overrides.GetString(key)
+			r.applied[overrideEnv(key)] = true
+		}
+		if value != n.Value {
+			// the value of a _file indirection keeps its first substitution
+			if last := len(r.substitutions) - 1; last >= 0 && r.substitutions[last].fileKey != "" && samePath(r.substitutions[last].path, path) {
+				r.substitutions[last].resolved = value
+			} else {
+				r.substitutions = append(r.substitutions, configSubstitution{path: path, raw: raw, style: style, resolved: value})
+			}
+			setScalar(n, value)
+		}
+	}
+	return nil
+}
+
+// interpolate replaces the ${NAME} and ${NAME:-default} references by the
+// environment variables, $${ is kept as ${
+func interpolate(value string) (string, error) {
+	var missing []string
+	out := envPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		if ref == "$${" {
+			return "${"
+		}
+		match := envPattern.FindStringSubmatch(ref)
+		if env, ok := os.LookupEnv(match[1]); ok {
+			return env
+		}
+		if match[2] != "" {
+			return match[3]
+		}
+		missing = append(missing, match[1])
+		return ref
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// overrideKey returns the viper key of the config value, the value level of
+// the chains is left out so chains.<name>.value.rpc-url is overridden by
+// CR_CHAINS_<NAME>_RPC_URL
+func overrideKey(path []string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	segments := path
+	if len(path) > 2 && path[0] == "chains" && path[2] == "value" {
+		segments = append(append([]string{}, path[:2]...), path[3:]...)
+	}
+	return strings.Join(segments, ".")
+}
+
+// overrideEnv returns the environment variable overriding the viper key
+func overrideEnv(key string) string {
+	return envOverridePrefix + "_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// addOverrides adds the keys of the CR_ overrides which are not in the file,
+// the scalar fields of the global config and of the values of the chains
+// present in the file are looked up in the schema of the config
+func (r *configResolver) addOverrides(root *yaml.Node) {
+	if root.Kind != yaml.MappingNode {
+		return
+	}
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(name, envOverridePrefix+"_") && !r.applied[name] {
+			env[name] = value
+		}
+	}
+	if len(env) == 0 {
+		return
+	}
+	schema := configSchema()
+	r.addFields(root, []string{"global"}, schema.Properties["global"], env)
+	_, chains := lookupNode(root, []string{"chains"})
+	if chains == nil || chains.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i < len(chains.Content); i += 2 {
+		name := chains.Content[i].Value
+		_, chainType := lookupNode(root, []string{"chains", name, "type"})
+		if chainType == nil || schema.Defs[chainType.Value] == nil {
+			continue
+		}
+		r.addFields(root, []string{"chains", name, "value"}, schema.Defs[chainType.Value], env)
+	}
+}
+
+// addFields adds the scalar properties of the schema overridden by the
+// environment to the mapping at the path, which is created when missing
+func (r *configResolver) addFields(root *yaml.Node, path []string, s *jsonschema.Schema, env map[string]string) {
+	for _, prop := range s.PropertyNames() {
+		if !isScalarSchema(s.Properties[prop]) {
+			continue
+		}
+		field := childPath(path, prop)
+		value, ok := env[overrideEnv(overrideKey(field))]
+		if !ok {
+			continue
+		}
+		if _, existing := lookupNode(root, field); existing != nil {
+			continue
+		}
+		parent := ensureMapping(root, path)
+		if parent == nil {
+			continue
+		}
+		node := &yaml.Node{}
+		setScalar(node, value)
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: prop}, node)
+		r.substitutions = append(r.substitutions, configSubstitution{path: field, resolved: value, added: true})
+	}
+}
+
+func isScalarSchema(s *jsonschema.Schema) bool {
+	if s == nil || len(s.Type) == 0 {
+		return false
+	}
+	for _, t := range s.Type {
+		switch t {
+		case jsonschema.TypeString, jsonschema.TypeInteger, jsonschema.TypeNumber, jsonschema.TypeBoolean:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ensureMapping returns the mapping at the path, the missing keys are added
+func ensureMapping(root *yaml.Node, path []string) *yaml.Node {
+	n := root
+	for _, segment := range path {
+		if n.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i < len(n.Content); i += 2 {
+			if n.Content[i].Value == segment {
+				next = n.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: segment}, next)
+		}
+		n = next
+	}
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	return n
+}
+
+// setScalar replaces the value of the node, its type is resolved again from the value
+func setScalar(n *yaml.Node, value string) {
+	n.Kind = yaml.ScalarNode
+	n.Value = value
+	n.Tag = ""
+	n.Style = 0
+	n.Content = nil
+}
+
+// restoreSubstitutions writes back the raw values of the substitutions which
+// were not changed since the config was loaded, the keys added by the
+// overrides are removed
+func restoreSubstitutions(root *yaml.Node, substitutions []configSubstitution) {
+	for _, s := range substitutions {
+		key, value := lookupNode(root, s.path)
+		if value == nil || value.Kind != yaml.ScalarNode || value.Value != s.resolved {
+			continue
+		}
+		if s.added {
+			removeNode(root, s.path)
+			continue
+		}
+		if s.fileKey != "" && key != nil {
+			key.Value = s.fileKey
+		}
+		value.Value = s.raw
+		value.Tag = ""
+		value.Style = s.style
+	}
+}
+
+// redactConfig hides the values of the sensitive keys, the values read from
+// files, the rpc-auth values and the passwords and queries of the urls
+func redactConfig(root *yaml.Node, substitutions []configSubstitution) {
+	for _, s := range substitutions {
+		if s.fileKey != "" {
+			if _, value := lookupNode(root, s.path); value != nil && value.Kind == yaml.ScalarNode {
+				setScalar(value, redacted)
+			}
+		}
+	}
+	redactNode(documentContent(root), false)
+}
+
+func redactNode(n *yaml.Node, sensitive bool) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i < len(n.Content); i += 2 {
+			key, value := n.Content[i].Value, n.Content[i+1]
+			redactNode(value, sensitive || key == "rpc-auth" || sensitiveKey.MatchString(key))
+		}
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			redactNode(item, sensitive)
+		}
+	case yaml.ScalarNode:
+		if n.Value == "" || n.Value == redacted {
+			return
+		}
+		if sensitive {
+			setScalar(n, redacted)
+			return
+		}
+		if u, err := url.Parse(n.Value); err == nil && u.Host != "" && (u.User != nil || u.RawQuery != "") {
+			if _, ok := u.User.Password(); ok {
+				u.User = url.UserPassword(u.User.Username(), "xxxxx")
+			}
+			if u.RawQuery != "" {
+				u.RawQuery = redacted
+			}
+			n.Value = u.String()
+		}
+	}
+}
+
+// lookupNode returns the key and value nodes at the path
+func lookupNode(root *yaml.Node, path []string) (*yaml.Node, *yaml.Node) {
+	var key *yaml.Node
+	n := documentContent(root)
+	for _, segment := range path {
+		switch n.Kind {
+		case yaml.MappingNode:
+			var next *yaml.Node
+			for i := 0; i < len(n.Content); i += 2 {
+				if n.Content[i].Value == segment {
+					key, next = n.Content[i], n.Content[i+1]
+					break
+				}
+			}
+			if next == nil {
+				return nil, nil
+			}
+			n = next
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i >= len(n.Content) {
+				return nil, nil
+			}
+			key, n = nil, n.Content[i]
+		default:
+			return nil, nil
+		}
+	}
+	return key, n
+}
+
+// removeNode removes the key at the path from its mapping
+func removeNode(root *yaml.Node, path []string) {
+	_, parent := lookupNode(root, path[:len(path)-1])
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == path[len(path)-1] {
+			parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func documentContent(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		return n.Content[0]
+	}
+	return n
+}
+
+func childPath(path []string, segment string) []string {
+	return append(append([]string{}, path...), segment)
+}
+
+func samePath(a, b []string) bool {
+	return strings.Join(a, ".") == strings.Join(b, ".")
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/icon-project/centralized-relay/relayer"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -19,6 +21,10 @@ const (
 	flagFresh           = "fresh"
 	flagFile            = "file"
 	flagConfig          = "config"
+	flagMetricsAddr     = "metrics-addr"
+	flagType            = "type"
+	flagInteractive     = "interactive"
+	flagDryRun          = "dry-run"
 )
 
 func flushIntervalFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
@@ -29,6 +35,14 @@ func flushIntervalFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
 	return cmd
 }
 
+func metricsAddrFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(flagMetricsAddr, "", "address to serve the prometheus metrics on, disabled when empty")
+	if err := v.BindPFlag(flagMetricsAddr, cmd.Flags().Lookup(flagMetricsAddr)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
 func freshFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().Bool(flagFresh, false, "whether to clear db and start fresh")
 	if err := v.BindPFlag(flagFresh, cmd.Flags().Lookup(flagFresh)); err != nil {
@@ -60,3 +74,27 @@ func fileFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
 	}
 	return cmd
 }
+
+func chainTypeFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().StringP(flagType, "t", "", "chain type, one of "+strings.Join(chainTypes(), ", "))
+	if err := v.BindPFlag(flagType, cmd.Flags().Lookup(flagType)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func interactiveFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().BoolP(flagInteractive, "i", false, "prompt for the values")
+	if err := v.BindPFlag(flagInteractive, cmd.Flags().Lookup(flagInteractive)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func dryRunFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Bool(flagDryRun, false, "print the changes without writing them")
+	if err := v.BindPFlag(flagDryRun, cmd.Flags().Lookup(flagDryRun)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/migration"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configMigrations upgrade the config files written for the older versions of
+// the relayer, a step is added for every change of the format
+var configMigrations = migration.NewRegistry(
+	migration.Step{From: 0, Description: "remove the settings of the older relayers and rename their fields", Migrate: migrateConfigV1},
+)
+
+// configVersion is the version of the config files written by the relayer
+var configVersion = configMigrations.Latest()
+
+// migrateConfigV1 upgrades the unversioned config files
+func migrateConfigV1(root *migration.Mapping) error {
+	if global := root.Mapping("global"); global != nil {
+		global.Remove("api-listen-addr", "the relayer is managed through its socket")
+		global.Remove("memo", "not used")
+		global.Remove("light-cache-size", "not used")
+	}
+	chains := root.Mapping("chains")
+	if chains == nil {
+		return nil
+	}
+	return chains.Each(func(_ string, chain *migration.Mapping) error {
+		value := chain.Mapping("value")
+		if value == nil {
+			return nil
+		}
+		value.Remove("block-interval", "the listeners follow the chain")
+		value.Remove("network-id", "the network is identified by nid")
+		var chainType string
+		if t := chain.Get("type"); t != nil {
+			chainType = t.Value
+		}
+		switch chainType {
+		case "icon":
+			value.Rename("min-step-limit", "step-min")
+			value.Rename("max-step-limit", "step-limit")
+		case "evm":
+			value.Remove("gas-price", "the gas price is estimated, max-fee-per-gas caps it")
+			value.Remove("gas-min", "not used")
+		case "cosmos":
+			value.Rename("gas-price", "gas-prices")
+			value.Remove("chain-id", "the chain id is the nid")
+			value.Remove("grpc-url", "the queries use rpc-url")
+			value.Remove("chain-name", "the chain name is its key")
+		case "sui":
+			value.Remove("ws-url", "the events are polled")
+			value.Remove("gas-price", "the gas price is read from the network")
+		}
+		return nil
+	})
+}
+
+// migrateConfigNode upgrades the config file to the current version, a
+// config written by a newer relayer is refused
+func migrateConfigNode(root *yaml.Node) (int, []migration.Change, error) {
+	from, changes, err := configMigrations.Migrate(root)
+	var newer *migration.NewerVersionError
+	if errors.As(err, &newer) {
+		return from, nil, fmt.Errorf("config %w, upgrade the relayer to use it", err)
+	}
+	if err != nil {
+		return from, nil, fmt.Errorf("error migrating config: %w", err)
+	}
+	return from, changes, nil
+}
+
+// backupConfigFile copies the config file of the version next to it, nothing is
+// written when the file does not exist
+func backupConfigFile(cfgPath string, version int) (string, error) {
+	data, err := os.ReadFile(cfgPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	backup := fmt.Sprintf("%s.v%d-%s.bak", cfgPath, version, time.Now().Format("20060102150405"))
+	if err := os.WriteFile(backup, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write config backup at %s: %w", backup, err)
+	}
+	return backup, nil
+}
+
+// Command for upgrading the config file to the current version
+func configMigrateCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrades the config file to the current version, keeping a backup of the file",
+		Long: strings.TrimSpace(`
+Upgrades the config file written for an older relayer to the current version:
+the removed settings are dropped and the renamed fields are renamed. The file
+is backed up next to it before it is rewritten, the comments of the file are
+kept.`),
+		Args: withUsage(cobra.NoArgs),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s config migrate --dry-run
+$ %s config migrate`, appName, appName)),
+		// the config is not loaded, the older versions may not load
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			log, err := newRootLogger(a.viper.GetString("log-format"), a.viper.GetBool("debug"))
+			if err != nil {
+				return err
+			}
+			a.log = log
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun, err := cmd.Flags().GetBool(flagDryRun)
+			if err != nil {
+				return err
+			}
+			unlock, err := a.lockConfig()
+			if err != nil {
+				return err
+			}
+			defer unlock()
+
+			file, err := os.ReadFile(a.configPath)
+			if err != nil {
+				return fmt.Errorf("error reading file: %w", err)
+			}
+			var root yaml.Node
+			if err := yaml.Unmarshal(file, &root); err != nil {
+				return fmt.Errorf("error unmarshalling config: %w", err)
+			}
+			from, changes, err := migrateConfigNode(&root)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if from == configVersion {
+				fmt.Fprintf(out, "config %s is at version %d, nothing to migrate\n", a.configPath, from)
+				return nil
+			}
+			fmt.Fprintf(out, "config %s: version %d to %d\n", a.configPath, from, configVersion)
+			for _, step := range configMigrations.Steps() {
+				if step.From < from {
+					continue
+				}
+				fmt.Fprintf(out, "v%d: %s\n", step.From+1, step.Description)
+				for _, change := range changes {
+					switch {
+					case change.Version != step.From+1:
+					case change.Path == "":
+						fmt.Fprintf(out, "  %s\n", change.Message)
+					default:
+						fmt.Fprintf(out, "  %s: %s\n", change.Path, change.Message)
+					}
+				}
+			}
+			if dryRun {
+				fmt.Fprintln(out, "dry run, the file is not written")
+				return nil
+			}
+
+			migrated, err := yaml.Marshal(&root)
+			if err != nil {
+				return err
+			}
+			backup, err := backupConfigFile(a.configPath, from)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(a.configPath, migrated, 0o600); err != nil {
+				return fmt.Errorf("failed to write config file at %s: %w", a.configPath, err)
+			}
+			fmt.Fprintf(out, "backup written to %s\n", backup)
+			return nil
+		},
+	}
+	return dryRunFlag(a.viper, cmd)
+}
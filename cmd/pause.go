@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/spf13/cobra"
+)
+
+type pauseState struct {
+	*dbState
+	dst      string
+	listen   bool
+	route    bool
+	from     string
+	until    string
+	duration time.Duration
+	reason   string
+}
+
+func newPauseState() *pauseState {
+	return &pauseState{dbState: newDBState()}
+}
+
+// kinds returns the paused kinds selected by the flags, both for a chain without flags
+func (p *pauseState) kinds() ([]string, error) {
+	if p.dst != "" {
+		if p.listen {
+			return nil, fmt.Errorf("--listen cannot be used with --dst, listening is paused per chain")
+		}
+		return []string{types.PauseRoute}, nil
+	}
+	var kinds []string
+	if p.listen {
+		kinds = append(kinds, types.PauseListen)
+	}
+	if p.route {
+		kinds = append(kinds, types.PauseRoute)
+	}
+	if len(kinds) == 0 {
+		kinds = []string{types.PauseListen, types.PauseRoute}
+	}
+	return kinds, nil
+}
+
+// window parses the maintenance window flags into unix times
+func (p *pauseState) window() (int64, int64, error) {
+	var from, until int64
+	start := time.Now()
+	if p.from != "" {
+		t, _, err := parseReportTime(p.from)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid --from: %w", err)
+		}
+		from, start = t.Unix(), t
+	}
+	switch {
+	case p.until != "" && p.duration != 0:
+		return 0, 0, fmt.Errorf("can't pass both --until and --for, must pick one")
+	case p.until != "":
+		t, _, err := parseReportTime(p.until)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid --until: %w", err)
+		}
+		until = t.Unix()
+	case p.duration != 0:
+		until = start.Add(p.duration).Unix()
+	}
+	return from, until, nil
+}
+
+func (p *pauseState) targetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.dst, "dst", "", "pause the routing of the route to dst only")
+	cmd.Flags().BoolVar(&p.listen, "listen", false, "listening to the chain")
+	cmd.Flags().BoolVar(&p.route, "route", false, "routing the messages to and from the chain")
+}
+
+func chainsPauseCmd(a *appState) *cobra.Command {
+	state := newPauseState()
+	cmd := &cobra.Command{
+		Use:   "pause chain-nid",
+		Short: "Pauses listening to a chain or routing on a chain or a route",
+		Long: "Pauses listening to the chain and routing its messages, or only one of them with --listen or --route.\n" +
+			"The pause survives restarts, with --from and --until or --for it is a maintenance window resumed automatically.",
+		Args: withUsage(cobra.ExactArgs(1)),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s chains pause 0x2.icon
+$ %s chains pause 0x2.icon --listen --reason "contract upgrade"
+$ %s chains pause 0x2.icon --dst archway --from 2024-10-01T10:00:00Z --for 2h`, appName, appName, appName)),
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			return state.closeSocket()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kinds, err := state.kinds()
+			if err != nil {
+				return err
+			}
+			from, until, err := state.window()
+			if err != nil {
+				return err
+			}
+			client, err := state.getSocket(a)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			for _, kind := range kinds {
+				pause := &types.Pause{
+					Kind:   kind,
+					Src:    args[0],
+					Dst:    state.dst,
+					Reason: state.reason,
+					From:   from,
+					Until:  until,
+				}
+				if err := client.Pause(pause); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "paused %s\n", pause.ID())
+			}
+			return nil
+		},
+	}
+	state.targetFlags(cmd)
+	cmd.Flags().StringVar(&state.from, "from", "", "start of the maintenance window (YYYY-MM-DD or RFC3339), default now")
+	cmd.Flags().StringVar(&state.until, "until", "", "automatic resume time (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().DurationVar(&state.duration, "for", 0, "automatic resume after the duration")
+	cmd.Flags().StringVar(&state.reason, "reason", "", "reason of the pause")
+	return cmd
+}
+
+func chainsResumeCmd(a *appState) *cobra.Command {
+	state := newPauseState()
+	cmd := &cobra.Command{
+		Use:   "resume chain-nid",
+		Short: "Resumes listening to a chain or routing on a chain or a route",
+		Args:  withUsage(cobra.ExactArgs(1)),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s chains resume 0x2.icon
+$ %s chains resume 0x2.icon --dst archway`, appName, appName)),
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			return state.closeSocket()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kinds, err := state.kinds()
+			if err != nil {
+				return err
+			}
+			client, err := state.getSocket(a)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			for _, kind := range kinds {
+				if err := client.Resume(kind, args[0], state.dst); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "resumed %s\n", (&types.Pause{Kind: kind, Src: args[0], Dst: state.dst}).ID())
+			}
+			return nil
+		},
+	}
+	state.targetFlags(cmd)
+	return cmd
+}
+
+func chainsPausesCmd(a *appState) *cobra.Command {
+	state := newPauseState()
+	cmd := &cobra.Command{
+		Use:   "pauses",
+		Short: "Lists the paused chains and routes and the maintenance windows",
+		Args:  withUsage(cobra.NoArgs),
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			return state.closeSocket()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := state.getSocket(a)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			pauses, err := client.ListPauses()
+			if err != nil {
+				return err
+			}
+			now := time.Now().Unix()
+			printLabels("Target", "Active", "From", "Until", "Reason")
+			for _, pause := range pauses {
+				printValues(pause.ID(), pause.IsActive(now), pauseTime(pause.From), pauseTime(pause.Until), pause.Reason)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func pauseTime(t int64) string {
+	if t == 0 {
+		return "-"
+	}
+	return time.Unix(t, 0).Format(time.RFC3339)
+}
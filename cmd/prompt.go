@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/jsonschema"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"gopkg.in/yaml.v3"
+)
+
+// detectNetworkTimeout bounds the detection of the network from the endpoints
+const detectNetworkTimeout = 30 * time.Second
+
+// chainPrompt asks for a field of a chain config, the fields are asked in order
+type chainPrompt struct {
+	field string
+	def   string
+	// optional fields are left out when no value is given
+	optional bool
+	// network returns the default value from the network detected with the
+	// endpoints asked before
+	network func(network, chainName string) string
+}
+
+// nidFromChainID suggests the nid of the evm and icon chains, prefixed by their chain id
+func nidFromChainID(network, chainName string) string {
+	return network + "." + chainName
+}
+
+// fromNetwork uses the detected network as value
+func fromNetwork(network, _ string) string {
+	return network
+}
+
+// chainPrompts are the fields asked by chains add --interactive, the other
+// fields keep their default values and can be edited in the config file
+var chainPrompts = map[string][]chainPrompt{
+	"evm": {
+		{field: "rpc-url"},
+		{field: "websocket-url"},
+		{field: "nid", network: nidFromChainID},
+		{field: "address", optional: true},
+		{field: "contracts.xcall"},
+		{field: "contracts.connection"},
+		{field: "start-height", optional: true},
+	},
+	"icon": {
+		{field: "rpc-url"},
+		{field: "nid", network: nidFromChainID},
+		{field: "address", optional: true},
+		{field: "contracts.xcall"},
+		{field: "contracts.connection"},
+		{field: "start-height", optional: true},
+	},
+	"cosmos": {
+		{field: "rpc-url"},
+		{field: "nid", network: fromNetwork},
+		{field: "account-prefix"},
+		{field: "denomination"},
+		{field: "gas-prices"},
+		{field: "keyring-backend", def: "memory"},
+		{field: "address", optional: true},
+		{field: "contracts.xcall"},
+		{field: "contracts.connection"},
+		{field: "start-height", optional: true},
+	},
+	"solana": {
+		{field: "rpc-url"},
+		{field: "nid"},
+		{field: "address", optional: true},
+		{field: "xcall-program"},
+		{field: "connection-program"},
+		{field: "alt-address", optional: true},
+	},
+	"stellar": {
+		{field: "horizon-url"},
+		{field: "soroban-url"},
+		{field: "network-passphrase", network: fromNetwork},
+		{field: "nid"},
+		{field: "address", optional: true},
+		{field: "contracts.xcall"},
+		{field: "contracts.connection"},
+		{field: "start-height", optional: true},
+	},
+	"sui": {
+		{field: "rpc-url"},
+		{field: "chain-id", network: fromNetwork},
+		{field: "nid"},
+		{field: "address", optional: true},
+		{field: "xcall-package-id"},
+		{field: "xcall-storage-id"},
+		{field: "connection-module"},
+		{field: "connection-id"},
+		{field: "connection-cap-id"},
+	},
+	"plugin": {
+		{field: "command"},
+		{field: "nid"},
+		{field: "address", optional: true},
+		{field: "start-height", optional: true},
+	},
+}
+
+// prompter reads the answers of the interactive commands
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{in: bufio.NewReader(in), out: out}
+}
+
+// ask returns the answer to the question, or def when the answer is empty
+func (p *prompter) ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	line, err := p.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("no answer to %s: %w", question, err)
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
+
+// askChain asks for the fields of the chain type and returns the config,
+// the values are checked against the schema of the chain type as they are given
+func (p *prompter) askChain(ctx context.Context, chainType, chainName string) (provider.Config, error) {
+	s, err := chainConfigSchema(chainType)
+	if err != nil {
+		return nil, err
+	}
+	value := &yaml.Node{Kind: yaml.MappingNode}
+	var network string
+	var detected bool
+	for _, prompt := range chainPrompts[chainType] {
+		def := prompt.def
+		if prompt.network != nil {
+			if !detected {
+				network = p.detectNetwork(ctx, chainType, value)
+				detected = true
+			}
+			if network != "" {
+				def = prompt.network(network, chainName)
+			}
+		}
+		question := prompt.field
+		if prompt.optional {
+			question += " (optional)"
+		}
+		if doc := promptDoc(chainType, prompt.field); doc != "" {
+			fmt.Fprintf(p.out, "# %s\n", doc)
+		}
+		for {
+			answer, err := p.ask(question, def)
+			if err != nil {
+				return nil, err
+			}
+			if answer == "" {
+				if prompt.optional {
+					break
+				}
+				fmt.Fprintf(p.out, "%s is required\n", prompt.field)
+				continue
+			}
+			setNodeField(value, prompt.field, answer)
+			if errs := fieldErrors(s, value, prompt.field); len(errs) > 0 {
+				for _, e := range errs {
+					fmt.Fprintf(p.out, "%s: %s\n", prompt.field, e.Message)
+				}
+				deleteNodeField(value, prompt.field)
+				continue
+			}
+			break
+		}
+	}
+	return decodeChainConfig(chainType, value)
+}
+
+// detectNetwork reads the network of the endpoints given so far, the
+// network is not detected for the chain types without NetworkDetector
+func (p *prompter) detectNetwork(ctx context.Context, chainType string, value *yaml.Node) string {
+	cfg, err := decodeChainConfig(chainType, value)
+	if err != nil {
+		return ""
+	}
+	detector, ok := cfg.(provider.NetworkDetector)
+	if !ok {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(ctx, detectNetworkTimeout)
+	defer cancel()
+	network, err := detector.DetectNetwork(ctx)
+	if err != nil {
+		fmt.Fprintf(p.out, "the network could not be detected: %v\n", err)
+		return ""
+	}
+	fmt.Fprintf(p.out, "detected network: %s\n", network)
+	return network
+}
+
+// promptDoc returns the description of the field, the contracts are described by their map
+func promptDoc(chainType, field string) string {
+	if doc := chainFieldDoc(chainType, field); doc != "" {
+		return doc
+	}
+	parent, _, _ := strings.Cut(field, ".")
+	return chainFieldDoc(chainType, parent)
+}
+
+func decodeChainConfig(chainType string, value *yaml.Node) (provider.Config, error) {
+	cfg, err := newChainConfig(chainType)
+	if err != nil {
+		return nil, err
+	}
+	if err := value.Decode(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// setNodeField sets the dotted field of the mapping to a plain scalar, the
+// tag of the scalar is resolved from the field it is decoded into
+func setNodeField(n *yaml.Node, field, value string) {
+	key, rest, nested := strings.Cut(field, ".")
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value != key {
+			continue
+		}
+		if nested {
+			setNodeField(n.Content[i+1], rest, value)
+		} else {
+			n.Content[i+1] = &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+		}
+		return
+	}
+	child := &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+	if nested {
+		child = &yaml.Node{Kind: yaml.MappingNode}
+		setNodeField(child, rest, value)
+	}
+	n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+}
+
+// deleteNodeField removes the dotted field of the mapping
+func deleteNodeField(n *yaml.Node, field string) {
+	key, rest, nested := strings.Cut(field, ".")
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value != key {
+			continue
+		}
+		if nested {
+			deleteNodeField(n.Content[i+1], rest)
+		} else {
+			n.Content = append(n.Content[:i], n.Content[i+2:]...)
+		}
+		return
+	}
+}
+
+// fieldErrors returns the schema errors of the field of the value
+func fieldErrors(s *jsonschema.Schema, value *yaml.Node, field string) []*jsonschema.Error {
+	var errs []*jsonschema.Error
+	for _, e := range s.Validate(value) {
+		if e.Path == field && !e.Unknown {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
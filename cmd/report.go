@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/fee"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/cobra"
+)
+
+const reportDateLayout = "2006-01-02"
+
+type reportState struct {
+	*dbState
+	app    *appState
+	from   string
+	to     string
+	format string
+	output string
+}
+
+func newReportState(a *appState) *reportState {
+	return &reportState{
+		dbState: newDBState(),
+		app:     a,
+	}
+}
+
+func reportCmd(a *appState) *cobra.Command {
+	state := newReportState(a)
+	report := &cobra.Command{
+		Use:   "report",
+		Short: "Reports of the relayer activity",
+	}
+	report.AddCommand(state.costs())
+	return report
+}
+
+func (r *reportState) costs() *cobra.Command {
+	costs := &cobra.Command{
+		Use:   "costs",
+		Short: "Delivery spend, claimed fees and net margin per route and chain",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s report costs --from 2024-10-01 --to 2024-10-31
+$ %s report costs --from 2024-10-01 --format csv --output costs.csv`, appName, appName)),
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			return r.closeSocket()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, to, err := r.timeRange()
+			if err != nil {
+				return err
+			}
+			client, err := r.getSocket(r.app)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			report, err := client.CostReport(from.Unix(), to.Unix())
+			if err != nil {
+				return err
+			}
+
+			out := io.Writer(os.Stdout)
+			if r.output != "" {
+				f, err := os.Create(r.output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				out = f
+			}
+			return writeCostReport(out, report, r.format)
+		},
+	}
+	costs.Flags().StringVar(&r.from, "from", "", "start date (YYYY-MM-DD or RFC3339), default all time")
+	costs.Flags().StringVar(&r.to, "to", "", "end date (YYYY-MM-DD or RFC3339), default now")
+	costs.Flags().StringVar(&r.format, "format", "table", "output format: table, json or csv")
+	costs.Flags().StringVarP(&r.output, "output", "o", "", "write the report to the file")
+	return costs
+}
+
+// timeRange parses the from and to flags, a date without time covers the whole day
+func (r *reportState) timeRange() (time.Time, time.Time, error) {
+	from, to := time.Unix(0, 0), time.Now()
+	if r.from != "" {
+		t, _, err := parseReportTime(r.from)
+		if err != nil {
+			return from, to, fmt.Errorf("invalid --from: %w", err)
+		}
+		from = t
+	}
+	if r.to != "" {
+		t, dateOnly, err := parseReportTime(r.to)
+		if err != nil {
+			return from, to, fmt.Errorf("invalid --to: %w", err)
+		}
+		if dateOnly {
+			t = t.Add(24*time.Hour - time.Second)
+		}
+		to = t
+	}
+	if to.Before(from) {
+		return from, to, fmt.Errorf("--to is before --from")
+	}
+	return from, to, nil
+}
+
+func parseReportTime(value string) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation(reportDateLayout, value, time.Local); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
+func writeCostReport(w io.Writer, report *fee.Report, format string) error {
+	switch format {
+	case "json":
+		enc := jsoniter.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case "csv":
+		return report.WriteCSV(w)
+	case "table":
+		fmt.Fprintf(w, "%-12s %-12s %-18s %-10s %-8s %-24s %-24s %-24s\n", "Src", "Dst", "Event", "Delivered", "Failed", "Spent", "FeePaid", "Margin")
+		for _, route := range report.Routes {
+			margin := "-"
+			if route.Margin != nil {
+				margin = route.Margin.String()
+			}
+			fmt.Fprintf(w, "%-12s %-12s %-18s %-10d %-8d %-24s %-24s %-24s\n",
+				route.Src, route.Dst, route.EventType, route.Deliveries, route.Failed, route.Spent, route.FeePaid, margin)
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%-12s %-10s %-8s %-24s %-24s %-24s\n", "Chain", "Delivered", "Failed", "Spent", "Earned", "Net")
+		for _, c := range report.Chains {
+			fmt.Fprintf(w, "%-12s %-10d %-8d %-24s %-24s %-24s\n", c.Chain, c.Deliveries, c.Failed, c.Spent, c.Earned, c.Net)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format %q, expected table, json or csv", format)
+}
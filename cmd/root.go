@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/icon-project/centralized-relay/relayer/chains/plugin"
 	zaplogfmt "github.com/jsternberg/zap-logfmt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -79,6 +80,9 @@ func Execute() {
 	}()
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Println(err)
+		cleanup()
+		// scripts checking the config rely on the exit code
+		os.Exit(1)
 	}
 	cleanup()
 }
@@ -160,6 +164,7 @@ func NewRootCmd(log *zap.Logger) *cobra.Command {
 		keystoreCmd(a),
 		contractCMD(a),
 		debugCmd(a),
+		reportCmd(a),
 	)
 	return rootCmd
 }
@@ -209,6 +214,8 @@ func withUsage(inner cobra.PositionalArgs) cobra.PositionalArgs {
 
 // Clean up upon shutdown.
 func cleanup() {
+	// Stop the processes of the plugin chains.
+	plugin.Cleanup()
 	// Force syncing the logs before exit, if anything is buffered.
 	_ = zap.L().Sync()
 }
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/icon-project/centralized-relay/relayer/jsonschema"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// configSchema returns the schema of the config file, the value of a chain is
+// checked against the schema of its type
+func configSchema() *jsonschema.Schema {
+	types := chainTypes()
+	chain := &jsonschema.Schema{
+		Type:     jsonschema.Types{jsonschema.TypeObject},
+		Required: []string{"type", "value"},
+		Properties: map[string]*jsonschema.Schema{
+			"type":  {Type: jsonschema.Types{jsonschema.TypeString}, Enum: types},
+			"value": {Type: jsonschema.Types{jsonschema.TypeObject}},
+		},
+		AdditionalProperties: jsonschema.False,
+	}
+	defs := make(map[string]*jsonschema.Schema, len(types))
+	for _, t := range types {
+		defs[t], _ = chainConfigSchema(t)
+		chain.AllOf = append(chain.AllOf, &jsonschema.Schema{
+			If: &jsonschema.Schema{
+				Required:   []string{"type"},
+				Properties: map[string]*jsonschema.Schema{"type": {Const: t}},
+			},
+			Then: &jsonschema.Schema{
+				Properties: map[string]*jsonschema.Schema{"value": {Ref: "#/$defs/" + t}},
+			},
+		})
+	}
+	return &jsonschema.Schema{
+		Schema: jsonschema.Draft,
+		Title:  "centralized-relay config",
+		Type:   jsonschema.Types{jsonschema.TypeObject},
+		Properties: map[string]*jsonschema.Schema{
+			"version": {
+				Type:        jsonschema.Types{jsonschema.TypeInteger},
+				Description: "Version of the config file, upgraded by config migrate.",
+				Minimum:     new(float64),
+			},
+			"global": jsonschema.Reflect(GlobalConfig{}),
+			"chains": {Type: jsonschema.Types{jsonschema.TypeObject}, AdditionalProperties: chain},
+		},
+		AdditionalProperties: jsonschema.False,
+		Defs:                 defs,
+	}
+}
+
+// schemaOf returns the schema of the config file, of the global config or of a chain type
+func schemaOf(name string) (*jsonschema.Schema, error) {
+	var s *jsonschema.Schema
+	switch name {
+	case "":
+		return configSchema(), nil
+	case "global":
+		s = jsonschema.Reflect(GlobalConfig{})
+		s.Title = "centralized-relay global config"
+	default:
+		var err error
+		if s, err = chainConfigSchema(name); err != nil {
+			return nil, err
+		}
+		s.Title = fmt.Sprintf("centralized-relay %s chain config", name)
+	}
+	s.Schema = jsonschema.Draft
+	return s, nil
+}
+
+// validateConfigSchema checks the config file against its schema, the unknown
+// fields are returned apart as they are ignored when the config is decoded
+func validateConfigSchema(root *yaml.Node) (unknown []*jsonschema.Error, err error) {
+	var errs []error
+	for _, e := range configSchema().Validate(root) {
+		if e.Unknown {
+			unknown = append(unknown, e)
+			continue
+		}
+		errs = append(errs, e)
+	}
+	if len(errs) > 0 {
+		return unknown, fmt.Errorf("config does not match the schema:\n%w", errors.Join(errs...))
+	}
+	return unknown, nil
+}
+
+// warnUnknownFields logs the fields of the config file which are not used
+func warnUnknownFields(log *zap.Logger, unknown []*jsonschema.Error) {
+	for _, e := range unknown {
+		log.Warn("unknown config field is ignored", zap.String("field", e.Path), zap.Int("line", e.Line))
+	}
+}
+
+// Command for printing the json schemas of the config
+func configSchemaCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema [global|" + strings.Join(chainTypes(), "|") + "]",
+		Short: "Prints the json schema of the config file, of the global config or of a chain type",
+		Args:  withUsage(cobra.MaximumNArgs(1)),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s config schema > config.schema.json
+$ %s config schema evm`, appName, appName)),
+		ValidArgs: append([]string{"global"}, chainTypes()...),
+		// the schemas do not depend on the config file
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			log, err := newRootLogger(a.viper.GetString("log-format"), a.viper.GetBool("debug"))
+			if err != nil {
+				return err
+			}
+			a.log = log
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var name string
+			if len(args) > 0 {
+				name = args[0]
+			}
+			s, err := schemaOf(name)
+			if err != nil {
+				return err
+			}
+			out, err := json.MarshalIndent(s, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+			return nil
+		},
+	}
+	return cmd
+}
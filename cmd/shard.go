@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// shardOwnership describes the routes from the chain owned by the configured shard
+func (a *appState) shardOwnership(nid string) string {
+	if a.config.Global == nil || a.config.Global.Shard == nil {
+		return ""
+	}
+	cfg := a.config.Global.Shard
+	var owned []string
+	for dst := range a.config.Chains {
+		if dst != nid && cfg.OwnsRoute(nid, dst) {
+			owned = append(owned, dst)
+		}
+	}
+	if len(owned) == 0 {
+		return fmt.Sprintf(" shard(%s): -", cfg.ID)
+	}
+	slices.Sort(owned)
+	ownership := fmt.Sprintf(" shard(%s): %s", cfg.ID, strings.Join(owned, ","))
+	if len(cfg.EventTypes) > 0 {
+		ownership += fmt.Sprintf(" [%s]", strings.Join(cfg.EventTypes, ","))
+	}
+	return ownership
+}
+
+func chainsShardCmd(a *appState) *cobra.Command {
+	state := newDBState()
+	cmd := &cobra.Command{
+		Use:   "shard",
+		Short: "Shows the routes owned by the running relayer and the overlaps and gaps of the shards",
+		Args:  withUsage(cobra.NoArgs),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s chains shard`, appName)),
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			return state.closeSocket()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := state.getSocket(a)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			status, err := client.GetShardStatus()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "shard: %s\n\n", status.Shard.ID)
+			printLabels("Src", "Dst", "EventTypes")
+			for _, route := range status.Routes {
+				printValues(route.Src, route.Dst, strings.Join(route.EventTypes, ","))
+			}
+			if status.Manifest == nil {
+				return nil
+			}
+			fmt.Fprintln(cmd.OutOrStdout())
+			printLabels("Shard", "Host", "UpdatedAt")
+			for _, entry := range status.Manifest.Shards {
+				printValues(entry.ID, entry.Host, entry.UpdatedAt.Format(time.RFC3339))
+			}
+			if status.Manifest.OK() && len(status.Manifest.Stale) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "\nevery route is owned by a single shard")
+				return nil
+			}
+			if len(status.Manifest.Stale) > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "\nstale shards: %s\n", strings.Join(status.Manifest.Stale, ","))
+			}
+			fmt.Fprintln(cmd.OutOrStdout())
+			printLabels("Coverage", "Src", "Dst", "EventType", "Shards")
+			for _, overlap := range status.Manifest.Overlaps {
+				printValues("overlap", overlap.Src, overlap.Dst, overlap.EventType, strings.Join(overlap.Owners, ","))
+			}
+			for _, gap := range status.Manifest.Gaps {
+				printValues("gap", gap.Src, gap.Dst, gap.EventType, "-")
+			}
+			return nil
+		},
+	}
+	return cmd
+}
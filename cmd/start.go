@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/icon-project/centralized-relay/relayer"
 	"github.com/icon-project/centralized-relay/relayer/lvldb"
+	"github.com/icon-project/centralized-relay/relayer/metrics"
 	"github.com/icon-project/centralized-relay/relayer/socket"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -45,6 +50,27 @@ func startCmd(a *appState) *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("error creating new relayer %v", err)
 			}
+			if err := a.config.Global.configureRelayer(rly); err != nil {
+				return err
+			}
+			rly.SetConfigLoader(func(ctx context.Context) (*relayer.LoadedConfig, error) {
+				return a.reloadConfigFile()
+			}, a.config.loaded)
+			go reloadOnSignal(cmd.Context(), a, rly)
+
+			metricsAddr, err := cmd.Flags().GetString(flagMetricsAddr)
+			if err != nil {
+				return err
+			}
+			if metricsAddr != "" {
+				server := metrics.NewServer(metricsAddr)
+				go func() {
+					if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+						a.log.Error("metrics server stopped", zap.Error(err))
+					}
+				}()
+				defer server.Close()
+			}
 
 			rlyErrCh, err := rly.Start(cmd.Context(), flushInterval, fresh)
 			if err != nil {
@@ -70,5 +96,24 @@ func startCmd(a *appState) *cobra.Command {
 	}
 	cmd = flushIntervalFlag(a.viper, cmd)
 	cmd = freshFlag(a.viper, cmd)
+	cmd = metricsAddrFlag(a.viper, cmd)
 	return cmd
 }
+
+// reloadOnSignal reloads the chains config on SIGHUP
+func reloadOnSignal(ctx context.Context, a *appState, rly *relayer.Relayer) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigCh:
+			a.log.Info("received SIGHUP, reloading config", zap.String("path", a.configPath))
+			if _, err := rly.ReloadConfig(ctx); err != nil {
+				a.log.Error("failed to reload config", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/icon-project/centralized-relay/relayer/jsonschema"
+)
+
+// chainFieldDocs describe the fields of the chain configs by their path, the
+// docs of a single chain type are keyed by type:path and take precedence
+var chainFieldDocs = map[string]string{
+	"rpc-url":                           "Primary rpc endpoint of the chain.",
+	"rpc-urls":                          "Fallback rpc endpoints, tried in order when the primary one is unhealthy.",
+	"rpc-pool":                          "Health checks and rate limit of the rpc endpoints, zero values use the defaults.",
+	"rpc-pool.health-interval":          "Interval of the health checks of the endpoints.",
+	"rpc-pool.max-lag":                  "Number of blocks an endpoint may lag behind the highest one.",
+	"rpc-pool.max-error-rate":           "Ratio of failed requests between two checks above which an endpoint is unhealthy.",
+	"rpc-pool.min-requests":             "Number of requests between two checks below which the error rate is ignored.",
+	"rpc-pool.failure-threshold":        "Consecutive failed requests which fail over without waiting for the next check.",
+	"rpc-pool.recover-checks":           "Passing checks before an unhealthy endpoint is used again.",
+	"rpc-pool.rate-limit":               "Requests per second sent to each endpoint, unlimited when 0.",
+	"rpc-pool.burst":                    "Requests sent at once under the rate limit, the rate limit by default.",
+	"rpc-auth":                          "Authentication of the requests to the endpoints, the secrets are a value or {env: NAME} or {file: path}.",
+	"rpc-auth.headers":                  "Headers sent with every request, e.g. x-api-key: {env: RPC_API_KEY}.",
+	"rpc-auth.basic":                    "Basic authorization.",
+	"rpc-auth.basic.username":           "Username of the basic authorization.",
+	"rpc-auth.basic.password":           "Password of the basic authorization.",
+	"rpc-auth.bearer-token":             "Static bearer token.",
+	"rpc-auth.jwt":                      "HS256 token signed for every request, as required by the authenticated rpc of the ethereum clients.",
+	"rpc-auth.jwt.secret":               "Hex encoded secret of the tokens, the raw value is used when it is not hex.",
+	"rpc-auth.jwt.claims":               "Claims added to the iat claim of the tokens.",
+	"http-options":                      "Proxy and tls settings of the connections to the endpoints.",
+	"http-options.proxy":                "Url of the http proxy, HTTP_PROXY and HTTPS_PROXY are used when empty.",
+	"http-options.ca-file":              "Pem certificates trusted in addition to the system ones.",
+	"http-options.cert-file":            "Client certificate sent to the endpoints.",
+	"http-options.key-file":             "Key of the client certificate.",
+	"http-options.insecure-skip-verify": "Disables the verification of the certificates of the endpoints.",
+	"start-height":                      "Height the listener starts from, 0 resumes from the last processed height.",
+	"address":                           "Address of the relayer wallet, set by keystore init and keystore import.",
+	"contracts":                         "Contract addresses keyed by xcall, connection and aggregation.",
+	"finality-block":                    "Number of blocks after which a block is final.",
+	"nid":                               "Network id of the chain in xcall.",
+	"decimals":                          "Decimals of the native coin.",
+	"disabled":                          "Leaves the chain out of the relay.",
+	"cluster-mode":                      "Set from the cluster-mode of the global config.",
+	"home-dir":                          "Set to the home directory of the relayer when the chain is loaded.",
+	"poll-interval":                     "Interval of the queries of new events.",
+	"chain-id":                          "Chain id of the network.",
+	"block-batch-size":                  "Number of blocks queried at once while catching up.",
+	"gas-limit":                         "Maximum gas of a transaction.",
+	"gas-adjustment":                    "Percentage added to the estimated gas.",
+
+	"evm:nid":                "Network id of the chain in xcall, 0x<chain id in hex>.<name>, e.g. 0xa869.fuji.",
+	"evm:contracts":          "Contract addresses keyed by xcall and connection, 0x prefixed.",
+	"evm:websocket-url":      "Websocket endpoint the listener subscribes to.",
+	"evm:websocket-urls":     "Fallback websocket endpoints, tried in order after websocket-url.",
+	"evm:use-legacy-fee":     "Sends legacy transactions with a gas price instead of EIP-1559 fees.",
+	"evm:gas-limit":          "Maximum fee of a transaction, in wei, unlimited when 0.",
+	"evm:gas-adjustment":     "Percentage added to the estimated gas limit and gas price.",
+	"evm:max-in-flight":      "Transactions sent before the previous ones are mined.",
+	"evm:bump-interval":      "Wait before a pending transaction is replaced with a higher fee, 0 disables the replacements.",
+	"evm:bump-percent":       "Fee increase of the replacement transactions.",
+	"evm:max-gas-price":      "Cap of the gas price or fee cap of the replacement transactions, in wei.",
+	"evm:fee-history-blocks": "Blocks of eth_feeHistory used to price the transactions.",
+	"evm:max-fee-per-gas":    "Cap of the fee cap or of the legacy gas price, in wei.",
+	"evm:max-priority-fee":   "Cap of the priority fee, in wei.",
+	"evm:urgency":            "Urgency of the transactions per event type, low, medium or high, medium by default.",
+	"evm:l2-type":            "Adds the L1 data fee of the rollup to the gas estimation, optimism or arbitrum.",
+
+	"icon:nid":                "Network id of the chain in xcall, 0x<network id in hex>.<name>, e.g. 0x2.icon.",
+	"icon:contracts":          "Contract addresses keyed by xcall and connection, cx prefixed.",
+	"icon:address":            "Address of the relayer wallet, hx prefixed, set by keystore init and keystore import.",
+	"icon:skip-simulation":    "Sends the transactions with step-default steps without estimating them.",
+	"icon:step-default":       "Steps of the transactions when the estimation is skipped.",
+	"icon:step-min":           "Minimum steps of a transaction.",
+	"icon:step-limit":         "Maximum steps of a transaction.",
+	"icon:step-adjustment":    "Percentage added to the estimated steps, 50 when 0.",
+	"icon:listener-mode":      "How the listener reads the events: websocket, polling, or auto polling after websocket-failures consecutive websocket failures. auto when empty.",
+	"icon:websocket-failures": "Consecutive websocket failures after which the auto mode polls the blocks, 3 when 0.",
+	"icon:websocket-retry":    "How long the auto mode polls before it tries the websocket again, 5m when 0.",
+	"icon:poll-interval":      "Wait for new blocks when polling, 2s when 0.",
+	"icon:poll-batch-size":    "Number of calls sent in a json-rpc batch when polling, 20 when 0.",
+
+	"cosmos:nid":                      "Network id of the chain in xcall, the chain id of the node.",
+	"cosmos:contracts":                "Contract addresses keyed by xcall and connection, bech32 with the account-prefix.",
+	"cosmos:keyring-backend":          "Backend of the keyring, e.g. memory or test.",
+	"cosmos:keyring-dir":              "Directory of the keyring, <home>/<nid> when empty.",
+	"cosmos:account-prefix":           "Bech32 prefix of the addresses, e.g. archway.",
+	"cosmos:denomination":             "Denomination of the fees, e.g. aconst.",
+	"cosmos:gas-prices":               "Gas prices of the transactions, e.g. 900000000000aconst.",
+	"cosmos:gas-adjustment":           "Multiplier of the simulated gas, e.g. 1.5.",
+	"cosmos:min-gas-amount":           "Minimum gas of a transaction.",
+	"cosmos:max-gas-amount":           "Maximum gas of a transaction.",
+	"cosmos:tx-confirmation-interval": "Interval of the checks of the sent transactions.",
+	"cosmos:broadcast-mode":           "Broadcast mode of the transactions, sync, async or block, sync is recommended.",
+	"cosmos:sign-mode":                "Sign mode of the transactions, e.g. direct.",
+	"cosmos:simulate":                 "Simulates the transactions to estimate their gas.",
+	"cosmos:extra-codecs":             "Comma separated extra codecs, e.g. injective.",
+
+	"solana:address":               "Base58 address of the relayer wallet, set by keystore init and keystore import.",
+	"solana:xcall-program":         "Base58 id of the xcall program.",
+	"solana:connection-program":    "Base58 id of the connection program.",
+	"solana:other-connections":     "Ids of the other connection programs of the relayer.",
+	"solana:dapps":                 "Dapp programs called by xcall.",
+	"solana:dapps[].name":          "Name of the dapp.",
+	"solana:dapps[].program-id":    "Base58 id of the dapp program.",
+	"solana:dapps[].config-prefix": "Seed prefix of the config account of the dapp.",
+	"solana:dapps[].other-prefix":  "Seed prefixes of the other accounts of the dapp.",
+	"solana:cp-nids":               "Network ids of the counterparty chains, e.g. 0x2.icon.",
+	"solana:alt-address":           "Address lookup table of the transactions.",
+	"solana:start-tx-sign":         "Signature of the transaction the listener starts after, empty resumes from the last processed one.",
+	"solana:tx-confirmation-time":  "Wait for the confirmation of the sent transactions.",
+	"solana:compute-unit-limit":    "Compute unit limit of the transactions.",
+	"solana:priority-fee-limit":    "Cap of the priority fee of the transactions, in micro lamports per compute unit.",
+
+	"stellar:chain-id":           "Chain id of the network.",
+	"stellar:horizon-url":        "Primary horizon endpoint.",
+	"stellar:soroban-url":        "Primary soroban rpc endpoint.",
+	"stellar:horizon-urls":       "Fallback horizon endpoints, tried in order.",
+	"stellar:soroban-urls":       "Fallback soroban rpc endpoints, tried in order.",
+	"stellar:address":            "G... address of the relayer wallet, set by keystore init and keystore import.",
+	"stellar:contracts":          "C... contract addresses keyed by xcall and connection.",
+	"stellar:max-inclusion-fee":  "Inclusion fee added to the resource fee of the transactions, in stroops.",
+	"stellar:network-passphrase": "Passphrase of the network, e.g. Test SDF Network ; September 2015.",
+	"stellar:start-height":       "Ledger the listener starts from, 0 resumes from the last processed ledger.",
+
+	"sui:chain-id":                    "Chain identifier of the network returned by sui_getChainIdentifier.",
+	"sui:address":                     "0x address of the relayer wallet, set by keystore init and keystore import.",
+	"sui:xcall-package-id":            "Id of the xcall package.",
+	"sui:xcall-storage-id":            "Id of the xcall storage object.",
+	"sui:connection-module":           "Module of the connection in the xcall package.",
+	"sui:connection-id":               "Id of the connection.",
+	"sui:connection-cap-id":           "Id of the capability object of the connection.",
+	"sui:dapps":                       "Dapp packages called by xcall.",
+	"sui:dapps[].package-id":          "Id of the dapp package.",
+	"sui:dapps[].constants":           "Object ids of the constants of the dapp keyed by name.",
+	"sui:dapps[].modules":             "Modules of the dapp.",
+	"sui:dapps[].modules[].name":      "Name of the module.",
+	"sui:dapps[].modules[].cap-id":    "Id of the capability object of the module.",
+	"sui:dapps[].modules[].config-id": "Id of the config object of the module.",
+	"sui:gas-limit":                   "Maximum gas of a transaction, in mist.",
+	"sui:start-tx-digest":             "Digest of the transaction the listener starts after, empty resumes from the last processed one.",
+	"plugin:command":                  "Path of the plugin binary serving the chain.",
+	"plugin:args":                     "Arguments of the plugin binary.",
+	"plugin:env":                      "Environment variables of the plugin process, added to the environment of the relayer.",
+	"plugin:start-timeout":            "Time allowed to the plugin process to start, 1m when zero.",
+	"plugin:options":                  "Settings of the chain read by the plugin, sent to it with the other fields.",
+}
+
+// chainFieldDoc returns the description of the field of the chain type
+func chainFieldDoc(chainType, path string) string {
+	if doc, ok := chainFieldDocs[chainType+":"+path]; ok {
+		return doc
+	}
+	return chainFieldDocs[path]
+}
+
+// chainConfigSchema returns the schema of the config of the chain type with
+// the descriptions of its fields
+func chainConfigSchema(chainType string) (*jsonschema.Schema, error) {
+	cfg, ok := chainConfigs[chainType]
+	if !ok {
+		return nil, fmt.Errorf("unknown chain type %s, must be one of %s", chainType, strings.Join(chainTypes(), ", "))
+	}
+	s := jsonschema.Reflect(cfg)
+	s.Describe(func(path string) string {
+		return chainFieldDoc(chainType, path)
+	})
+	return s, nil
+}
+
+// chainTemplate returns a chain file of the chain type with every field
+// commented, for chains add --file
+func chainTemplate(chainType string) ([]byte, error) {
+	s, err := chainConfigSchema(chainType)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# %s chain, edit the values and add it with:\n", chainType)
+	fmt.Fprintf(&b, "#   %s chains add --file <file>.yaml <chain-name>\n", appName)
+	fmt.Fprintf(&b, "# the fields left out or commented out take their default values\n")
+	fmt.Fprintf(&b, "type: %s\nvalue:\n", chainType)
+	for _, line := range bytes.SplitAfter(s.Template(), []byte("\n")) {
+		if len(line) > 0 {
+			b.WriteString("  ")
+			b.Write(line)
+		}
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/kms"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	flagOnline = "online"
+	// onlineCheckTimeout bounds the live checks of a chain
+	onlineCheckTimeout = time.Minute
+)
+
+type checkStatus string
+
+const (
+	checkOK   checkStatus = "ok"
+	checkWarn checkStatus = "warn"
+	checkFail checkStatus = "fail"
+	checkSkip checkStatus = "skip"
+)
+
+type checkResult struct {
+	status checkStatus
+	check  string
+	detail string
+}
+
+// validationSection groups the checks of the global config or of a chain
+type validationSection struct {
+	title   string
+	results []checkResult
+}
+
+func (s *validationSection) add(status checkStatus, check, format string, args ...any) {
+	s.results = append(s.results, checkResult{status, check, fmt.Sprintf(format, args...)})
+}
+
+// addErr adds a failed check for err, or a passed one with the detail when err is nil
+func (s *validationSection) addErr(check string, err error, detail string) {
+	if err != nil {
+		s.add(checkFail, check, "%v", err)
+		return
+	}
+	s.add(checkOK, check, "%s", detail)
+}
+
+func (s *validationSection) count(status checkStatus) int {
+	var n int
+	for _, result := range s.results {
+		if result.status == status {
+			n++
+		}
+	}
+	return n
+}
+
+type validationReport struct {
+	sections []*validationSection
+}
+
+func (r *validationReport) section(title string) *validationSection {
+	s := &validationSection{title: title}
+	r.sections = append(r.sections, s)
+	return s
+}
+
+func (r *validationReport) count(status checkStatus) int {
+	var n int
+	for _, s := range r.sections {
+		n += s.count(status)
+	}
+	return n
+}
+
+func (r *validationReport) print(w io.Writer) {
+	for _, s := range r.sections {
+		fmt.Fprintln(w, s.title)
+		for _, result := range s.results {
+			fmt.Fprintf(w, "  %-6s %-24s %s\n", "["+string(result.status)+"]", result.check, result.detail)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "errors: %d, warnings: %d\n", r.count(checkFail), r.count(checkWarn))
+}
+
+// Command for checking the config file without starting the relayer
+func configValidateCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "validate",
+		Aliases: []string{"v", "check"},
+		Short:   "Checks the config file, --online also checks the chains",
+		Long: `Checks the config file against its schema, then the required fields, the
+address formats, the nids and the contract keys of every chain. With --online the rpc endpoints, the networks, the
+contracts, the keystores and the wallet balances are checked as well.`,
+		Args: withUsage(cobra.NoArgs),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s config validate
+$ %s config validate --online`, appName, appName)),
+		// the config is checked without building the providers of the chains
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			log, err := newRootLogger(a.viper.GetString("log-format"), a.viper.GetBool("debug"))
+			if err != nil {
+				return err
+			}
+			a.log = log
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			online, err := cmd.Flags().GetBool(flagOnline)
+			if err != nil {
+				return err
+			}
+			report, err := a.validateConfigFile(cmd.Context(), online)
+			if err != nil {
+				return err
+			}
+			report.print(cmd.OutOrStdout())
+			if n := report.count(checkFail); n > 0 {
+				return fmt.Errorf("config %s has %d errors", a.configPath, n)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Bool(flagOnline, false, "also check the rpc endpoints, contracts, keystores and balances")
+	return cmd
+}
+
+// validateConfigFile parses the config file and checks the global config and the chains
+func (a *appState) validateConfigFile(ctx context.Context, online bool) (*validationReport, error) {
+	file, err := os.ReadFile(a.configPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(file, &root); err != nil {
+		return nil, fmt.Errorf("error unmarshalling config: %w", err)
+	}
+
+	report := &validationReport{}
+	// the older versions are checked as they are loaded, after their migration
+	version := report.section("version")
+	from, changes, err := migrateConfigNode(&root)
+	switch {
+	case err != nil:
+		version.add(checkFail, "version", "%v", err)
+		return report, nil
+	case from < configVersion:
+		version.add(checkWarn, "version", "version %d is migrated with %d changes on load, config migrate upgrades the file to %d", from, len(changes), configVersion)
+	default:
+		version.add(checkOK, "version", "version %d is current", from)
+	}
+
+	if _, err := resolveConfigNode(&root); err != nil {
+		return nil, fmt.Errorf("error resolving config: %w", err)
+	}
+
+	schema := report.section("schema")
+	schemaErrs := configSchema().Validate(&root)
+	for _, e := range schemaErrs {
+		status := checkFail
+		if e.Unknown {
+			status = checkWarn
+		}
+		schema.add(status, "line "+strconv.Itoa(e.Line), "%s: %s", e.Path, e.Message)
+	}
+	if len(schemaErrs) == 0 {
+		schema.add(checkOK, "schema", "fields and types are valid")
+	}
+	if schema.count(checkFail) > 0 {
+		// the config cannot be decoded, the other checks are skipped
+		return report, nil
+	}
+
+	cfg := &ConfigInputWrapper{}
+	if err := root.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("error unmarshalling config: %w", err)
+	}
+	if cfg.Global == nil {
+		cfg.Global = newDefaultGlobalConfig()
+	}
+
+	global := report.section("global")
+	a.checkGlobalConfig(global, cfg.Global)
+
+	names := make([]string, 0, len(cfg.ProviderConfigs))
+	for name := range cfg.ProviderConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		global.add(checkWarn, "chains", "no chain is configured")
+	}
+
+	// the nids are read from the file as the chains are keyed by them at runtime
+	chainsByNID := make(map[string][]string)
+	for _, name := range names {
+		if _, nid := lookupNode(&root, []string{"chains", name, "value", "nid"}); nid != nil && nid.Value != "" {
+			chainsByNID[nid.Value] = append(chainsByNID[nid.Value], name)
+		}
+	}
+
+	var kmsProvider kms.KMS
+	if online {
+		kmsProvider, err = kms.NewKMSConfig(ctx, &cfg.Global.KMSKeyID)
+		global.addErr("kms", err, "client created")
+	}
+
+	for _, name := range names {
+		pcfg := cfg.ProviderConfigs[name]
+		chainCfg := pcfg.Value.(provider.Config)
+		_, nid := lookupNode(&root, []string{"chains", name, "value", "nid"})
+		title := fmt.Sprintf("%s [%s]", name, pcfg.Type)
+		if nid != nil && nid.Value != "" {
+			title = fmt.Sprintf("%s [%s %s]", name, pcfg.Type, nid.Value)
+		}
+		section := report.section(title)
+		if nid != nil && len(chainsByNID[nid.Value]) > 1 {
+			section.add(checkFail, "nid", "%s is used by the chains %s", nid.Value, strings.Join(chainsByNID[nid.Value], ", "))
+		}
+		checkChainConfig(section, chainCfg)
+		if !online {
+			continue
+		}
+		if !chainCfg.Enabled() {
+			section.add(checkSkip, "online", "chain is disabled")
+			continue
+		}
+		a.checkChainOnline(ctx, section, name, chainCfg, kmsProvider)
+	}
+	return report, nil
+}
+
+func (a *appState) checkGlobalConfig(s *validationSection, global *GlobalConfig) {
+	if global.Timeout != "" {
+		_, err := time.ParseDuration(global.Timeout)
+		s.addErr("timeout", err, global.Timeout)
+	}
+	if global.KMSKeyID == "" {
+		s.add(checkWarn, "kms-key-id", "not set, the keystores cannot be decrypted")
+	} else {
+		s.add(checkOK, "kms-key-id", "set")
+	}
+	if cluster := global.ClusterMode; cluster != nil && cluster.Enabled {
+		if cluster.Key == "" {
+			s.add(checkFail, "cluster-mode", "key is required when enabled")
+		} else {
+			_, err := os.Stat(filepath.Join(a.homePath, "keystore", "cluster", cluster.Key))
+			s.addErr("cluster-mode", err, "key found")
+		}
+	}
+}
+
+// checkChainConfig runs the offline checks of the chain config
+func checkChainConfig(s *validationSection, cfg provider.Config) {
+	checker, ok := cfg.(provider.ConfigChecker)
+	if !ok {
+		s.add(checkSkip, "config", "no offline checks for this chain type")
+		return
+	}
+	errs := checker.CheckConfig()
+	for _, err := range errs {
+		s.add(checkFail, "config", "%v", err)
+	}
+	if len(errs) == 0 {
+		s.add(checkOK, "config", "required fields and addresses are valid")
+	}
+}
+
+// checkChainOnline builds the provider of the chain and checks the endpoints,
+// the network, the contracts, the keystore and the balance of the wallet
+func (a *appState) checkChainOnline(ctx context.Context, s *validationSection, name string, cfg provider.Config, kmsProvider kms.KMS) {
+	ctx, cancel := context.WithTimeout(ctx, onlineCheckTimeout)
+	defer cancel()
+
+	log := a.log.With(zap.String("chain", name))
+	prov, err := cfg.NewProvider(ctx, log, a.homePath, a.debug, name)
+	if err != nil {
+		s.add(checkFail, "rpc", "%v", err)
+		return
+	}
+	if err := prov.Init(ctx, a.homePath, kmsProvider); err != nil {
+		s.add(checkFail, "rpc", "%v", err)
+		return
+	}
+	height, err := prov.QueryLatestHeight(ctx)
+	if err != nil {
+		s.add(checkFail, "rpc", "%v", err)
+		return
+	}
+	s.add(checkOK, "rpc", "latest height %d", height)
+
+	if checker, ok := prov.(provider.NetworkChecker); ok {
+		s.addErr("network", checker.CheckNetwork(ctx), "matches nid "+prov.NID())
+	} else {
+		s.add(checkSkip, "network", "not supported by this chain type")
+	}
+
+	if checker, ok := prov.(provider.ContractChecker); ok {
+		errs := checker.CheckContracts(ctx)
+		contracts := make([]string, 0, len(errs))
+		for contract := range errs {
+			contracts = append(contracts, contract)
+		}
+		sort.Strings(contracts)
+		for _, contract := range contracts {
+			s.addErr("contract "+contract, errs[contract], "deployed")
+		}
+	} else {
+		s.add(checkSkip, "contracts", "not supported by this chain type")
+	}
+
+	if kmsProvider == nil {
+		s.add(checkSkip, "keystore", "kms is not available")
+	} else {
+		err := prov.RestoreKeystore(ctx)
+		if errors.Is(err, os.ErrNotExist) {
+			err = fmt.Errorf("keystore of %s not found: %w", cfg.GetWallet(), err)
+		}
+		s.addErr("keystore", err, "decrypted")
+	}
+
+	balance, err := prov.QueryBalance(ctx, cfg.GetWallet())
+	switch {
+	case err != nil:
+		s.add(checkFail, "balance", "%v", err)
+	case balance.Amount == 0:
+		s.add(checkWarn, "balance", "wallet %s has no balance", cfg.GetWallet())
+	default:
+		s.add(checkOK, "balance", "%s %s", balance.Calculate(), balance.Denom)
+	}
+}
@@ -3,7 +3,12 @@ package relayer
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/icon-project/centralized-relay/relayer/circuit"
+	"github.com/icon-project/centralized-relay/relayer/metrics"
 	"github.com/icon-project/centralized-relay/relayer/provider"
 	"github.com/icon-project/centralized-relay/relayer/types"
 	"go.uber.org/zap"
@@ -16,18 +21,79 @@ type ChainRuntime struct {
 	LastBlockHeight uint64
 	LastSavedHeight uint64
 	MessageCache    *types.MessageCache
+	// Circuit stops routing to the chain when it keeps failing
+	Circuit *circuit.Breaker
+
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stopped bool
+	// draining is set while a paused listener is being waited for
+	draining atomic.Bool
 }
 
 func NewChainRuntime(log *zap.Logger, chain *Chain) (*ChainRuntime, error) {
 	if chain == nil {
 		return nil, fmt.Errorf("failed to construct chain runtime")
 	}
-	return &ChainRuntime{
+	r := &ChainRuntime{
 		log:          log.With(zap.String("nid ", chain.NID())),
 		Provider:     chain.ChainProvider,
 		listenerChan: make(chan *types.BlockInfo, listenerChannelBufferSize),
 		MessageCache: types.NewMessageCache(),
-	}, nil
+		Circuit:      circuit.NewBreaker(chain.NID(), nil),
+	}
+	r.Circuit.OnChange(func(state circuit.State) {
+		r.log.Warn("circuit breaker state changed", zap.Stringer("state", state))
+		metrics.CircuitState.WithLabelValues(chain.NID()).Set(float64(state))
+		if state == circuit.Open {
+			metrics.CircuitTrips.WithLabelValues(chain.NID()).Inc()
+		}
+	})
+	return r, nil
+}
+
+// run returns the context of the listener and the block processor of the chain,
+// it is derived from ctx on the first call and cancelled by stop
+func (r *ChainRuntime) run(ctx context.Context) context.Context {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ctx == nil {
+		r.ctx, r.cancel = context.WithCancel(ctx)
+	}
+	return r.ctx
+}
+
+// stop cancels the listener and the block processor of the chain
+func (r *ChainRuntime) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = true
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// wait blocks until the listener and the block processor returned, false if the timeout elapsed first
+func (r *ChainRuntime) wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func (r *ChainRuntime) isStopped() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stopped
 }
 
 func (r *ChainRuntime) mergeMessages(ctx context.Context, messages []*types.Message) {
@@ -0,0 +1,100 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
+	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// CheckConfig checks the required fields and the addresses of the config
+func (p *Config) CheckConfig() []error {
+	var errs []error
+	if len(p.RPCEndpoints()) == 0 {
+		errs = append(errs, fmt.Errorf("rpc-url or rpc-urls is required"))
+	}
+	if len(rpcpool.Merge(p.WebsocketUrl, p.WebsocketUrls)) == 0 {
+		errs = append(errs, fmt.Errorf("websocket-url or websocket-urls is required"))
+	}
+	if p.NID == "" {
+		errs = append(errs, fmt.Errorf("nid is required"))
+	}
+	if p.Address == "" {
+		errs = append(errs, fmt.Errorf("address is required"))
+	} else if !common.IsHexAddress(p.Address) {
+		errs = append(errs, fmt.Errorf("address %s is not a hex address", p.Address))
+	}
+	if p.Contracts[providerTypes.ConnectionContract] == "" {
+		errs = append(errs, fmt.Errorf("contracts.%s is required", providerTypes.ConnectionContract))
+	}
+	for _, name := range p.Contracts.Names() {
+		addr := p.Contracts[name]
+		if addr != "" && !common.IsHexAddress(addr) {
+			errs = append(errs, fmt.Errorf("contracts.%s %s is not a hex address", name, addr))
+		}
+	}
+	for _, name := range p.Contracts.UnknownKeys() {
+		errs = append(errs, fmt.Errorf("contracts.%s is not a known contract", name))
+	}
+	// the defaults are applied to a copy
+	cfg := *p
+	if err := cfg.sanitize(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// CheckNetwork compares the chain id of the endpoint with the one of the nid
+func (p *Provider) CheckNetwork(ctx context.Context) error {
+	want, ok := providerTypes.ChainIDFromNID(p.NID())
+	if !ok {
+		return fmt.Errorf("nid %s has no chain id prefix", p.NID())
+	}
+	if got := p.client.GetChainID(); got.Cmp(want) != 0 {
+		return fmt.Errorf("rpc chain id 0x%x does not match nid %s", got, p.NID())
+	}
+	return nil
+}
+
+// DetectNetwork reads the chain id of the first rpc endpoint
+func (p *Config) DetectNetwork(ctx context.Context) (string, error) {
+	urls := p.RPCEndpoints()
+	if len(urls) == 0 {
+		return "", fmt.Errorf("rpc-url is required")
+	}
+	auth, err := p.RPCAuthClient()
+	if err != nil {
+		return "", err
+	}
+	rpcClient, err := rpc.DialOptions(ctx, urls[0], dialOptions(auth)...)
+	if err != nil {
+		return "", err
+	}
+	client := ethclient.NewClient(rpcClient)
+	defer client.Close()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("0x%x", chainID), nil
+}
+
+// CheckContracts checks that code is deployed at the contract addresses
+func (p *Provider) CheckContracts(ctx context.Context) map[string]error {
+	errs := make(map[string]error)
+	for name, addr := range p.cfg.Contracts {
+		if addr == "" {
+			continue
+		}
+		code, err := p.client.CodeAt(ctx, common.HexToAddress(addr), nil)
+		if err == nil && len(code) == 0 {
+			err = fmt.Errorf("no contract deployed at %s", addr)
+		}
+		errs[name] = err
+	}
+	return errs
+}
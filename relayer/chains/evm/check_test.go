@@ -0,0 +1,96 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type checkClient struct {
+	IClient
+	chainID *big.Int
+	code    map[common.Address][]byte
+}
+
+func (c *checkClient) GetChainID() *big.Int {
+	return c.chainID
+}
+
+func (c *checkClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.code[contract], nil
+}
+
+const (
+	checkWallet     = "0x0000000000000000000000000000000000000001"
+	checkConnection = "0x0000000000000000000000000000000000000002"
+	checkXcall      = "0x0000000000000000000000000000000000000003"
+)
+
+func checkConfig() *Config {
+	return &Config{
+		CommonConfig: provider.CommonConfig{
+			RPCUrl:  "http://localhost:8545",
+			NID:     "0xa869.fuji",
+			Address: checkWallet,
+			Contracts: providerTypes.ContractConfigMap{
+				providerTypes.ConnectionContract: checkConnection,
+				providerTypes.XcallContract:      checkXcall,
+			},
+		},
+		WebsocketUrl: "ws://localhost:8546",
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.Empty(t, checkConfig().CheckConfig())
+	})
+
+	t.Run("url lists", func(t *testing.T) {
+		cfg := checkConfig()
+		cfg.RPCUrls = []string{cfg.RPCUrl}
+		cfg.WebsocketUrls = []string{cfg.WebsocketUrl}
+		cfg.RPCUrl, cfg.WebsocketUrl = "", ""
+		assert.Empty(t, cfg.CheckConfig())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cfg := checkConfig()
+		cfg.WebsocketUrl = ""
+		cfg.Address = "0x1234"
+		cfg.Contracts["conection"] = checkConnection
+		delete(cfg.Contracts, providerTypes.ConnectionContract)
+		var msgs []string
+		for _, err := range cfg.CheckConfig() {
+			msgs = append(msgs, err.Error())
+		}
+		assert.Equal(t, []string{
+			"websocket-url or websocket-urls is required",
+			"address 0x1234 is not a hex address",
+			"contracts.connection is required",
+			"contracts.conection is not a known contract",
+		}, msgs)
+	})
+}
+
+func TestCheckOnline(t *testing.T) {
+	client := &checkClient{
+		chainID: big.NewInt(0xa869),
+		code:    map[common.Address][]byte{common.HexToAddress(checkConnection): {0x60}},
+	}
+	p := &Provider{cfg: checkConfig(), client: client, cfgMu: new(sync.RWMutex)}
+
+	assert.NoError(t, p.CheckNetwork(context.Background()))
+	client.chainID = big.NewInt(1)
+	assert.Error(t, p.CheckNetwork(context.Background()))
+
+	errs := p.CheckContracts(context.Background())
+	assert.NoError(t, errs[providerTypes.ConnectionContract])
+	assert.Error(t, errs[providerTypes.XcallContract])
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	bridgeContract "github.com/icon-project/centralized-relay/relayer/chains/evm/abi"
+	"github.com/icon-project/centralized-relay/relayer/rpcauth"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	"go.uber.org/zap"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -21,43 +26,65 @@ const (
 	DefaultCreateTimeout         = time.Second * 10
 )
 
-func newClient(ctx context.Context, connectionContract, XcallContract common.Address, rpcUrl,
-	websocketUrl string, l *zap.Logger, clusterMode bool) (IClient, error) {
+var (
+	// receiptPollInterval is the interval of the receipt queries of the transactions sent
+	receiptPollInterval = time.Second
+	// txConfirmationTimeout bounds the wait for the receipt of a transaction
+	// and its replacements
+	txConfirmationTimeout = DefaultTxConfirmationTimeout
+)
+
+func newClient(ctx context.Context, connectionContract, XcallContract common.Address, rpcPool,
+	wsPool *rpcpool.Pool, l *zap.Logger, clusterMode bool) (IClient, error) {
 	createCtx, cancel := context.WithTimeout(ctx, DefaultCreateTimeout)
 	defer cancel()
-	rpc, err := ethclient.DialContext(createCtx, rpcUrl)
+	// the http requests fail over through the transport of the pool, the
+	// websocket is dialed to the current endpoint and dialed again on changes
+	rpcClient, err := rpc.DialOptions(createCtx, rpcPool.URL(), rpc.WithHTTPClient(rpcPool.HTTPClient(nil)))
 	if err != nil {
 		return nil, err
 	}
-	ws, err := ethclient.DialContext(createCtx, websocketUrl)
+	ethRpc := ethclient.NewClient(rpcClient)
+	dialWebsocket := func(ctx context.Context) (*ethclient.Client, error) {
+		wsClient, err := rpc.DialOptions(ctx, wsPool.Current(), dialOptions(wsPool.Auth())...)
+		if err != nil {
+			return nil, err
+		}
+		return ethclient.NewClient(wsClient), nil
+	}
+	ws, err := dialWebsocket(createCtx)
 	if err != nil {
 		return nil, err
 	}
-	connection, err := bridgeContract.NewConnection(connectionContract, ws)
+	// the calls and transactions go through the pool, so they are rate
+	// limited and fail over, the websocket only serves the subscriptions
+	connection, err := bridgeContract.NewConnection(connectionContract, ethRpc)
 	if err != nil {
 		return nil, fmt.Errorf("error occured when creating connection cobtract: %v ", err)
 	}
-	xcall, err := bridgeContract.NewXcall(XcallContract, ws)
+	xcall, err := bridgeContract.NewXcall(XcallContract, ethRpc)
 	if err != nil {
 		return nil, fmt.Errorf("error occured when creating eth client: %v ", err)
 	}
 	// getting the chain id
-	evmChainId, err := ws.ChainID(createCtx)
+	evmChainId, err := ethRpc.ChainID(createCtx)
 	if err != nil {
 		return nil, err
 	}
-	reconnectFunc := func() (IClient, error) {
-		return newClient(ctx, connectionContract, XcallContract, rpcUrl, websocketUrl, l, clusterMode)
+	reconnectFunc := func() (*ethclient.Client, error) {
+		dialCtx, cancel := context.WithTimeout(ctx, DefaultCreateTimeout)
+		defer cancel()
+		return dialWebsocket(dialCtx)
 	}
 	if clusterMode {
-		clusterConnection, err := bridgeContract.NewClusterConnection(connectionContract, ws)
+		clusterConnection, err := bridgeContract.NewClusterConnection(connectionContract, ethRpc)
 		if err != nil {
 			return nil, fmt.Errorf("error occured when creating connection cobtract: %v ", err)
 		}
 		return &Client{
 			log:               l,
 			eth:               ws,
-			ethRpc:            rpc,
+			ethRpc:            ethRpc,
 			EVMChainID:        evmChainId,
 			connection:        connection,
 			clusterConnection: clusterConnection,
@@ -69,7 +96,7 @@ func newClient(ctx context.Context, connectionContract, XcallContract common.Add
 	return &Client{
 		log:        l,
 		eth:        ws,
-		ethRpc:     rpc,
+		ethRpc:     ethRpc,
 		EVMChainID: evmChainId,
 		connection: connection,
 		xcall:      xcall,
@@ -77,16 +104,40 @@ func newClient(ctx context.Context, connectionContract, XcallContract common.Add
 	}, nil
 }
 
+// probeHeight returns the latest block number of the rpc or websocket endpoint
+func probeHeight(ctx context.Context, auth *rpcauth.Client, url string) (uint64, error) {
+	rpcClient, err := rpc.DialOptions(ctx, url, dialOptions(auth)...)
+	if err != nil {
+		return 0, err
+	}
+	client := ethclient.NewClient(rpcClient)
+	defer client.Close()
+	return client.BlockNumber(ctx)
+}
+
+// dialOptions sends the requests and the websocket handshakes with the auth and http options
+func dialOptions(auth *rpcauth.Client) []rpc.ClientOption {
+	return []rpc.ClientOption{
+		rpc.WithHTTPClient(auth.HTTPClient()),
+		rpc.WithWebsocketDialer(*auth.WebsocketDialer()),
+		rpc.WithHTTPAuth(auth.SetHeader),
+	}
+}
+
 // grouped rpc api clients
 type Client struct {
-	log               *zap.Logger
-	eth               *ethclient.Client
+	log *zap.Logger
+	// wsMu guards eth, which is dialed again on reconnect
+	wsMu sync.RWMutex
+	// eth is the websocket client, it only serves the log subscriptions
+	eth *ethclient.Client
+	// ethRpc is the client of the rpc pool, rate limited and failing over
 	ethRpc            *ethclient.Client
 	EVMChainID        *big.Int
 	connection        *bridgeContract.Connection
 	xcall             *bridgeContract.Xcall
 	clusterConnection *bridgeContract.ClusterConnection
-	reconnect         func() (IClient, error)
+	reconnect         func() (*ethclient.Client, error)
 }
 
 type IClient interface {
@@ -100,14 +151,21 @@ type IClient interface {
 	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
 	SuggestGasTip(ctx context.Context) (*big.Int, error)
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
 	PendingNonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 	GetLatestNonce(ctx context.Context, account common.Address) (*big.Int, error)
 	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
 	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethTypes.Receipt, error)
+	TransactionL1Fee(ctx context.Context, txHash common.Hash) (*big.Int, error)
+	TransferredValue(ctx context.Context, txHash common.Hash, from common.Address) (*big.Int, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
 	SendTransaction(ctx context.Context, tx *ethTypes.Transaction) error
 	Subscribe(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethTypes.Log) (ethereum.Subscription, error)
-	Reconnect() (IClient, error)
+	Reconnect() error
+	CloseWebsocket()
+	Close()
 
 	// abiContract for connection
 	ParseConnectionMessage(log ethTypes.Log) (*bridgeContract.ConnectionMessage, error)
@@ -129,7 +187,7 @@ type IClient interface {
 }
 
 func (c *Client) PendingNonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
-	nonce, err := c.eth.PendingNonceAt(ctx, account)
+	nonce, err := c.ethRpc.PendingNonceAt(ctx, account)
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +195,7 @@ func (c *Client) PendingNonceAt(ctx context.Context, account common.Address, blo
 }
 
 func (c *Client) GetLatestNonce(ctx context.Context, account common.Address) (*big.Int, error) {
-	nonce, err := c.eth.NonceAt(ctx, account, nil)
+	nonce, err := c.ethRpc.NonceAt(ctx, account, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -145,18 +203,22 @@ func (c *Client) GetLatestNonce(ctx context.Context, account common.Address) (*b
 }
 
 func (cl *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethTypes.Receipt, error) {
-	return cl.eth.TransactionReceipt(ctx, txHash)
+	return cl.ethRpc.TransactionReceipt(ctx, txHash)
 }
 
 func (cl *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	return cl.eth.CallContract(ctx, msg, blockNumber)
+	return cl.ethRpc.CallContract(ctx, msg, blockNumber)
+}
+
+func (cl *Client) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return cl.ethRpc.CodeAt(ctx, contract, blockNumber)
 }
 
 func (cl *Client) GetBalance(ctx context.Context, hexAddr string) (*big.Int, error) {
 	if !common.IsHexAddress(hexAddr) {
 		return nil, fmt.Errorf("invalid hex address: %v", hexAddr)
 	}
-	return cl.eth.BalanceAt(ctx, common.HexToAddress(hexAddr), nil)
+	return cl.ethRpc.BalanceAt(ctx, common.HexToAddress(hexAddr), nil)
 }
 
 func (cl *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error) {
@@ -166,21 +228,27 @@ func (cl *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]eth
 func (cl *Client) GetBlockNumber(ctx context.Context) (uint64, error) {
 	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
 	defer cancel()
-	return cl.eth.BlockNumber(ctx)
+	return cl.ethRpc.BlockNumber(ctx)
 }
 
 func (cl *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
-	return cl.eth.SuggestGasPrice(ctx)
+	return cl.ethRpc.SuggestGasPrice(ctx)
 }
 
 func (cl *Client) SuggestGasTip(ctx context.Context) (*big.Int, error) {
-	return cl.eth.SuggestGasTipCap(ctx)
+	return cl.ethRpc.SuggestGasTipCap(ctx)
+}
+
+func (cl *Client) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+	return cl.ethRpc.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
 
 func (cl *Client) GetHeaderByHeight(ctx context.Context, height *big.Int) (*ethTypes.Header, error) {
 	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
 	defer cancel()
-	return cl.eth.HeaderByNumber(ctx, height)
+	return cl.ethRpc.HeaderByNumber(ctx, height)
 }
 
 func (c *Client) GetChainID() *big.Int {
@@ -188,6 +256,8 @@ func (c *Client) GetChainID() *big.Int {
 }
 
 func (c *Client) GetEthClient() *ethclient.Client {
+	c.wsMu.RLock()
+	defer c.wsMu.RUnlock()
 	return c.eth
 }
 
@@ -211,8 +281,54 @@ func (c *Client) ReceiveMessageWithSignature(opts *bind.TransactOpts, srcNID str
 	return c.clusterConnection.RecvMessageWithSignatures(opts, srcNID, sn, msg, signatures)
 }
 
+// TransactionL1Fee returns the l1Fee field of the receipt of OP stack chains, nil if absent
+func (c *Client) TransactionL1Fee(ctx context.Context, txHash common.Hash) (*big.Int, error) {
+	var receipt struct {
+		L1Fee *hexutil.Big `json:"l1Fee"`
+	}
+	if err := c.ethRpc.Client().CallContext(ctx, &receipt, "eth_getTransactionReceipt", txHash); err != nil {
+		return nil, err
+	}
+	if receipt.L1Fee == nil {
+		return nil, nil
+	}
+	return receipt.L1Fee.ToInt(), nil
+}
+
+// callFrame is a call of the trace of the callTracer
+type callFrame struct {
+	From  common.Address `json:"from"`
+	Value *hexutil.Big   `json:"value"`
+	Calls []callFrame    `json:"calls"`
+}
+
+// TransferredValue returns the value sent by from in the internal calls of
+// the transaction, it needs the debug api of the endpoint
+func (c *Client) TransferredValue(ctx context.Context, txHash common.Hash, from common.Address) (*big.Int, error) {
+	var trace callFrame
+	if err := c.ethRpc.Client().CallContext(ctx, &trace, "debug_traceTransaction", txHash, map[string]string{"tracer": "callTracer"}); err != nil {
+		return nil, err
+	}
+	return trace.sentBy(from), nil
+}
+
+func (f *callFrame) sentBy(from common.Address) *big.Int {
+	value := new(big.Int)
+	if f.From == from && f.Value != nil {
+		value.Add(value, f.Value.ToInt())
+	}
+	for i := range f.Calls {
+		value.Add(value, f.Calls[i].sentBy(from))
+	}
+	return value
+}
+
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return c.ethRpc.BalanceAt(ctx, account, blockNumber)
+}
+
 func (c *Client) SendTransaction(ctx context.Context, tx *ethTypes.Transaction) error {
-	return c.eth.SendTransaction(ctx, tx)
+	return c.ethRpc.SendTransaction(ctx, tx)
 }
 
 func (c *Client) MessageReceived(opts *bind.CallOpts, srcNetwork string, _connSn *big.Int) (bool, error) {
@@ -240,7 +356,7 @@ func (c *Client) ExecuteCall(opts *bind.TransactOpts, reqID *big.Int, data []byt
 }
 
 func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
-	return c.eth.EstimateGas(ctx, msg)
+	return c.ethRpc.EstimateGas(ctx, msg)
 }
 
 // GetFee
@@ -265,10 +381,32 @@ func (c *Client) ExecuteRollback(opts *bind.TransactOpts, sn *big.Int) (*ethType
 
 // Subscribe
 func (c *Client) Subscribe(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethTypes.Log) (ethereum.Subscription, error) {
-	return c.eth.SubscribeFilterLogs(ctx, q, ch)
+	return c.GetEthClient().SubscribeFilterLogs(ctx, q, ch)
+}
+
+// Reconnect dials the websocket again to the current endpoint of the pool,
+// the rpc client fails over by itself and is kept
+func (c *Client) Reconnect() error {
+	ws, err := c.reconnect()
+	if err != nil {
+		return err
+	}
+	c.wsMu.Lock()
+	old := c.eth
+	c.eth = ws
+	c.wsMu.Unlock()
+	old.Close()
+	return nil
+}
+
+// CloseWebsocket closes the websocket, the subscriptions end with
+// rpc.ErrClientQuit and the listener reconnects
+func (c *Client) CloseWebsocket() {
+	c.GetEthClient().Close()
 }
 
-// Reconnect
-func (c *Client) Reconnect() (IClient, error) {
-	return c.reconnect()
+// Close closes the connections, the subscriptions end with rpc.ErrClientQuit
+func (c *Client) Close() {
+	c.CloseWebsocket()
+	c.ethRpc.Close()
 }
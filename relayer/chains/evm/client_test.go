@@ -0,0 +1,44 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type chainService struct{}
+
+func (chainService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1))
+}
+
+func newInProcClient(t *testing.T) *ethclient.Client {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", chainService{}))
+	t.Cleanup(server.Stop)
+	return ethclient.NewClient(rpc.DialInProc(server))
+}
+
+func TestClientWebsocket(t *testing.T) {
+	ws, ethRpc, next := newInProcClient(t), newInProcClient(t), newInProcClient(t)
+	c := &Client{eth: ws, ethRpc: ethRpc, reconnect: func() (*ethclient.Client, error) { return next, nil }}
+	ctx := context.Background()
+
+	c.CloseWebsocket()
+	_, err := ws.ChainID(ctx)
+	assert.ErrorIs(t, err, rpc.ErrClientQuit)
+	// the rpc client is kept for the calls and transactions
+	_, err = ethRpc.ChainID(ctx)
+	assert.NoError(t, err)
+
+	require.NoError(t, c.Reconnect())
+	assert.Same(t, next, c.GetEthClient())
+	_, err = c.GetEthClient().ChainID(ctx)
+	assert.NoError(t, err)
+}
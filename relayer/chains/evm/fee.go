@@ -0,0 +1,233 @@
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/icon-project/centralized-relay/relayer/jsonschema"
+	"go.uber.org/zap"
+)
+
+// Urgency of the transactions of an event type, higher urgencies pay a higher
+// priority fee and leave more headroom for the base fee to rise
+type Urgency string
+
+const (
+	UrgencyLow    Urgency = "low"
+	UrgencyMedium Urgency = "medium"
+	UrgencyHigh   Urgency = "high"
+
+	DefaultUrgency          = UrgencyMedium
+	DefaultFeeHistoryBlocks = 20
+)
+
+// feeHistoryPercentiles are the priority fee percentiles requested from eth_feeHistory
+var feeHistoryPercentiles = []float64{10, 50, 90}
+
+// urgencyParams are the priority fee percentile index and the number of
+// blocks the base fee may rise by 12.5% before the transaction is priced out
+var urgencyParams = map[Urgency]struct {
+	percentile int
+	headroom   int
+}{
+	UrgencyLow:    {percentile: 0, headroom: 1},
+	UrgencyMedium: {percentile: 1, headroom: 2},
+	UrgencyHigh:   {percentile: 2, headroom: 4},
+}
+
+func (u Urgency) Validate() error {
+	if _, ok := urgencyParams[u]; !ok {
+		return fmt.Errorf("unknown urgency %q, must be low, medium or high", u)
+	}
+	return nil
+}
+
+// JSONSchema lists the urgencies
+func (Urgency) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: jsonschema.Types{jsonschema.TypeString},
+		Enum: []string{string(UrgencyLow), string(UrgencyMedium), string(UrgencyHigh)},
+	}
+}
+
+// urgency returns the urgency configured for the event type
+func (c *Config) urgency(eventType string) Urgency {
+	if urgency, ok := c.Urgency[eventType]; ok {
+		return urgency
+	}
+	return DefaultUrgency
+}
+
+// errMaxFeePerGas is returned when max-fee-per-gas is below the fee of the next
+// block, the transaction would stay pending so the message is retried later
+var errMaxFeePerGas = errors.New("max-fee-per-gas is below the fee of the next block")
+
+// Fees are the gas prices of a transaction, GasPrice for the legacy ones
+type Fees struct {
+	GasPrice  *big.Int
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+}
+
+// setFees prices the transaction of the event type
+func (p *Provider) setFees(ctx context.Context, opts *bind.TransactOpts, eventType string) error {
+	var (
+		fees *Fees
+		err  error
+	)
+	if p.cfg.UseLegacyFee {
+		fees, err = p.legacyFees(ctx)
+	} else {
+		settings := p.settings()
+		fees, err = p.feeHistoryFees(ctx, settings.urgency(eventType))
+		if err != nil && !errors.Is(err, errMaxFeePerGas) {
+			p.log.Warn("fee history unavailable, using the suggested gas price", zap.Error(err))
+			fees, err = p.suggestedFees(ctx)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	opts.GasPrice, opts.GasFeeCap, opts.GasTipCap = fees.GasPrice, fees.GasFeeCap, fees.GasTipCap
+	return nil
+}
+
+// legacyFees returns the suggested gas price with the gas adjustment
+func (p *Provider) legacyFees(ctx context.Context) (*Fees, error) {
+	gasPrice, err := p.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	if err := p.checkFeeCap(gasPrice); err != nil {
+		return nil, err
+	}
+	return &Fees{GasPrice: p.capFee(p.adjust(gasPrice), p.settings().MaxFeePerGas)}, nil
+}
+
+// suggestedFees returns the dynamic fees from the suggested gas price and tip
+func (p *Provider) suggestedFees(ctx context.Context) (*Fees, error) {
+	gasPrice, err := p.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	if err := p.checkFeeCap(gasPrice); err != nil {
+		return nil, err
+	}
+	gasTip, err := p.client.SuggestGasTip(ctx)
+	if err != nil {
+		p.log.Warn("failed to get gas tip", zap.Error(err))
+		gasTip = new(big.Int)
+	}
+	gasTipCap := p.capFee(p.adjust(gasTip), p.settings().MaxPriorityFee)
+	gasFeeCap := p.adjust(gasPrice)
+	if gasFeeCap.Cmp(gasTipCap) != 1 {
+		gasFeeCap = gasFeeCap.Add(gasFeeCap, gasTipCap)
+	}
+	return p.dynamicFees(gasFeeCap, gasTipCap), nil
+}
+
+// feeHistoryFees prices the transaction from the priority fees paid in the last
+// blocks and the next base fee, with a headroom growing with the urgency and
+// when the base fee is rising
+func (p *Provider) feeHistoryFees(ctx context.Context, urgency Urgency) (*Fees, error) {
+	params := urgencyParams[urgency]
+	settings := p.settings()
+	history, err := p.client.FeeHistory(ctx, settings.FeeHistoryBlocks, nil, feeHistoryPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	if len(history.BaseFee) == 0 {
+		return nil, fmt.Errorf("empty fee history")
+	}
+	// the base fees include the one of the next block
+	nextBaseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	headroom := params.headroom
+	if baseFeeRising(history) {
+		headroom++
+	}
+	maxBaseFee := new(big.Int).Set(nextBaseFee)
+	for i := 0; i < headroom; i++ {
+		// the base fee rises by 12.5% at most per block
+		maxBaseFee.Add(maxBaseFee, new(big.Int).Div(maxBaseFee, big.NewInt(8)))
+	}
+
+	gasTipCap := p.capFee(priorityFee(history, params.percentile), settings.MaxPriorityFee)
+	if err := p.checkFeeCap(new(big.Int).Add(nextBaseFee, gasTipCap)); err != nil {
+		return nil, err
+	}
+	gasFeeCap := new(big.Int).Add(maxBaseFee, gasTipCap)
+	return p.dynamicFees(gasFeeCap, gasTipCap), nil
+}
+
+// dynamicFees caps the fee cap, the tip is lowered to the fee cap if above it
+func (p *Provider) dynamicFees(gasFeeCap, gasTipCap *big.Int) *Fees {
+	gasFeeCap = p.capFee(gasFeeCap, p.settings().MaxFeePerGas)
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasTipCap = new(big.Int).Set(gasFeeCap)
+	}
+	return &Fees{GasFeeCap: gasFeeCap, GasTipCap: gasTipCap}
+}
+
+// baseFeeRising returns true if the next base fee is above the average of the window
+func baseFeeRising(history *ethereum.FeeHistory) bool {
+	if len(history.BaseFee) < 2 {
+		return false
+	}
+	past := history.BaseFee[:len(history.BaseFee)-1]
+	sum := new(big.Int)
+	for _, baseFee := range past {
+		sum.Add(sum, baseFee)
+	}
+	average := sum.Div(sum, big.NewInt(int64(len(past))))
+	return history.BaseFee[len(history.BaseFee)-1].Cmp(average) > 0
+}
+
+// priorityFee returns the median of the priority fee percentile of the non empty blocks
+func priorityFee(history *ethereum.FeeHistory, percentile int) *big.Int {
+	var rewards []*big.Int
+	for i, reward := range history.Reward {
+		if i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0 {
+			continue
+		}
+		if percentile < len(reward) && reward[percentile] != nil {
+			rewards = append(rewards, reward[percentile])
+		}
+	}
+	if len(rewards) == 0 {
+		return new(big.Int)
+	}
+	sort.Slice(rewards, func(i, j int) bool {
+		return rewards[i].Cmp(rewards[j]) < 0
+	})
+	return new(big.Int).Set(rewards[len(rewards)/2])
+}
+
+// adjust adds the gas adjustment percentage to the price
+func (p *Provider) adjust(price *big.Int) *big.Int {
+	adjusted := new(big.Int).Mul(price, new(big.Int).SetUint64(100+p.settings().GasAdjustment))
+	return adjusted.Div(adjusted, big.NewInt(100))
+}
+
+// checkFeeCap returns errMaxFeePerGas if max-fee-per-gas is below the fee
+// required by the next block, the headroom and the adjustment are capped
+func (p *Provider) checkFeeCap(required *big.Int) error {
+	max := p.settings().MaxFeePerGas
+	if max > 0 && required.Cmp(new(big.Int).SetUint64(max)) > 0 {
+		return fmt.Errorf("%w: %s > %d", errMaxFeePerGas, required, max)
+	}
+	return nil
+}
+
+// capFee limits the fee to max, 0 is unlimited
+func (p *Provider) capFee(fee *big.Int, max uint64) *big.Int {
+	if max > 0 && fee.Cmp(new(big.Int).SetUint64(max)) > 0 {
+		return new(big.Int).SetUint64(max)
+	}
+	return fee
+}
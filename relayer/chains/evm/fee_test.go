@@ -0,0 +1,112 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type feeHistoryClient struct {
+	IClient
+	history *ethereum.FeeHistory
+}
+
+func (c *feeHistoryClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return c.history, nil
+}
+
+func rewards(values ...int64) [][]*big.Int {
+	var reward [][]*big.Int
+	for _, v := range values {
+		reward = append(reward, []*big.Int{big.NewInt(v), big.NewInt(v * 2), big.NewInt(v * 4)})
+	}
+	return reward
+}
+
+func TestFeeHistoryFees(t *testing.T) {
+	history := &ethereum.FeeHistory{
+		BaseFee:      []*big.Int{big.NewInt(800), big.NewInt(800), big.NewInt(800), big.NewInt(800)},
+		GasUsedRatio: []float64{0.5, 0, 0.5},
+		Reward:       rewards(10, 1000, 30),
+	}
+	p := &Provider{
+		log:    zap.NewNop(),
+		cfg:    &Config{FeeHistoryBlocks: 3},
+		client: &feeHistoryClient{history: history},
+		cfgMu:  new(sync.RWMutex),
+	}
+	ctx := context.Background()
+
+	t.Run("urgency", func(t *testing.T) {
+		// the empty block is ignored, the median of 10 and 30 is 30
+		fees, err := p.feeHistoryFees(ctx, UrgencyLow)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(30), fees.GasTipCap)
+		// one block of headroom: 800 + 100
+		assert.Equal(t, big.NewInt(930), fees.GasFeeCap)
+
+		fees, err = p.feeHistoryFees(ctx, UrgencyHigh)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(120), fees.GasTipCap)
+		// four blocks of headroom: 800, 900, 1012, 1138, 1280
+		assert.Equal(t, big.NewInt(1400), fees.GasFeeCap)
+	})
+
+	t.Run("rising base fee", func(t *testing.T) {
+		history.BaseFee[3] = big.NewInt(1000)
+		defer func() { history.BaseFee[3] = big.NewInt(800) }()
+		fees, err := p.feeHistoryFees(ctx, UrgencyLow)
+		assert.NoError(t, err)
+		// two blocks of headroom: 1000, 1125, 1265
+		assert.Equal(t, big.NewInt(1295), fees.GasFeeCap)
+	})
+
+	t.Run("caps", func(t *testing.T) {
+		p.cfg.MaxPriorityFee = 50
+		p.cfg.MaxFeePerGas = 1000
+		defer func() { p.cfg.MaxPriorityFee, p.cfg.MaxFeePerGas = 0, 0 }()
+		fees, err := p.feeHistoryFees(ctx, UrgencyHigh)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(50), fees.GasTipCap)
+		assert.Equal(t, big.NewInt(1000), fees.GasFeeCap)
+	})
+
+	t.Run("cap below the next block", func(t *testing.T) {
+		// 800 of base fee and 60 of tip at medium urgency
+		p.cfg.MaxFeePerGas = 850
+		defer func() { p.cfg.MaxFeePerGas = 0 }()
+		_, err := p.feeHistoryFees(ctx, UrgencyHigh)
+		assert.ErrorIs(t, err, errMaxFeePerGas)
+
+		// the fee is not priced from the suggested gas price either
+		opts := new(bind.TransactOpts)
+		err = p.setFees(ctx, opts, events.EmitMessage)
+		assert.ErrorIs(t, err, errMaxFeePerGas)
+	})
+}
+
+func TestCallFrameSentBy(t *testing.T) {
+	conn := common.HexToAddress("0x01")
+	relayer := common.HexToAddress("0x02")
+	trace := callFrame{
+		From:  relayer,
+		Value: (*hexutil.Big)(big.NewInt(0)),
+		Calls: []callFrame{
+			{From: conn, Value: (*hexutil.Big)(big.NewInt(700))},
+			{From: conn, Calls: []callFrame{{From: conn, Value: (*hexutil.Big)(big.NewInt(300))}}},
+			{From: relayer, Value: (*hexutil.Big)(big.NewInt(50))},
+		},
+	}
+	assert.Equal(t, big.NewInt(1000), trace.sentBy(conn))
+	assert.Equal(t, big.NewInt(50), trace.sentBy(relayer))
+	assert.Equal(t, 0, trace.sentBy(common.HexToAddress("0x03")).Sign())
+}
@@ -0,0 +1,172 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// L2Optimism prices the L1 data fee with the OP stack GasPriceOracle predeploy
+	L2Optimism = "optimism"
+	// L2Arbitrum prices the L1 data gas with the Arbitrum NodeInterface precompile
+	L2Arbitrum = "arbitrum"
+)
+
+var (
+	opGasPriceOracle = common.HexToAddress("0x420000000000000000000000000000000000000F")
+	arbNodeInterface = common.HexToAddress("0x00000000000000000000000000000000000000C8")
+
+	opGasPriceOracleABI = mustParseABI(`[{"inputs":[{"name":"_data","type":"bytes"}],"name":"getL1Fee","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`)
+	arbNodeInterfaceABI = mustParseABI(`[{"inputs":[{"name":"to","type":"address"},{"name":"contractCreation","type":"bool"},{"name":"data","type":"bytes"}],"name":"gasEstimateL1Component","outputs":[{"name":"gasEstimateForL1","type":"uint64"},{"name":"baseFee","type":"uint256"},{"name":"l1BaseFeeEstimate","type":"uint256"}],"stateMutability":"payable","type":"function"}]`)
+)
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// GasEstimate is the gas of a transaction and its L1 data component on rollups
+type GasEstimate struct {
+	// Gas is the gas estimated by the node, it includes L1Gas on Arbitrum
+	Gas uint64
+	// L1Gas is the gas charged for the L1 data on Arbitrum
+	L1Gas uint64
+	// L1Fee is the L1 data fee charged on top of the gas on OP stack chains
+	L1Fee *big.Int
+}
+
+// ExecutionGas returns the gas of the execution on the chain
+func (e *GasEstimate) ExecutionGas() uint64 {
+	return e.Gas - e.L1Gas
+}
+
+// TotalGas returns the gas including the L1 data fee converted at the gas price
+func (e *GasEstimate) TotalGas(gasPrice *big.Int) uint64 {
+	if e.L1Fee == nil || e.L1Fee.Sign() == 0 || gasPrice == nil || gasPrice.Sign() == 0 {
+		return e.Gas
+	}
+	l1Gas := new(big.Int).Add(e.L1Fee, new(big.Int).Sub(gasPrice, big.NewInt(1)))
+	l1Gas.Div(l1Gas, gasPrice)
+	return e.Gas + l1Gas.Uint64()
+}
+
+// Cost returns the expected fee of the transaction at the gas price
+func (e *GasEstimate) Cost(gasPrice *big.Int) *big.Int {
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(e.Gas), gasPrice)
+	if e.L1Fee != nil {
+		cost.Add(cost, e.L1Fee)
+	}
+	return cost
+}
+
+func (c *Config) validateL2Type() error {
+	switch c.L2Type {
+	case "", L2Optimism, L2Arbitrum:
+		return nil
+	}
+	return fmt.Errorf("unknown l2-type %q, must be %s or %s", c.L2Type, L2Optimism, L2Arbitrum)
+}
+
+// estimateGas estimates the gas of the message and the L1 data component on rollups
+func (p *Provider) estimateGas(ctx context.Context, message *providerTypes.Message) (*GasEstimate, error) {
+	msg, err := p.callMsg(message)
+	if err != nil {
+		return nil, err
+	}
+	gas, err := p.client.EstimateGas(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	estimate := &GasEstimate{Gas: gas}
+	switch p.cfg.L2Type {
+	case L2Optimism:
+		tx, err := types.NewTx(&types.DynamicFeeTx{
+			ChainID: p.client.GetChainID(),
+			Gas:     gas,
+			To:      msg.To,
+			Data:    msg.Data,
+		}).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		if estimate.L1Fee, err = p.opL1Fee(ctx, tx, nil); err != nil {
+			return nil, fmt.Errorf("failed to get l1 fee: %w", err)
+		}
+	case L2Arbitrum:
+		if estimate.L1Gas, err = p.arbL1Gas(ctx, msg); err != nil {
+			return nil, fmt.Errorf("failed to get l1 gas: %w", err)
+		}
+		if estimate.L1Gas > estimate.Gas {
+			estimate.L1Gas = estimate.Gas
+		}
+	}
+	return estimate, nil
+}
+
+// opL1Fee returns the L1 data fee of the serialized transaction
+func (p *Provider) opL1Fee(ctx context.Context, tx []byte, blockNumber *big.Int) (*big.Int, error) {
+	data, err := opGasPriceOracleABI.Pack("getL1Fee", tx)
+	if err != nil {
+		return nil, err
+	}
+	out, err := p.client.CallContract(ctx, ethereum.CallMsg{To: &opGasPriceOracle, Data: data}, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	values, err := opGasPriceOracleABI.Unpack("getL1Fee", out)
+	if err != nil {
+		return nil, err
+	}
+	return values[0].(*big.Int), nil
+}
+
+// arbL1Gas returns the gas charged for the L1 data of the call
+func (p *Provider) arbL1Gas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	data, err := arbNodeInterfaceABI.Pack("gasEstimateL1Component", *msg.To, false, msg.Data)
+	if err != nil {
+		return 0, err
+	}
+	out, err := p.client.CallContract(ctx, ethereum.CallMsg{From: msg.From, To: &arbNodeInterface, Data: data}, nil)
+	if err != nil {
+		return 0, err
+	}
+	values, err := arbNodeInterfaceABI.Unpack("gasEstimateL1Component", out)
+	if err != nil {
+		return 0, err
+	}
+	return values[0].(uint64), nil
+}
+
+// l1Fee returns the L1 data fee paid by a mined transaction on OP stack chains,
+// on Arbitrum it is already included in the gas used
+func (p *Provider) l1Fee(ctx context.Context, tx *types.Transaction, receipt *types.Receipt) *big.Int {
+	if p.cfg.L2Type != L2Optimism {
+		return nil
+	}
+	fee, err := p.client.TransactionL1Fee(ctx, receipt.TxHash)
+	if err == nil && fee != nil {
+		return fee
+	}
+	// nodes without the l1Fee receipt field are priced with the oracle at the block
+	data, err := tx.MarshalBinary()
+	if err == nil {
+		fee, err = p.opL1Fee(ctx, data, receipt.BlockNumber)
+	}
+	if err != nil {
+		p.log.Warn("failed to get l1 fee", zap.String("tx_hash", receipt.TxHash.String()), zap.Error(err))
+		return nil
+	}
+	return fee
+}
@@ -0,0 +1,79 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type l2Client struct {
+	IClient
+	gas uint64
+}
+
+func (c *l2Client) GetChainID() *big.Int {
+	return big.NewInt(10)
+}
+
+func (c *l2Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return c.gas, nil
+}
+
+func (c *l2Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	switch *msg.To {
+	case opGasPriceOracle:
+		return opGasPriceOracleABI.Methods["getL1Fee"].Outputs.Pack(big.NewInt(5000))
+	case arbNodeInterface:
+		return arbNodeInterfaceABI.Methods["gasEstimateL1Component"].Outputs.Pack(uint64(40000), big.NewInt(1), big.NewInt(1))
+	}
+	return nil, ethereum.NotFound
+}
+
+func TestL2GasEstimate(t *testing.T) {
+	ctx := context.Background()
+	message := &providerTypes.Message{EventType: events.EmitMessage, Src: "0x2.icon", Sn: big.NewInt(1), Data: []byte{1}}
+	newProvider := func(l2Type string) *Provider {
+		return &Provider{
+			log:    zap.NewNop(),
+			wallet: &keystore.Key{},
+			client: &l2Client{gas: 100000},
+			cfg: &Config{
+				CommonConfig: provider.CommonConfig{Contracts: providerTypes.ContractConfigMap{providerTypes.ConnectionContract: "0x01"}},
+				L2Type:       l2Type,
+			},
+			cfgMu: new(sync.RWMutex),
+		}
+	}
+
+	t.Run("optimism", func(t *testing.T) {
+		estimate, err := newProvider(L2Optimism).estimateGas(ctx, message)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(100000), estimate.ExecutionGas())
+		assert.Equal(t, big.NewInt(5000), estimate.L1Fee)
+		// 5000 wei at 3 wei per gas is 1667 gas
+		assert.Equal(t, uint64(101667), estimate.TotalGas(big.NewInt(3)))
+		assert.Equal(t, big.NewInt(305000), estimate.Cost(big.NewInt(3)))
+	})
+
+	t.Run("arbitrum", func(t *testing.T) {
+		estimate, err := newProvider(L2Arbitrum).estimateGas(ctx, message)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(40000), estimate.L1Gas)
+		assert.Equal(t, uint64(60000), estimate.ExecutionGas())
+		assert.Equal(t, uint64(100000), estimate.TotalGas(big.NewInt(3)))
+		assert.Equal(t, big.NewInt(300000), estimate.Cost(big.NewInt(3)))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		assert.Error(t, (&Config{L2Type: "zksync"}).validateL2Type())
+	})
+}
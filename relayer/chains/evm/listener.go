@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
 	"github.com/pkg/errors"
 )
@@ -73,11 +74,9 @@ func (p *Provider) Listener(ctx context.Context, lastProcessedTx relayertypes.La
 				clientReconnected := false
 				for !clientReconnected {
 					p.log.Info("reconnecting client")
-					client, err := p.client.Reconnect()
-					if err == nil {
+					if err := p.client.Reconnect(); err == nil {
 						clientReconnected = true
 						p.log.Info("client reconnected")
-						p.client = client
 					} else {
 						p.log.Error("failed to re-connect", zap.Error(err))
 						time.Sleep(ClientReconnectDelay)
@@ -165,6 +164,7 @@ func (p *Provider) getLogsRetry(ctx context.Context, filter ethereum.FilterQuery
 func (p *Provider) isConnectionError(err error) bool {
 	return strings.Contains(err.Error(), "tcp") ||
 		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, rpc.ErrClientQuit) ||
 		strings.Contains(err.Error(), "websocket")
 }
 
@@ -249,6 +249,10 @@ func (p *Provider) Subscribe(ctx context.Context, blockInfoChan chan *relayertyp
 			p.log.Debug("subscriptions stopped")
 			return ctx.Err()
 		case err := <-sub.Err():
+			// the subscription ends without an error when the client is closed
+			if err == nil {
+				err = rpc.ErrClientQuit
+			}
 			p.log.Warn("subscription error", zap.Error(err))
 			resetCh <- err
 			return err
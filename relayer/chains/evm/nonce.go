@@ -0,0 +1,129 @@
+package evm
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// DefaultMaxInFlight is the number of transactions sent without waiting for the previous ones
+const DefaultMaxInFlight = 1
+
+// NonceFetcher returns the pending nonce of the wallet from the node
+type NonceFetcher func(ctx context.Context) (uint64, error)
+
+// NonceManager allocates the nonces of the wallet locally so several transactions
+// can be in flight, it is resynced from the node when the nonces diverge
+type NonceManager struct {
+	mu     sync.Mutex
+	fetch  NonceFetcher
+	synced bool
+	next   uint64
+	// returned are the nonces of the transactions which were not sent,
+	// they are allocated first so they don't leave a gap
+	returned []uint64
+	slots    chan struct{}
+	// resync is set when the node expects a lower nonce while other
+	// transactions are in flight, it is applied once they are done
+	resync bool
+}
+
+func NewNonceManager(maxInFlight int, fetch NonceFetcher) *NonceManager {
+	if maxInFlight <= 0 {
+		maxInFlight = DefaultMaxInFlight
+	}
+	return &NonceManager{
+		fetch: fetch,
+		slots: make(chan struct{}, maxInFlight),
+	}
+}
+
+// Acquire waits for an in-flight slot and allocates the next nonce,
+// the nonce is handed back with Done once sent or with Return if it was not
+func (m *NonceManager) Acquire(ctx context.Context) (uint64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case m.slots <- struct{}{}:
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// the slot of the caller is the only one taken, no nonce is in flight
+	if !m.synced || (m.resync && len(m.slots) == 1) {
+		if err := m.sync(ctx); err != nil {
+			<-m.slots
+			return 0, err
+		}
+	}
+	if len(m.returned) > 0 {
+		nonce := m.returned[0]
+		m.returned = m.returned[1:]
+		return nonce, nil
+	}
+	nonce := m.next
+	m.next++
+	return nonce, nil
+}
+
+// Done frees the slot of a transaction which was mined or dropped
+func (m *NonceManager) Done() {
+	<-m.slots
+}
+
+// Return gives back the nonce of a transaction which was not sent
+func (m *NonceManager) Return(nonce uint64) {
+	defer func() { <-m.slots }()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.synced || nonce >= m.next {
+		return
+	}
+	if nonce == m.next-1 {
+		m.next--
+		return
+	}
+	if !slices.Contains(m.returned, nonce) {
+		m.returned = append(m.returned, nonce)
+		slices.Sort(m.returned)
+	}
+}
+
+// Resync reads the pending nonce from the node, the nonces allocated after a
+// dropped transaction are replaced so the gap it left is filled. It is called
+// by the holder of a slot; while other transactions are in flight their nonces
+// may not be pending on the node yet, so only a higher nonce of the node is
+// applied and a lower one waits for them to be done
+func (m *NonceManager) Resync(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.slots) <= 1 {
+		return m.sync(ctx)
+	}
+	nonce, err := m.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if !m.synced {
+		return nil
+	}
+	if nonce < m.next {
+		m.resync = true
+		return nil
+	}
+	m.next = nonce
+	m.returned = nil
+	return nil
+}
+
+func (m *NonceManager) sync(ctx context.Context) error {
+	nonce, err := m.fetch(ctx)
+	if err != nil {
+		// the local nonces are kept, the transactions in flight use them
+		return err
+	}
+	m.next = nonce
+	m.returned = nil
+	m.synced = true
+	m.resync = false
+	return nil
+}
@@ -0,0 +1,125 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceManager(t *testing.T) {
+	ctx := context.Background()
+	pending := uint64(10)
+	fetches := 0
+	m := NewNonceManager(3, func(ctx context.Context) (uint64, error) {
+		fetches++
+		return pending, nil
+	})
+
+	t.Run("allocate locally", func(t *testing.T) {
+		for want := uint64(10); want < 13; want++ {
+			nonce, err := m.Acquire(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, want, nonce)
+		}
+		assert.Equal(t, 1, fetches)
+	})
+
+	t.Run("in-flight limit", func(t *testing.T) {
+		timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, err := m.Acquire(timeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		m.Done()
+		nonce, err := m.Acquire(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(13), nonce)
+	})
+
+	t.Run("returned nonces fill the gap", func(t *testing.T) {
+		// 11 and 13 were not sent
+		m.Return(11)
+		m.Return(13)
+		nonce, err := m.Acquire(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(11), nonce)
+		nonce, err = m.Acquire(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(13), nonce)
+	})
+
+	t.Run("resync", func(t *testing.T) {
+		m.Done()
+		m.Done()
+		m.Done()
+		// 12 was dropped, the node expects it again
+		pending = 12
+		assert.NoError(t, m.Resync(ctx))
+		nonce, err := m.Acquire(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(12), nonce)
+		assert.Equal(t, 2, fetches)
+	})
+
+	t.Run("resync with transactions in flight", func(t *testing.T) {
+		// 12 and 13 are in flight, 13 is not pending on the node yet
+		nonce, err := m.Acquire(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(13), nonce)
+		assert.NoError(t, m.Resync(ctx))
+		nonce, err = m.Acquire(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(14), nonce)
+
+		// the lower nonce of the node is applied once they are done
+		m.Done()
+		m.Done()
+		m.Done()
+		nonce, err = m.Acquire(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(12), nonce)
+
+		// a higher nonce of the node is applied at once
+		pending = 20
+		_, err = m.Acquire(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, m.Resync(ctx))
+		nonce, err = m.Acquire(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(20), nonce)
+	})
+}
+
+// nonceClient returns the pending and latest nonces of the wallet
+type nonceClient struct {
+	IClient
+	pending, latest int64
+}
+
+func (c *nonceClient) PendingNonceAt(context.Context, common.Address, *big.Int) (*big.Int, error) {
+	return big.NewInt(c.pending), nil
+}
+
+func (c *nonceClient) GetLatestNonce(context.Context, common.Address) (*big.Int, error) {
+	return big.NewInt(c.latest), nil
+}
+
+func TestPendingTx(t *testing.T) {
+	client := &nonceClient{pending: 12, latest: 10}
+	p := &Provider{client: client, wallet: &keystore.Key{}}
+
+	pending, err := p.PendingTx(context.Background())
+	require.NoError(t, err)
+	assert.True(t, pending)
+
+	client.latest = 12
+	pending, err = p.PendingTx(context.Background())
+	require.NoError(t, err)
+	assert.False(t, pending)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/icon-project/centralized-relay/relayer/events"
 	"github.com/icon-project/centralized-relay/relayer/kms"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
 
 	"go.uber.org/zap"
@@ -48,6 +49,26 @@ type Config struct {
 	GasLimit              uint64 `json:"gas-limit" yaml:"gas-limit"`
 	GasAdjustment         uint64 `json:"gas-adjustment" yaml:"gas-adjustment"`
 	BlockBatchSize        uint64 `json:"block-batch-size" yaml:"block-batch-size"`
+	// WebsocketUrls are the fallback websocket endpoints tried in order after websocket-url
+	WebsocketUrls []string `json:"websocket-urls,omitempty" yaml:"websocket-urls,omitempty"`
+	// MaxInFlight is the number of transactions sent before the previous ones are mined
+	MaxInFlight int `json:"max-in-flight" yaml:"max-in-flight"`
+	// BumpInterval is the wait before a pending transaction is replaced with a higher fee, 0 disables it
+	BumpInterval time.Duration `json:"bump-interval" yaml:"bump-interval"`
+	// BumpPercent is the fee increase of the replacement transactions
+	BumpPercent uint64 `json:"bump-percent" yaml:"bump-percent"`
+	// MaxGasPrice caps the gas price or fee cap of the replacement transactions, in wei
+	MaxGasPrice uint64 `json:"max-gas-price" yaml:"max-gas-price"`
+	// FeeHistoryBlocks is the number of blocks of eth_feeHistory used to price the transactions
+	FeeHistoryBlocks uint64 `json:"fee-history-blocks" yaml:"fee-history-blocks"`
+	// MaxFeePerGas caps the fee cap or the legacy gas price, in wei
+	MaxFeePerGas uint64 `json:"max-fee-per-gas" yaml:"max-fee-per-gas"`
+	// MaxPriorityFee caps the priority fee, in wei
+	MaxPriorityFee uint64 `json:"max-priority-fee" yaml:"max-priority-fee"`
+	// Urgency is the urgency of the transactions per event type, medium by default
+	Urgency map[string]Urgency `json:"urgency,omitempty" yaml:"urgency,omitempty"`
+	// L2Type adds the L1 data fee of the rollup to the gas estimation, optimism or arbitrum
+	L2Type string `json:"l2-type,omitempty" yaml:"l2-type,omitempty"`
 }
 
 type Provider struct {
@@ -60,7 +81,14 @@ type Provider struct {
 	kms                 kms.KMS
 	contracts           map[string]providerTypes.EventMap
 	LastSavedHeightFunc func() uint64
-	routerMutex         *sync.Mutex
+	nonces              *NonceManager
+	rpcPool             *rpcpool.Pool
+	wsPool              *rpcpool.Pool
+	// cancel stops the health checks of the pools started by Init
+	cancel context.CancelFunc
+	// cfgMu guards the updatable fields of the config, shared with the
+	// endpoint readers
+	cfgMu *sync.RWMutex
 }
 
 func (p *Config) NewProvider(ctx context.Context, log *zap.Logger, homepath string, debug bool, chainName string) (provider.ChainProvider, error) {
@@ -79,13 +107,31 @@ func (p *Config) NewProvider(ctx context.Context, log *zap.Logger, homepath stri
 		p.FinalityBlock = DefaultFinalityBlock
 	}
 
-	return &Provider{
-		cfg:         p,
-		log:         log.With(zap.Stringp("nid", &p.NID), zap.Stringp("name", &p.ChainName)),
-		blockReq:    p.GetMonitorEventFilters(),
-		contracts:   p.eventMap(),
-		routerMutex: new(sync.Mutex),
-	}, nil
+	provider := &Provider{
+		cfg:       p,
+		log:       log.With(zap.Stringp("nid", &p.NID), zap.Stringp("name", &p.ChainName)),
+		blockReq:  p.GetMonitorEventFilters(),
+		contracts: p.eventMap(),
+		cfgMu:     new(sync.RWMutex),
+	}
+	provider.nonces = NewNonceManager(p.MaxInFlight, provider.pendingNonce)
+
+	rpcPool, err := rpcpool.New(chainName, "rpc", p.RPCEndpoints(), p.RPCPool, probeHeight, provider.log)
+	if err != nil {
+		return nil, err
+	}
+	wsPool, err := rpcpool.New(chainName, "websocket", rpcpool.Merge(p.WebsocketUrl, p.WebsocketUrls), p.RPCPool, probeHeight, provider.log)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := p.RPCAuthClient()
+	if err != nil {
+		return nil, err
+	}
+	rpcPool.SetAuth(auth)
+	wsPool.SetAuth(auth)
+	provider.rpcPool, provider.wsPool = rpcPool, wsPool
+	return provider, nil
 }
 
 func (p *Provider) GetLastProcessedBlockHeight(ctx context.Context) (uint64, error) {
@@ -110,9 +156,93 @@ func (p *Config) sanitize() error {
 	if p.Decimals == 0 {
 		p.Decimals = providerTypes.DefaultCoinDecimals
 	}
+	if p.MaxInFlight == 0 {
+		p.MaxInFlight = DefaultMaxInFlight
+	}
+	if p.MaxInFlight < 0 {
+		return fmt.Errorf("max-in-flight must be positive")
+	}
+	if err := p.validateL2Type(); err != nil {
+		return err
+	}
+	if p.FeeHistoryBlocks == 0 {
+		p.FeeHistoryBlocks = DefaultFeeHistoryBlocks
+	}
+	for eventType, urgency := range p.Urgency {
+		if err := urgency.Validate(); err != nil {
+			return fmt.Errorf("urgency of %s: %w", eventType, err)
+		}
+	}
+	if p.BumpPercent == 0 {
+		p.BumpPercent = DefaultBumpPercent
+	}
+	if p.BumpPercent < DefaultBumpPercent {
+		return fmt.Errorf("bump-percent must be at least %d to replace a pending transaction", DefaultBumpPercent)
+	}
+	if p.BumpInterval > 0 && p.MaxGasPrice == 0 {
+		return fmt.Errorf("max-gas-price is required to replace the pending transactions")
+	}
+	// the replacements are bumped from fees capped by max-fee-per-gas
+	if p.MaxGasPrice > 0 && p.MaxGasPrice < p.MaxFeePerGas {
+		return fmt.Errorf("max-gas-price must be at least max-fee-per-gas, it caps the replacements of the transactions priced up to it")
+	}
+	if p.BumpInterval >= DefaultTxConfirmationTimeout {
+		return fmt.Errorf("bump-interval must be shorter than the confirmation timeout %s", DefaultTxConfirmationTimeout)
+	}
+	return nil
+}
+
+// updatableFields are read when pricing and sending the transactions, a
+// config reload applies them without a restart
+var updatableFields = []string{
+	"gas-limit",
+	"gas-adjustment",
+	"max-gas-price",
+	"max-fee-per-gas",
+	"max-priority-fee",
+	"fee-history-blocks",
+	"urgency",
+	"bump-interval",
+	"bump-percent",
+}
+
+func (p *Provider) UpdatableFields() []string {
+	return updatableFields
+}
+
+// UpdateConfig applies the updatable fields of the config
+func (p *Provider) UpdateConfig(cfg provider.Config) error {
+	next, ok := cfg.(*Config)
+	if !ok {
+		return fmt.Errorf("invalid config type %T", cfg)
+	}
+	if err := next.Validate(); err != nil {
+		return err
+	}
+	if err := next.sanitize(); err != nil {
+		return err
+	}
+	p.cfgMu.Lock()
+	defer p.cfgMu.Unlock()
+	p.cfg.GasLimit = next.GasLimit
+	p.cfg.GasAdjustment = next.GasAdjustment
+	p.cfg.MaxGasPrice = next.MaxGasPrice
+	p.cfg.MaxFeePerGas = next.MaxFeePerGas
+	p.cfg.MaxPriorityFee = next.MaxPriorityFee
+	p.cfg.FeeHistoryBlocks = next.FeeHistoryBlocks
+	p.cfg.Urgency = next.Urgency
+	p.cfg.BumpInterval = next.BumpInterval
+	p.cfg.BumpPercent = next.BumpPercent
 	return nil
 }
 
+// settings returns a copy of the config, the updatable fields are read with it
+func (p *Provider) settings() Config {
+	p.cfgMu.RLock()
+	defer p.cfgMu.RUnlock()
+	return *p.cfg
+}
+
 func (p *Config) SetWallet(addr string) {
 	p.Address = addr
 }
@@ -127,19 +257,65 @@ func (c *Config) Enabled() bool {
 }
 
 func (p *Provider) Init(ctx context.Context, homePath string, kms kms.KMS) error {
+	ctx, cancel := context.WithCancel(ctx)
 	connectionContract := common.HexToAddress(p.cfg.Contracts[providerTypes.ConnectionContract])
 	xcallContract := common.HexToAddress(p.cfg.Contracts[providerTypes.XcallContract])
 
-	client, err := newClient(ctx, connectionContract, xcallContract, p.cfg.RPCUrl,
-		p.cfg.WebsocketUrl, p.log, p.cfg.GetClusterMode())
+	client, err := newClient(ctx, connectionContract, xcallContract, p.rpcPool,
+		p.wsPool, p.log, p.cfg.GetClusterMode())
 	if err != nil {
+		cancel()
 		return fmt.Errorf("error occured when creating client: %v", err)
 	}
 	p.client = client
 	p.kms = kms
+	p.cancel = cancel
+
+	// closing the websocket ends the subscription and the listener dials the new endpoint
+	p.wsPool.OnChange(func(url string) {
+		p.client.CloseWebsocket()
+	})
+	go p.rpcPool.Start(ctx)
+	go p.wsPool.Start(ctx)
+	return nil
+}
+
+// Close stops the health checks of the pools and closes the clients
+func (p *Provider) Close() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	if p.client != nil {
+		p.client.Close()
+	}
+	p.rpcPool.Close()
+	p.wsPool.Close()
 	return nil
 }
 
+// EndpointReaders returns a provider per rpc endpoint for the quorum reads
+func (p *Provider) EndpointReaders(ctx context.Context) ([]*provider.EndpointReader, error) {
+	connectionContract := common.HexToAddress(p.cfg.Contracts[providerTypes.ConnectionContract])
+	xcallContract := common.HexToAddress(p.cfg.Contracts[providerTypes.XcallContract])
+
+	var readers []*provider.EndpointReader
+	for _, url := range p.rpcPool.Endpoints() {
+		pool, err := p.rpcPool.Pin(url)
+		if err != nil {
+			return nil, err
+		}
+		client, err := newClient(ctx, connectionContract, xcallContract, pool, pool, p.log, p.cfg.GetClusterMode())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pool.Label(), err)
+		}
+		reader := *p
+		// closing the reader only closes its own client and pinned pool
+		reader.client, reader.rpcPool, reader.wsPool, reader.cancel = client, pool, pool, nil
+		readers = append(readers, &provider.EndpointReader{Endpoint: pool.Label(), Provider: &reader})
+	}
+	return readers, nil
+}
+
 func (p *Provider) Type() string {
 	return "evm"
 }
@@ -166,31 +342,77 @@ func (p *Provider) FinalityBlock(ctx context.Context) uint64 {
 	return p.cfg.FinalityBlock
 }
 
+// WaitForResults waits for the receipt of a transaction sent by SendTransaction
+// and frees its in-flight slot
 func (p *Provider) WaitForResults(ctx context.Context, tx *ethTypes.Transaction) (*ethTypes.Receipt, error) {
-	ticker := time.NewTicker(1 * time.Second)
+	receipt, _, err := p.waitForResults(ctx, tx)
+	return receipt, err
+}
+
+// MaxTxWait returns the wait for the receipt of a transaction and its
+// replacements before it is timed out, counted from the first one sent
+func (p *Provider) MaxTxWait() time.Duration {
+	return txConfirmationTimeout
+}
+
+// waitForResults waits for the receipt of any of the transactions sent with the nonce of tx,
+// while pending the last one is replaced with a bumped fee every bump-interval.
+// The wait is bounded from the first transaction sent, the replacements do not
+// extend it. The transactions sent are returned, the mined one is the one of the receipt
+func (p *Provider) waitForResults(ctx context.Context, tx *ethTypes.Transaction) (*ethTypes.Receipt, []*ethTypes.Transaction, error) {
+	defer p.nonces.Done()
+	ticker := time.NewTicker(receiptPollInterval)
 	defer ticker.Stop()
-	startTime := time.Now()
+	deadline := time.NewTimer(txConfirmationTimeout)
+	defer deadline.Stop()
+	sent := []*ethTypes.Transaction{tx}
+	settings := p.settings()
+	replace := settings.replaceEnabled()
+	sentAt := time.Now()
 	for {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-ticker.C:
-			txReceipt, err := p.client.TransactionReceipt(ctx, tx.Hash())
-			if err != nil && !errors.Is(err, ethereum.NotFound) {
-				return txReceipt, err
+			return nil, sent, ctx.Err()
+		case <-deadline.C:
+			// the transaction may have been dropped, leaving a gap
+			// which blocks the transactions sent after it
+			if err := p.nonces.Resync(ctx); err != nil {
+				p.log.Warn("failed to resync nonce", zap.Error(err))
 			}
+			return nil, sent, fmt.Errorf("tx confirmation timed out [tx hash: %s]", sent[len(sent)-1].Hash())
+		case <-ticker.C:
+			for _, candidate := range sent {
+				txReceipt, err := p.client.TransactionReceipt(ctx, candidate.Hash())
+				if err != nil && !errors.Is(err, ethereum.NotFound) {
+					return txReceipt, sent, err
+				}
 
-			if txReceipt != nil {
-				if txReceipt.Status == ethTypes.ReceiptStatusFailed {
-					return txReceipt, fmt.Errorf("txn failed [tx hash: %s]", tx.Hash())
-				} else {
-					return txReceipt, nil
+				if txReceipt != nil {
+					if txReceipt.Status == ethTypes.ReceiptStatusFailed {
+						return txReceipt, sent, fmt.Errorf("txn failed [tx hash: %s]", candidate.Hash())
+					} else {
+						return txReceipt, sent, nil
+					}
 				}
 			}
 
-			// handle txn not found case:
-			if time.Since(startTime) > DefaultTxConfirmationTimeout {
-				return txReceipt, fmt.Errorf("tx confirmation timed out [tx hash: %s]", tx.Hash())
+			last := sent[len(sent)-1]
+			if replace && time.Since(sentAt) >= settings.BumpInterval {
+				replacement, err := p.replaceTransaction(ctx, last)
+				switch {
+				case err == nil:
+					sent = append(sent, replacement)
+					sentAt = time.Now()
+					continue
+				case errors.Is(err, errMaxGasPrice):
+					// keep waiting for the transactions already sent
+					p.log.Warn("stopped replacing stuck transaction", zap.String("tx_hash", last.Hash().String()), zap.Error(err))
+					replace = false
+				case p.parseErr(err) == ErrNonceTooLow:
+					// one of the transactions was mined, its receipt is read on the next tick
+				default:
+					p.log.Warn("failed to replace stuck transaction", zap.String("tx_hash", last.Hash().String()), zap.Error(err))
+				}
 			}
 		}
 	}
@@ -230,58 +452,60 @@ func (r *Provider) transferBalance(senderKey, recepientAddress string, amount *b
 	return
 }
 
-func (p *Provider) GetTransationOpts(ctx context.Context) (*bind.TransactOpts, error) {
-	newTransactOpts := func(w *keystore.Key) (*bind.TransactOpts, error) {
-		txo, err := bind.NewKeyedTransactorWithChainID(w.PrivateKey, p.client.GetChainID())
-		if err != nil {
-			return nil, err
-		}
-		return txo, nil
-	}
-
+// pendingNonce returns the nonce of the wallet including the pending transactions
+func (p *Provider) pendingNonce(ctx context.Context) (uint64, error) {
 	wallet, err := p.Wallet()
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+	nonce, err := p.client.PendingNonceAt(ctx, wallet.Address, nil)
+	if err != nil {
+		return 0, err
+	}
+	return nonce.Uint64(), nil
+}
 
-	txOpts, err := newTransactOpts(p.wallet)
+// PendingTx returns true while a transaction of the wallet is not mined, its
+// pending nonce is then ahead of the nonce of the latest block
+func (p *Provider) PendingTx(ctx context.Context) (bool, error) {
+	wallet, err := p.Wallet()
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
 	defer cancel()
-
-	latestNonce, err := p.client.GetLatestNonce(ctx, wallet.Address)
+	pending, err := p.client.PendingNonceAt(ctx, wallet.Address, nil)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-	txOpts.Nonce = latestNonce
-
-	gasPrice, err := p.client.SuggestGasPrice(ctx)
+	latest, err := p.client.GetLatestNonce(ctx, wallet.Address)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get gas price: %w", err)
+		return false, err
 	}
+	return pending.Cmp(latest) > 0, nil
+}
 
-	adjustmentFactor := 1.0 + float64(p.cfg.GasAdjustment)/100.0
-	adjustedGasPrice := float64(gasPrice.Int64()) * adjustmentFactor
-
-	if p.cfg.UseLegacyFee {
-		txOpts.GasPrice = big.NewInt(int64(adjustedGasPrice))
-	} else {
-		gasTip, err := p.client.SuggestGasTip(ctx)
+func (p *Provider) GetTransationOpts(ctx context.Context) (*bind.TransactOpts, error) {
+	newTransactOpts := func(w *keystore.Key) (*bind.TransactOpts, error) {
+		txo, err := bind.NewKeyedTransactorWithChainID(w.PrivateKey, p.client.GetChainID())
 		if err != nil {
-			p.log.Warn("failed to get gas tip", zap.Error(err))
+			return nil, err
 		}
-		adjustedGasTip := float64(gasTip.Int64()) * adjustmentFactor
-		txOpts.GasTipCap = big.NewInt(int64(adjustedGasTip))
-
-		txOpts.GasFeeCap = big.NewInt(int64(adjustedGasPrice))
+		return txo, nil
+	}
 
-		if txOpts.GasFeeCap.Cmp(txOpts.GasTipCap) != 1 {
-			txOpts.GasFeeCap = txOpts.GasFeeCap.Add(txOpts.GasFeeCap, txOpts.GasTipCap)
-		}
+	wallet, err := p.Wallet()
+	if err != nil {
+		return nil, err
 	}
 
+	txOpts, err := newTransactOpts(wallet)
+	if err != nil {
+		return nil, err
+	}
+	// the nonce and the fees are set when the transaction is sent
 	return txOpts, nil
 }
 
@@ -292,6 +516,9 @@ func (p *Provider) SetAdmin(ctx context.Context, admin string) error {
 		return err
 	}
 	tx, err := p.SendTransaction(ctx, opts, &providerTypes.Message{EventType: events.SetAdmin, Dst: admin})
+	if err != nil {
+		return err
+	}
 	receipt, err := p.WaitForResults(ctx, tx)
 	if err != nil {
 		return err
@@ -328,25 +555,39 @@ func (p *Provider) RevertMessage(ctx context.Context, sn *big.Int) error {
 
 // ClaimFees
 func (p *Provider) ClaimFee(ctx context.Context) error {
+	_, err := p.ClaimFeeAmount(ctx)
+	return err
+}
+
+// ClaimFeeAmount claims the fees and returns the value the connection sent in
+// the claim transaction. Without the debug api it is the balance of the
+// connection before the block of the claim, the claim drains the balance
+func (p *Provider) ClaimFeeAmount(ctx context.Context) (*big.Int, error) {
 	msg := &providerTypes.Message{
 		EventType: events.ClaimFee,
 	}
 	opts, err := p.GetTransationOpts(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	tx, err := p.SendTransaction(ctx, opts, msg)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	receipt, err := p.WaitForResults(ctx, tx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if receipt.Status != 1 {
-		return fmt.Errorf("failed to revert message: %s", err)
+		return nil, fmt.Errorf("failed to claim fees: %s", receipt.TxHash)
 	}
-	return nil
+	conn := common.HexToAddress(p.cfg.Contracts[providerTypes.ConnectionContract])
+	amount, err := p.client.TransferredValue(ctx, receipt.TxHash, conn)
+	if err == nil {
+		return amount, nil
+	}
+	p.log.Debug("failed to trace the claim, reading the connection balance", zap.Error(err))
+	return p.client.BalanceAt(ctx, conn, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
 }
 
 // SetFee
@@ -410,6 +651,15 @@ func (p *Provider) ExecuteRollback(ctx context.Context, sn *big.Int) error {
 
 // EstimateGas
 func (p *Provider) EstimateGas(ctx context.Context, message *providerTypes.Message) (uint64, error) {
+	msg, err := p.callMsg(message)
+	if err != nil {
+		return 0, err
+	}
+	return p.client.EstimateGas(ctx, msg)
+}
+
+// callMsg returns the contract call of the message
+func (p *Provider) callMsg(message *providerTypes.Message) (ethereum.CallMsg, error) {
 	contract := common.HexToAddress(p.cfg.Contracts[providerTypes.ConnectionContract])
 	msg := ethereum.CallMsg{
 		From: p.wallet.Address,
@@ -419,87 +669,103 @@ func (p *Provider) EstimateGas(ctx context.Context, message *providerTypes.Messa
 	case events.EmitMessage:
 		abi, err := bridgeContract.ConnectionMetaData.GetAbi()
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		data, err := abi.Pack(MethodRecvMessage, message.Src, message.Sn, message.Data)
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		msg.Data = data
 	case events.SetAdmin:
 		abi, err := bridgeContract.ConnectionMetaData.GetAbi()
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		data, err := abi.Pack(MethodSetAdmin, message.Src)
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		msg.Data = data
 	case events.RevertMessage:
 		abi, err := bridgeContract.ConnectionMetaData.GetAbi()
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		data, err := abi.Pack(MethodRevertMessage, message.Sn)
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		msg.Data = data
 	case events.ClaimFee:
 		abi, err := bridgeContract.ConnectionMetaData.GetAbi()
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		data, err := abi.Pack(MethodClaimFees)
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		msg.Data = data
 	case events.SetFee:
 		abi, err := bridgeContract.ConnectionMetaData.GetAbi()
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		data, err := abi.Pack(MethodSetFee, message.Src, message.Sn, message.ReqID)
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		msg.Data = data
 	case events.CallMessage:
 		abi, err := bridgeContract.XcallMetaData.GetAbi()
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		data, err := abi.Pack(MethodExecuteCall, message.ReqID, message.Data)
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		msg.Data = data
 		contract = common.HexToAddress(p.cfg.Contracts[providerTypes.XcallContract])
 	case events.RollbackMessage:
 		abi, err := bridgeContract.XcallMetaData.GetAbi()
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		data, err := abi.Pack(MethodExecuteRollback, message.Sn)
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		msg.Data = data
 		contract = common.HexToAddress(p.cfg.Contracts[providerTypes.XcallContract])
 	case events.PacketAcknowledged:
 		abi, err := bridgeContract.ClusterConnectionMetaData.GetAbi()
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		data, err := abi.Pack(MethodRecvMessageWithSignatures, message.Src, message.Sn, message.Data, message.Signatures)
 		if err != nil {
-			return 0, err
+			return msg, err
 		}
 		msg.Data = data
 	}
-	return p.client.EstimateGas(ctx, msg)
+	return msg, nil
+}
+
+// EstimateCost returns the expected fee of delivering the message in wei
+func (p *Provider) EstimateCost(ctx context.Context, message *providerTypes.Message) (*big.Int, error) {
+	if _, err := p.Wallet(); err != nil {
+		return nil, err
+	}
+	estimate, err := p.estimateGas(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	gasPrice, err := p.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	return estimate.Cost(gasPrice), nil
 }
 
 // SetLastSavedBlockHeightFunc sets the function to save the last saved block height
@@ -0,0 +1,109 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+)
+
+// DefaultBumpPercent is the fee increase of a replacement transaction,
+// it is the minimum accepted by the nodes to replace a pending transaction
+const DefaultBumpPercent = 10
+
+// errMaxGasPrice is returned when the bumped fee would exceed max-gas-price
+var errMaxGasPrice = fmt.Errorf("replacement fee exceeds max-gas-price")
+
+// replaceEnabled returns true if the stuck transactions are replaced
+func (c *Config) replaceEnabled() bool {
+	return c.BumpInterval > 0
+}
+
+// replaceTransaction signs the transaction again with the same nonce and a bumped fee and sends it
+func (p *Provider) replaceTransaction(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	wallet, err := p.Wallet()
+	if err != nil {
+		return nil, err
+	}
+	var data types.TxData
+	switch tx.Type() {
+	case types.LegacyTxType:
+		gasPrice, err := p.bumpFee(tx.GasPrice())
+		if err != nil {
+			return nil, err
+		}
+		data = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: gasPrice,
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}
+	case types.DynamicFeeTxType:
+		gasFeeCap, err := p.bumpFee(tx.GasFeeCap())
+		if err != nil {
+			return nil, err
+		}
+		gasTipCap := bump(tx.GasTipCap(), p.settings().BumpPercent)
+		if gasTipCap.Cmp(gasFeeCap) > 0 {
+			gasTipCap = gasFeeCap
+		}
+		data = &types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  gasTipCap,
+			GasFeeCap:  gasFeeCap,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type())
+	}
+	signed, err := types.SignNewTx(wallet.PrivateKey, types.LatestSignerForChainID(p.client.GetChainID()), data)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.client.SendTransaction(ctx, signed); err != nil {
+		return nil, err
+	}
+	p.log.Info("replaced stuck transaction",
+		zap.String("tx_hash", tx.Hash().String()),
+		zap.String("replacement_hash", signed.Hash().String()),
+		zap.Uint64("nonce", tx.Nonce()),
+		zap.Any("gas_price", signed.GasPrice()),
+		zap.Any("gas_cap", signed.GasFeeCap()),
+		zap.Any("gas_tip", signed.GasTipCap()),
+	)
+	return signed, nil
+}
+
+// bumpFee raises the fee by bump-percent, capped by max-gas-price
+func (p *Provider) bumpFee(fee *big.Int) (*big.Int, error) {
+	settings := p.settings()
+	bumped := bump(fee, settings.BumpPercent)
+	if settings.MaxGasPrice == 0 {
+		return bumped, nil
+	}
+	max := new(big.Int).SetUint64(settings.MaxGasPrice)
+	if bumped.Cmp(max) > 0 {
+		return nil, fmt.Errorf("%w: %s > %s", errMaxGasPrice, bumped, max)
+	}
+	return bumped, nil
+}
+
+// bump raises the value by percent, rounded up so the replacement minimum is met
+func bump(value *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(value, new(big.Int).SetUint64(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(value) == 0 {
+		bumped.Add(bumped, big.NewInt(1))
+	}
+	return bumped
+}
@@ -0,0 +1,185 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBumpFee(t *testing.T) {
+	p := &Provider{cfg: &Config{BumpPercent: 10, MaxGasPrice: 140}, cfgMu: new(sync.RWMutex)}
+
+	t.Run("replacement minimum", func(t *testing.T) {
+		assert.Equal(t, big.NewInt(110), bump(big.NewInt(100), 10))
+		// 16.5 is rounded up so the node accepts the replacement
+		assert.Equal(t, big.NewInt(17), bump(big.NewInt(15), 10))
+		assert.Equal(t, big.NewInt(2), bump(big.NewInt(1), 10))
+	})
+
+	t.Run("max gas price", func(t *testing.T) {
+		fee, err := p.bumpFee(big.NewInt(120))
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(132), fee)
+
+		_, err = p.bumpFee(fee)
+		assert.ErrorIs(t, err, errMaxGasPrice)
+	})
+}
+
+// replaceClient mines the transaction at the index of sent set by mine
+type replaceClient struct {
+	IClient
+	mu   sync.Mutex
+	sent []*types.Transaction
+	mine int
+}
+
+func (c *replaceClient) GetChainID() *big.Int {
+	return big.NewInt(1)
+}
+
+func (c *replaceClient) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+func (c *replaceClient) TransactionReceipt(_ context.Context, hash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mine >= 0 && c.mine < len(c.sent) && c.sent[c.mine].Hash() == hash {
+		return &types.Receipt{TxHash: hash, Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1), GasUsed: 21000}, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (c *replaceClient) sentCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.sent)
+}
+
+func (c *replaceClient) setMine(i int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mine = i
+}
+
+func TestWaitForResultsReplacement(t *testing.T) {
+	interval := receiptPollInterval
+	receiptPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() { receiptPollInterval = interval })
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	newProvider := func(maxGasPrice uint64) (*Provider, *replaceClient, *types.Transaction) {
+		client := &replaceClient{mine: -1}
+		p := &Provider{
+			log:    zap.NewNop(),
+			cfg:    &Config{BumpInterval: 20 * time.Millisecond, BumpPercent: 10, MaxGasPrice: maxGasPrice},
+			cfgMu:  new(sync.RWMutex),
+			client: client,
+			wallet: &keystore.Key{PrivateKey: key},
+			nonces: NewNonceManager(1, func(context.Context) (uint64, error) { return 0, nil }),
+		}
+		_, err := p.nonces.Acquire(ctx)
+		require.NoError(t, err)
+		to := common.HexToAddress("0x01")
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(client.GetChainID()), &types.DynamicFeeTx{
+			ChainID: client.GetChainID(), GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(100), Gas: 21000, To: &to,
+		})
+		require.NoError(t, err)
+		require.NoError(t, client.SendTransaction(ctx, tx))
+		return p, client, tx
+	}
+	waitFor := func(p *Provider, tx *types.Transaction) (*providerTypes.TxResponse, error) {
+		var (
+			res      *providerTypes.TxResponse
+			resErr   error
+			received = make(chan struct{})
+		)
+		go p.WaitForTxResult(ctx, tx, &providerTypes.MessageKey{}, func(_ *providerTypes.MessageKey, response *providerTypes.TxResponse, err error) {
+			res, resErr = response, err
+			close(received)
+		})
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			t.Fatal("no transaction result")
+		}
+		return res, resErr
+	}
+
+	t.Run("stuck transaction replaced", func(t *testing.T) {
+		p, client, tx := newProvider(0)
+		go func() {
+			assert.Eventually(t, func() bool { return client.sentCount() >= 3 }, 5*time.Second, time.Millisecond)
+			client.setMine(2)
+		}()
+		res, err := waitFor(p, tx)
+		require.NoError(t, err)
+		replacement := client.sent[2]
+		assert.Equal(t, replacement.Hash().String(), res.TxHash)
+		assert.Equal(t, tx.Nonce(), replacement.Nonce())
+		assert.Equal(t, big.NewInt(121), replacement.GasFeeCap())
+		assert.Equal(t, big.NewInt(13), replacement.GasTipCap())
+		assert.GreaterOrEqual(t, len(res.Replacements), 3)
+		assert.Equal(t, tx.Hash().String(), res.Replacements[0])
+	})
+
+	t.Run("bump stops at the cap", func(t *testing.T) {
+		// 110 is sent, 121 exceeds the cap
+		p, client, tx := newProvider(120)
+		go func() {
+			assert.Eventually(t, func() bool { return client.sentCount() == 2 }, 5*time.Second, time.Millisecond)
+			// no replacement is sent for several bump intervals
+			time.Sleep(100 * time.Millisecond)
+			client.setMine(1)
+		}()
+		res, err := waitFor(p, tx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, client.sentCount())
+		assert.Equal(t, big.NewInt(110), client.sent[1].GasFeeCap())
+		assert.Equal(t, client.sent[1].Hash().String(), res.TxHash)
+		assert.Len(t, res.Replacements, 2)
+	})
+
+	t.Run("replacements do not extend the wait", func(t *testing.T) {
+		timeout := txConfirmationTimeout
+		txConfirmationTimeout = 100 * time.Millisecond
+		t.Cleanup(func() { txConfirmationTimeout = timeout })
+		p, client, tx := newProvider(0)
+		start := time.Now()
+		_, err := waitFor(p, tx)
+		assert.ErrorContains(t, err, "tx confirmation timed out")
+		assert.Less(t, time.Since(start), time.Second)
+		assert.GreaterOrEqual(t, client.sentCount(), 3)
+		assert.Equal(t, txConfirmationTimeout, p.MaxTxWait())
+	})
+
+	t.Run("original receipt wins", func(t *testing.T) {
+		p, client, tx := newProvider(0)
+		go func() {
+			assert.Eventually(t, func() bool { return client.sentCount() >= 2 }, 5*time.Second, time.Millisecond)
+			client.setMine(0)
+		}()
+		res, err := waitFor(p, tx)
+		require.NoError(t, err)
+		assert.Equal(t, tx.Hash().String(), res.TxHash)
+		assert.Contains(t, res.Replacements, client.sent[1].Hash().String())
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -24,10 +25,6 @@ const (
 
 // this will be executed in go route
 func (p *Provider) Route(ctx context.Context, message *providerTypes.Message, callback providerTypes.TxResponseFunc) error {
-	// lock here to prevent transcation replacement
-	p.routerMutex.Lock()
-	defer p.routerMutex.Unlock()
-
 	p.log.Info("starting to route message",
 		zap.String("src", message.Src),
 		zap.String("dst", message.Dst),
@@ -51,22 +48,66 @@ func (p *Provider) Route(ctx context.Context, message *providerTypes.Message, ca
 	return nil
 }
 
+// SendTransaction sends the transaction of the message with a nonce allocated
+// by the nonce manager, WaitForResults must be called once it is sent
 func (p *Provider) SendTransaction(ctx context.Context, opts *bind.TransactOpts, message *providerTypes.Message) (*types.Transaction, error) {
-	var (
-		tx  *types.Transaction
-		err error
-	)
-
-	gasLimit, err := p.EstimateGas(ctx, message)
+	estimate, err := p.estimateGas(ctx, message)
 	if err != nil {
 		return nil, fmt.Errorf("failed to estimate gas: %w", err)
 	}
+	gasLimit := estimate.Gas
+
+	if err := p.setFees(ctx, opts, message.EventType); err != nil {
+		return nil, err
+	}
+
+	// the L1 data fee of rollups counts in the ceiling as gas at the fee cap
+	gasPrice := opts.GasFeeCap
+	if gasPrice == nil {
+		gasPrice = opts.GasPrice
+	}
+	settings := p.settings()
+	if totalGas := estimate.TotalGas(gasPrice); settings.GasLimit > 0 && totalGas > settings.GasLimit {
+		return nil, fmt.Errorf("gas limit exceeded: %d (execution: %d)", totalGas, estimate.ExecutionGas())
+	}
 
-	if p.cfg.GasLimit > 0 && gasLimit > p.cfg.GasLimit {
-		return nil, fmt.Errorf("gas limit exceeded: %d", gasLimit)
+	opts.GasLimit = gasLimit + (gasLimit * settings.GasAdjustment / 100)
+
+	// a nonce rejected by the node is resynced and the transaction sent once more
+	for retry := true; ; retry = false {
+		nonce, err := p.nonces.Acquire(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate nonce: %w", err)
+		}
+		opts.Nonce = new(big.Int).SetUint64(nonce)
+		tx, err := p.sendTransaction(opts, message, gasLimit)
+		if err == nil {
+			return tx, nil
+		}
+		switch p.parseErr(err) {
+		case ErrNonceTooLow, ErrNonceTooHigh:
+			syncErr := p.nonces.Resync(ctx)
+			p.nonces.Done()
+			if syncErr != nil {
+				return nil, fmt.Errorf("failed to resync nonce: %w", syncErr)
+			}
+			if retry {
+				p.log.Warn("nonce rejected, resynced from the node", zap.Uint64("nonce", nonce), zap.Error(err))
+				continue
+			}
+		default:
+			p.nonces.Return(nonce)
+		}
+		return nil, err
 	}
+}
 
-	opts.GasLimit = gasLimit + (gasLimit * p.cfg.GasAdjustment / 100)
+// sendTransaction sends the transaction of the message with the nonce set in opts
+func (p *Provider) sendTransaction(opts *bind.TransactOpts, message *providerTypes.Message, gasLimit uint64) (*types.Transaction, error) {
+	var (
+		tx  *types.Transaction
+		err error
+	)
 
 	p.log.Info("transaction info",
 		zap.Any("gas_price", opts.GasPrice),
@@ -109,13 +150,30 @@ func (p *Provider) WaitForTxResult(ctx context.Context, tx *types.Transaction, m
 		TxHash: tx.Hash().String(),
 	}
 
-	txReceipts, err := p.WaitForResults(ctx, tx)
+	txReceipts, sent, err := p.waitForResults(ctx, tx)
+	if len(sent) > 1 {
+		for _, replaced := range sent {
+			res.Replacements = append(res.Replacements, replaced.Hash().String())
+		}
+		res.TxHash = sent[len(sent)-1].Hash().String()
+	}
+	if txReceipts != nil {
+		// whichever of the replacements was mined
+		for _, candidate := range sent {
+			if candidate.Hash() == txReceipts.TxHash {
+				tx = candidate
+			}
+		}
+		res.TxHash = tx.Hash().String()
+	}
 	if err != nil {
 		callback(m, res, fmt.Errorf("error waiting for tx result: %w", err))
 		return
 	}
 
 	res.Height = txReceipts.BlockNumber.Int64()
+	res.GasUsed = txReceipts.GasUsed
+	res.TxFee = p.txFee(ctx, tx, txReceipts)
 
 	if txReceipts.Status != types.ReceiptStatusSuccessful {
 		res.Code = providerTypes.Failed
@@ -126,6 +184,20 @@ func (p *Provider) WaitForTxResult(ctx context.Context, tx *types.Transaction, m
 	}
 }
 
+// txFee returns the fee paid for the transaction, gas used * effective gas price
+// plus the L1 data fee on OP stack chains
+func (p *Provider) txFee(ctx context.Context, tx *types.Transaction, receipt *types.Receipt) *big.Int {
+	gasPrice := receipt.EffectiveGasPrice
+	if gasPrice == nil {
+		gasPrice = tx.GasPrice()
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice)
+	if l1Fee := p.l1Fee(ctx, tx, receipt); l1Fee != nil {
+		fee.Add(fee, l1Fee)
+	}
+	return fee
+}
+
 func (p *Provider) LogSuccessTx(message *providerTypes.MessageKey, receipt *types.Receipt) {
 	p.log.Info("successful transaction",
 		zap.Any("message-key", message),
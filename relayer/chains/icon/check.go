@@ -0,0 +1,111 @@
+package icon
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/icon-project/centralized-relay/relayer/chains/icon/types"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
+	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+var (
+	eoaAddress   = regexp.MustCompile(`^hx[0-9a-f]{40}$`)
+	scoreAddress = regexp.MustCompile(`^cx[0-9a-f]{40}$`)
+)
+
+// CheckConfig checks the required fields and the addresses of the config
+func (c *Config) CheckConfig() []error {
+	var errs []error
+	if len(c.RPCEndpoints()) == 0 {
+		errs = append(errs, fmt.Errorf("rpc-url or rpc-urls is required"))
+	}
+	if c.NID == "" {
+		errs = append(errs, fmt.Errorf("nid is required"))
+	}
+	if c.Address == "" {
+		errs = append(errs, fmt.Errorf("address is required"))
+	} else if !eoaAddress.MatchString(c.Address) {
+		errs = append(errs, fmt.Errorf("address %s is not an hx address", c.Address))
+	}
+	if c.Contracts[providerTypes.ConnectionContract] == "" {
+		errs = append(errs, fmt.Errorf("contracts.%s is required", providerTypes.ConnectionContract))
+	}
+	for _, name := range c.Contracts.Names() {
+		addr := c.Contracts[name]
+		if addr != "" && !scoreAddress.MatchString(addr) {
+			errs = append(errs, fmt.Errorf("contracts.%s %s is not a cx address", name, addr))
+		}
+	}
+	for _, name := range c.Contracts.UnknownKeys() {
+		errs = append(errs, fmt.Errorf("contracts.%s is not a known contract", name))
+	}
+	if err := validateListenerMode(c.ListenerMode); err != nil {
+		errs = append(errs, err)
+	}
+	if c.WebsocketFailures < 0 {
+		errs = append(errs, fmt.Errorf("websocket-failures cannot be negative"))
+	}
+	if c.WebsocketRetry < 0 {
+		errs = append(errs, fmt.Errorf("websocket-retry cannot be negative"))
+	}
+	if c.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll-interval cannot be negative"))
+	}
+	if c.PollBatchSize < 0 {
+		errs = append(errs, fmt.Errorf("poll-batch-size cannot be negative"))
+	}
+	return errs
+}
+
+// CheckNetwork compares the network id of the endpoint with the one of the nid
+func (p *Provider) CheckNetwork(ctx context.Context) error {
+	want, ok := providerTypes.ChainIDFromNID(p.NID())
+	if !ok {
+		return fmt.Errorf("nid %s has no network id prefix", p.NID())
+	}
+	got, err := p.networkID.BigInt()
+	if err != nil {
+		return err
+	}
+	if got.Cmp(want) != 0 {
+		return fmt.Errorf("rpc network id %s does not match nid %s", p.networkID, p.NID())
+	}
+	return nil
+}
+
+// DetectNetwork reads the network id of the rpc endpoints
+func (c *Config) DetectNetwork(ctx context.Context) (string, error) {
+	pool, err := rpcpool.New(c.ChainName, "rpc", c.RPCEndpoints(), c.RPCPool, probeHeight, zap.NewNop())
+	if err != nil {
+		return "", err
+	}
+	auth, err := c.RPCAuthClient()
+	if err != nil {
+		return "", err
+	}
+	pool.SetAuth(auth)
+	info, err := NewClient(ctx, pool, zap.NewNop()).GetNetworkInfo()
+	if err != nil {
+		return "", err
+	}
+	return string(info.NetworkID), nil
+}
+
+// CheckContracts checks that a score is deployed at the contract addresses
+func (p *Provider) CheckContracts(ctx context.Context) map[string]error {
+	errs := make(map[string]error)
+	for name, addr := range p.cfg.Contracts {
+		if addr == "" {
+			continue
+		}
+		if _, err := p.client.GetScoreApi(&types.AddressParam{Address: types.Address(addr)}); err != nil {
+			errs[name] = fmt.Errorf("no score deployed at %s: %w", addr, err)
+			continue
+		}
+		errs[name] = nil
+	}
+	return errs
+}
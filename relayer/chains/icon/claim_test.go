@@ -0,0 +1,29 @@
+package icon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/chains/icon/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimedAmount(t *testing.T) {
+	conn := types.Address("cx0000000000000000000000000000000000000001")
+	logs := []types.EventLogStr{
+		{Addr: conn, Indexed: []string{EventICXTransfer, string(conn), "hx01", "0xde0b6b3a7640000"}},
+		{Addr: conn, Indexed: []string{EventICXTransfer, string(conn), "hx01"}, Data: []string{"0x2b5e3af16b1880000"}},
+		// transfers of other contracts and other events are ignored
+		{Addr: "cx02", Indexed: []string{EventICXTransfer, "cx02", "hx01", "0x1"}},
+		{Addr: conn, Indexed: []string{EventICXTransfer, "hx01", string(conn), "0x1"}},
+		{Addr: conn, Indexed: []string{"Message(str,int,bytes)", string(conn), "hx01", "0x1"}},
+	}
+	amount, err := claimedAmount(conn, logs)
+	require.NoError(t, err)
+	expected, _ := new(big.Int).SetString("51000000000000000000", 10)
+	assert.Equal(t, expected, amount)
+
+	_, err = claimedAmount(conn, []types.EventLogStr{{Addr: conn, Indexed: []string{EventICXTransfer, string(conn), "hx01", "0xzz"}}})
+	assert.ErrorContains(t, err, "invalid transfer amount")
+}
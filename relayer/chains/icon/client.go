@@ -3,6 +3,7 @@ package icon
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -17,6 +18,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 
 	"github.com/icon-project/centralized-relay/relayer/chains/icon/types"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
 	"go.uber.org/zap"
 
@@ -71,6 +73,7 @@ type Client struct {
 	conns         map[string]*websocket.Conn
 	log           *zap.Logger
 	mtx           sync.Mutex
+	pool          *rpcpool.Pool
 }
 
 var txSerializeExcludes = map[string]bool{"signature": true}
@@ -318,8 +321,22 @@ type wsConnectError struct {
 }
 
 func (c *Client) wsConnect(reqUrl string, reqHeader http.Header) (*websocket.Conn, error) {
-	wsEndpoint := strings.Replace(c.Endpoint, "http", "ws", 1)
-	conn, httpResp, err := websocket.DefaultDialer.Dial(wsEndpoint+reqUrl, reqHeader)
+	endpoint := c.Endpoint
+	if c.pool != nil {
+		endpoint = c.pool.Current()
+	}
+	wsEndpoint := strings.Replace(endpoint, "http", "ws", 1)
+	dialer := websocket.DefaultDialer
+	if c.pool != nil {
+		if reqHeader == nil {
+			reqHeader = make(http.Header)
+		}
+		if err := c.pool.Auth().SetHeader(reqHeader); err != nil {
+			return nil, err
+		}
+		dialer = c.pool.Auth().WebsocketDialer()
+	}
+	conn, httpResp, err := dialer.Dial(wsEndpoint+reqUrl, reqHeader)
 	if err != nil {
 		wsErr := wsConnectError{error: err}
 		wsErr.httpResp = httpResp
@@ -419,6 +436,15 @@ func (c *Client) GetBlockHeaderByHeight(height int64) (*types.BlockHeader, error
 	return &blockHeader, nil
 }
 
+// GetScoreApi returns the api of the score deployed at the address
+func (c *Client) GetScoreApi(param *types.AddressParam) ([]interface{}, error) {
+	var result []interface{}
+	if _, err := c.Do("icx_getScoreApi", param, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *Client) GetBalance(param *types.AddressParam) (*big.Int, error) {
 	var result types.HexInt
 	_, err := c.Do("icx_getBalance", param, &result)
@@ -527,23 +553,153 @@ func (c *Client) GetNetworkInfo() (*types.NetworkInfo, error) {
 	return result, nil
 }
 
-func NewClient(ctx context.Context, uri string, l *zap.Logger) *Client {
+// BatchCall is a call of a json-rpc batch, the result of the call is decoded
+// into Result
+type BatchCall struct {
+	Method string
+	Params interface{}
+	Result interface{}
+}
+
+// Batch sends the calls in a single json-rpc batch request, the first error
+// of the calls is returned
+func (c *Client) Batch(calls []*BatchCall) error {
+	if len(calls) == 0 {
+		return nil
+	}
+	requests := make([]*jsonrpc.Request, len(calls))
+	for i, call := range calls {
+		method := call.Method
+		request := &jsonrpc.Request{ID: i, Version: jsonrpc.Version, Method: &method}
+		if call.Params != nil {
+			params, err := json.Marshal(call.Params)
+			if err != nil {
+				return err
+			}
+			request.Params = params
+		}
+		requests[i] = request
+	}
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return err
+	}
+	resp, err := c.Raw(body)
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var responses []*client.Response
+	if err := json.Unmarshal(data, &responses); err != nil {
+		// the endpoints without batch support answer with a single error
+		var single client.Response
+		if json.Unmarshal(data, &single) == nil && single.Error != nil {
+			return single.Error
+		}
+		return fmt.Errorf("invalid batch response: %w", err)
+	}
+	answered := make([]bool, len(calls))
+	for _, r := range responses {
+		id, ok := r.ID.(float64)
+		if !ok || id < 0 || int(id) >= len(calls) {
+			return fmt.Errorf("invalid batch response id %v", r.ID)
+		}
+		i := int(id)
+		call := calls[i]
+		if r.Error != nil {
+			return fmt.Errorf("%s: %w", call.Method, r.Error)
+		}
+		if call.Result != nil {
+			if err := json.Unmarshal(r.Result, call.Result); err != nil {
+				return fmt.Errorf("%s: %w", call.Method, err)
+			}
+		}
+		answered[i] = true
+	}
+	for i, ok := range answered {
+		if !ok {
+			return fmt.Errorf("%s: no response in batch", calls[i].Method)
+		}
+	}
+	return nil
+}
+
+// GetBlocksByHeight returns the blocks of the heights with a batch request
+func (c *Client) GetBlocksByHeight(heights []int64) ([]*types.Block, error) {
+	blocks := make([]*types.Block, len(heights))
+	calls := make([]*BatchCall, len(heights))
+	for i, height := range heights {
+		blocks[i] = &types.Block{}
+		calls[i] = &BatchCall{
+			Method: "icx_getBlockByHeight",
+			Params: &types.BlockHeightParam{Height: types.NewHexInt(height)},
+			Result: blocks[i],
+		}
+	}
+	if err := c.Batch(calls); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// GetTransactionResults returns the results of the transactions with a batch request
+func (c *Client) GetTransactionResults(hashes []types.HexBytes) ([]*types.TransactionResult, error) {
+	results := make([]*types.TransactionResult, len(hashes))
+	calls := make([]*BatchCall, len(hashes))
+	for i, hash := range hashes {
+		results[i] = &types.TransactionResult{}
+		calls[i] = &BatchCall{
+			Method: "icx_getTransactionResult",
+			Params: &types.TransactionHashParam{Hash: hash},
+			Result: results[i],
+		}
+	}
+	if err := c.Batch(calls); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// NewClient returns the client of the endpoints of the pool, the requests are
+// sent to the current endpoint and the monitors connect to it
+func NewClient(ctx context.Context, pool *rpcpool.Pool, l *zap.Logger) *Client {
 	// TODO options {MaxRetrySendTx, MaxRetryGetResult, MaxIdleConnsPerHost, Debug, Dump}
 	opts := IconOptions{
 		IconOptionsTimeout: "10s",
 		IconOptionsDebug:   "true",
 	}
+	uri := pool.URL()
 	tr := &http.Transport{MaxIdleConnsPerHost: 1000}
 	c := &Client{
-		JsonRpcClient: client.NewJsonRpcClient(&http.Client{Transport: tr}, uri),
+		JsonRpcClient: client.NewJsonRpcClient(pool.HTTPClient(tr), uri),
 		DebugEndPoint: guessDebugEndpoint(uri),
 		conns:         make(map[string]*websocket.Conn),
 		log:           l,
+		pool:          pool,
 	}
 	c.CustomHeader[HeaderKeyIconOptions] = opts.ToHeaderValue()
 	return c
 }
 
+// probeHeight returns the height of the last block of the endpoint
+var probeHeight = rpcpool.JSONRPCProbe("icx_getLastBlock", nil, func(result json.RawMessage) (uint64, error) {
+	var block struct {
+		Height json.RawMessage `json:"height"`
+	}
+	if err := json.Unmarshal(result, &block); err != nil {
+		return 0, err
+	}
+	return rpcpool.ParseNumber(block.Height)
+})
+
 func guessDebugEndpoint(endpoint string) string {
 	return strings.Replace(endpoint, "v3", "v3d", 1)
 }
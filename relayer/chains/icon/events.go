@@ -13,6 +13,9 @@ const (
 	RollbackMessage    = "RollbackMessage(int)"
 	PacketRegistered   = "PacketRegistered(str,str,int,int,str,str,bytes)"
 	PacketAcknowledged = "PacketAcknowledged(str,str,int,int,str,str,bytes,bytes)"
+
+	// EventICXTransfer is emitted for the ICX transferred by a contract
+	EventICXTransfer = "ICXTransfer(Address,Address,int)"
 )
 
 // EventSigToEventType converts event signature to event type
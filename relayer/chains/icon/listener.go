@@ -2,6 +2,7 @@ package icon
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -29,8 +30,44 @@ type btpBlockRequest struct {
 	response *btpBlockResponse
 }
 
+// The listener modes of the config
+const (
+	ListenerModeAuto      = "auto"
+	ListenerModeWebsocket = "websocket"
+	ListenerModePolling   = "polling"
+)
+
+const (
+	// DefaultWebsocketFailures is the number of consecutive websocket failures
+	// after which the auto mode polls the blocks
+	DefaultWebsocketFailures = 3
+	// DefaultWebsocketRetry is how long the auto mode polls before it tries
+	// the websocket again
+	DefaultWebsocketRetry = 5 * time.Minute
+	// DefaultPollInterval is close to the block time of icon
+	DefaultPollInterval = 2 * time.Second
+	// DefaultPollBatchSize is the number of calls of a batch request
+	DefaultPollBatchSize = 20
+)
+
+func validateListenerMode(mode string) error {
+	switch mode {
+	case "", ListenerModeAuto, ListenerModeWebsocket, ListenerModePolling:
+		return nil
+	}
+	return fmt.Errorf("listener-mode %s must be one of %s, %s or %s", mode, ListenerModeAuto, ListenerModeWebsocket, ListenerModePolling)
+}
+
+// monitorResult is the end of a websocket monitor, received is set when
+// the monitor received notifications before it failed
+type monitorResult struct {
+	err      error
+	received bool
+}
+
 func (p *Provider) Listener(ctx context.Context, lastProcessedTx providerTypes.LastProcessedTx, incoming chan *providerTypes.BlockInfo) error {
 	reconnectCh := make(chan struct{}, 1) // reconnect channel
+	monitorCh := make(chan monitorResult, 1)
 
 	reconnect := func() {
 		select {
@@ -47,6 +84,10 @@ func (p *Provider) Listener(ctx context.Context, lastProcessedTx providerTypes.L
 	}
 
 	p.log.Info("Start from height", zap.Int64("height", processedheight), zap.Uint64("finality block", p.FinalityBlock(ctx)))
+	if p.cfg.ListenerMode == ListenerModePolling {
+		_, err := p.pollBlocks(ctx, processedheight, incoming)
+		return err
+	}
 	// subscribe to monitor block
 	reconnect()
 
@@ -57,14 +98,53 @@ func (p *Provider) Listener(ctx context.Context, lastProcessedTx providerTypes.L
 		ProgressInterval: types.NewHexInt(25),
 	}
 
+	var failures int
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
+		case result := <-monitorCh:
+			if result.received {
+				failures = 0
+			}
+			failures++
+			p.log.Warn("error occured during monitor event", zap.Error(result.err), zap.Int("failures", failures))
+			if p.cfg.ListenerMode == ListenerModeAuto && failures >= p.cfg.WebsocketFailures {
+				height, err := eventReq.Height.Value()
+				if err != nil {
+					return err
+				}
+				p.log.Warn("websocket is failing, polling the blocks", zap.Int("failures", failures), zap.Int64("height", height), zap.Duration("retry", p.cfg.WebsocketRetry))
+				pollCtx, cancel := context.WithTimeout(ctx, p.cfg.WebsocketRetry)
+				height, err = p.pollBlocks(pollCtx, height, incoming)
+				cancel()
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if !errors.Is(err, context.DeadlineExceeded) {
+					return err
+				}
+				// the monitor has returned, the request is not shared
+				eventReq.Height = types.NewHexInt(height)
+				failures = 0
+				p.log.Info("retrying the websocket", zap.Int64("height", height))
+				reconnect()
+				continue
+			}
+			go func() {
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Second * 3):
+					reconnect()
+				}
+			}()
 		case <-reconnectCh:
 			ctxMonitorBlock, cancelMonitorBlock := context.WithCancel(ctx)
 			go func(ctx context.Context, cancel context.CancelFunc) {
+				defer cancel()
+				var received bool
 				err := p.client.MonitorEvent(ctx, eventReq, incoming, func(v *types.EventNotification, outgoing chan *providerTypes.BlockInfo) error {
+					received = true
 					if !errors.Is(ctx.Err(), context.Canceled) {
 						p.log.Debug("event notification received", zap.Any("event", v))
 						if v.Progress != "" {
@@ -87,14 +167,7 @@ func (p *Provider) Listener(ctx context.Context, lastProcessedTx providerTypes.L
 							return err
 						}
 						for _, msg := range msgs {
-							p.log.Info("Detected eventlog",
-								zap.String("src", msg.Src),
-								zap.String("dst", msg.Dst),
-								zap.Uint64("sn", msg.Sn.Uint64()),
-								zap.Any("req_id", msg.ReqID),
-								zap.String("event_type", msg.EventType),
-								zap.Uint64("height", msg.MessageHeight),
-							)
+							p.logMessage(msg)
 							outgoing <- &providerTypes.BlockInfo{
 								Messages: []*providerTypes.Message{msg},
 								Height:   msg.MessageHeight,
@@ -107,15 +180,24 @@ func (p *Provider) Listener(ctx context.Context, lastProcessedTx providerTypes.L
 					if errors.Is(err, context.Canceled) {
 						return
 					}
-					time.Sleep(time.Second * 3)
-					reconnect()
-					p.log.Warn("error occured during monitor event", zap.Error(err))
+					monitorCh <- monitorResult{err: err, received: received}
 				}
 			}(ctxMonitorBlock, cancelMonitorBlock)
 		}
 	}
 }
 
+func (p *Provider) logMessage(msg *providerTypes.Message) {
+	p.log.Info("Detected eventlog",
+		zap.String("src", msg.Src),
+		zap.String("dst", msg.Dst),
+		zap.Uint64("sn", msg.Sn.Uint64()),
+		zap.Any("req_id", msg.ReqID),
+		zap.String("event_type", msg.EventType),
+		zap.Uint64("height", msg.MessageHeight),
+	)
+}
+
 func (p *Provider) StartFromHeight(ctx context.Context, lastSavedHeight uint64) (int64, error) {
 	latestHeight, err := p.QueryLatestHeight(ctx)
 	if err != nil {
@@ -20,4 +20,9 @@ var (
 	MethodClusterMsgReceived       = "packetSubmitted"
 	MethodPacketAcknowledged       = "packetAcknowledged"
 	MethodRecvMessageWithSignature = "recvMessageWithSignatures"
+
+	// Governance Methods
+	MethodGetStepPrice = "getStepPrice"
+
+	GovernanceAddress = "cx0000000000000000000000000000000000000001"
 )
@@ -0,0 +1,120 @@
+package icon
+
+import (
+	"context"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/chains/icon/types"
+	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+// dataTypeBase is the data type of the base transaction of the blocks, it
+// never emits the events of the contracts
+const dataTypeBase = "base"
+
+// pollBlocks reads the events of the blocks from the height with http requests,
+// for the endpoints without websocket. The blocks and the transaction results
+// are read with batch requests of poll-batch-size calls. It returns the height
+// to read next when the context is done
+func (p *Provider) pollBlocks(ctx context.Context, height int64, incoming chan *providerTypes.BlockInfo) (int64, error) {
+	p.log.Info("polling blocks", zap.Int64("height", height), zap.Duration("interval", p.cfg.PollInterval))
+	filters := p.GetMonitorEventFilters()
+	ticker := time.NewTicker(p.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		latest, err := p.QueryLatestHeight(ctx)
+		if err != nil {
+			p.log.Warn("failed to get latest height", zap.Error(err))
+		}
+		for err == nil && height <= int64(latest) {
+			to := min(int64(latest), height+int64(p.cfg.PollBatchSize)-1)
+			var msgs []*providerTypes.Message
+			if msgs, err = p.blockMessages(height, to, filters); err != nil {
+				p.log.Warn("failed to poll blocks", zap.Int64("from", height), zap.Int64("to", to), zap.Error(err))
+				break
+			}
+			for _, msg := range msgs {
+				p.logMessage(msg)
+				select {
+				case incoming <- &providerTypes.BlockInfo{Messages: []*providerTypes.Message{msg}, Height: msg.MessageHeight}:
+				case <-ctx.Done():
+					return height, ctx.Err()
+				}
+			}
+			height = to + 1
+		}
+		select {
+		case <-ctx.Done():
+			return height, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// blockMessages returns the messages of the events matching the filters in
+// the blocks from and to included, in the order of the blocks and transactions
+func (p *Provider) blockMessages(from, to int64, filters []*types.EventFilter) ([]*providerTypes.Message, error) {
+	heights := make([]int64, 0, to-from+1)
+	for height := from; height <= to; height++ {
+		heights = append(heights, height)
+	}
+	blocks, err := p.client.GetBlocksByHeight(heights)
+	if err != nil {
+		return nil, err
+	}
+	var hashes []types.HexBytes
+	for _, block := range blocks {
+		for _, tx := range block.NormalTransactions {
+			if tx.DataType != dataTypeBase {
+				hashes = append(hashes, tx.TxHash)
+			}
+		}
+	}
+
+	var messages []*providerTypes.Message
+	for start := 0; start < len(hashes); start += p.cfg.PollBatchSize {
+		end := min(len(hashes), start+p.cfg.PollBatchSize)
+		results, err := p.client.GetTransactionResults(hashes[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			height, err := result.BlockHeight.Int64()
+			if err != nil {
+				return nil, err
+			}
+			for _, log := range result.EventLogs {
+				if !matchEventFilters(filters, log) {
+					continue
+				}
+				event := types.EventNotificationLog{
+					Address: log.Addr,
+					Indexed: log.Indexed,
+					Data:    log.Data,
+				}
+				msg, err := p.parseMessageFromEventLog(uint64(height), &event)
+				if err != nil {
+					p.log.Warn("received invalid event", zap.Error(err))
+				} else if msg != nil {
+					messages = append(messages, msg)
+				}
+			}
+		}
+	}
+	return messages, nil
+}
+
+// matchEventFilters reports whether the event log matches the address and
+// the signature of one of the filters of the websocket monitor
+func matchEventFilters(filters []*types.EventFilter, log types.EventLogStr) bool {
+	if len(log.Indexed) == 0 {
+		return false
+	}
+	for _, filter := range filters {
+		if filter.Addr == log.Addr && filter.Signature == log.Indexed[0] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,315 @@
+package icon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/chains/icon/types"
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/icon-project/goloop/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	pollConnection = "cx0000000000000000000000000000000000000001"
+	pollXcall      = "cx0000000000000000000000000000000000000002"
+	pollOther      = "cx0000000000000000000000000000000000000003"
+)
+
+// pollNode serves the blocks and transaction results of a chain over json-rpc
+type pollNode struct {
+	mu      sync.Mutex
+	latest  int64
+	blocks  map[int64][]string
+	results map[string]map[string]any
+	// batches counts the batch requests by method
+	batches map[string]int
+	// websockets counts the websocket handshakes, they are refused
+	websockets int
+}
+
+func newPollNode() *pollNode {
+	return &pollNode{
+		blocks:  make(map[int64][]string),
+		results: make(map[string]map[string]any),
+		batches: make(map[string]int),
+	}
+}
+
+// addTx adds a transaction emitting the event logs at the height
+func (n *pollNode) addTx(height int64, hash string, logs ...map[string]any) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.blocks[height] = append(n.blocks[height], hash)
+	n.results[hash] = map[string]any{
+		"blockHeight": fmt.Sprintf("0x%x", height),
+		"txHash":      hash,
+		"status":      "0x1",
+		"eventLogs":   logs,
+	}
+	n.latest = max(n.latest, height)
+}
+
+func (n *pollNode) result(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "icx_getLastBlock":
+		return map[string]any{"height": n.latest}, nil
+	case "icx_getBlockByHeight":
+		var p types.BlockHeightParam
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		height, err := p.Height.Int64()
+		if err != nil {
+			return nil, err
+		}
+		txs := []map[string]any{{"txHash": "0xbase", "dataType": "base"}}
+		for _, hash := range n.blocks[height] {
+			txs = append(txs, map[string]any{"txHash": hash, "dataType": "call"})
+		}
+		return map[string]any{"height": height, "confirmed_transaction_list": txs}, nil
+	case "icx_getTransactionResult":
+		var p types.TransactionHashParam
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		result, ok := n.results[string(p.Hash)]
+		if !ok {
+			return nil, fmt.Errorf("unexpected transaction %s", p.Hash)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func (n *pollNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if r.Header.Get("Upgrade") == "websocket" {
+		n.websockets++
+		http.Error(w, "websocket is not supported", http.StatusServiceUnavailable)
+		return
+	}
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	type request struct {
+		ID     any             `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	respond := func(req request) map[string]any {
+		result, err := n.result(req.Method, req.Params)
+		if err != nil {
+			return map[string]any{"jsonrpc": "2.0", "id": req.ID, "error": map[string]any{"code": -32000, "message": err.Error()}}
+		}
+		return map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if body[0] != '[' {
+		var req request
+		json.Unmarshal(body, &req)
+		json.NewEncoder(w).Encode(respond(req))
+		return
+	}
+	var reqs []request
+	json.Unmarshal(body, &reqs)
+	n.batches[reqs[0].Method]++
+	// the responses of a batch may come in any order
+	responses := make([]map[string]any, 0, len(reqs))
+	for i := len(reqs) - 1; i >= 0; i-- {
+		responses = append(responses, respond(reqs[i]))
+	}
+	json.NewEncoder(w).Encode(responses)
+}
+
+func emitMessageLog(addr, dst string, sn int64) map[string]any {
+	return map[string]any{
+		"scoreAddress": addr,
+		"indexed":      []string{EmitMessage, dst, fmt.Sprintf("0x%x", sn)},
+		"data":         []string{"0x0102"},
+	}
+}
+
+func newPollProvider(t *testing.T, node *pollNode) *Provider {
+	srv := httptest.NewServer(node)
+	t.Cleanup(srv.Close)
+	cfg := &Config{
+		CommonConfig: provider.CommonConfig{
+			NID: "0x2.icon",
+			Contracts: providerTypes.ContractConfigMap{
+				providerTypes.ConnectionContract: pollConnection,
+				providerTypes.XcallContract:      pollXcall,
+			},
+		},
+		ListenerMode:  ListenerModePolling,
+		PollInterval:  10 * time.Millisecond,
+		PollBatchSize: 2,
+	}
+	return &Provider{
+		log:       zap.NewNop(),
+		cfg:       cfg,
+		client:    &Client{JsonRpcClient: client.NewJsonRpcClient(srv.Client(), srv.URL), log: zap.NewNop()},
+		contracts: cfg.eventMap(),
+	}
+}
+
+func TestBlockMessages(t *testing.T) {
+	node := newPollNode()
+	node.addTx(10, "0x01", emitMessageLog(pollConnection, "0x1.eth", 1))
+	// the events of the other contracts are filtered out
+	node.addTx(11, "0x02", emitMessageLog(pollOther, "0x1.eth", 2))
+	node.addTx(12, "0x03", emitMessageLog(pollConnection, "0x1.eth", 3), emitMessageLog(pollConnection, "0x1.eth", 4))
+	node.addTx(12, "0x04")
+	p := newPollProvider(t, node)
+
+	msgs, err := p.blockMessages(10, 12, p.GetMonitorEventFilters())
+	require.NoError(t, err)
+	require.Len(t, msgs, 3)
+	for i, want := range []struct {
+		sn     int64
+		height uint64
+	}{{1, 10}, {3, 12}, {4, 12}} {
+		assert.Equal(t, big.NewInt(want.sn), msgs[i].Sn)
+		assert.Equal(t, want.height, msgs[i].MessageHeight)
+		assert.Equal(t, events.EmitMessage, msgs[i].EventType)
+		assert.Equal(t, "0x2.icon", msgs[i].Src)
+		assert.Equal(t, "0x1.eth", msgs[i].Dst)
+		assert.Equal(t, []byte{1, 2}, msgs[i].Data)
+	}
+	// the blocks are read in one batch, the 3 results in batches of 2
+	assert.Equal(t, 1, node.batches["icx_getBlockByHeight"])
+	assert.Equal(t, 2, node.batches["icx_getTransactionResult"])
+
+	// the range is read by batches of blocks without websocket
+	msgs, err = p.QueryBlockMessages(context.Background(), 10, 12)
+	require.NoError(t, err)
+	assert.Len(t, msgs, 3)
+}
+
+func TestPollBlocks(t *testing.T) {
+	node := newPollNode()
+	node.addTx(5, "0x01", emitMessageLog(pollConnection, "0x1.eth", 1))
+	p := newPollProvider(t, node)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	incoming := make(chan *providerTypes.BlockInfo)
+	type result struct {
+		height int64
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		height, err := p.pollBlocks(ctx, 3, incoming)
+		done <- result{height, err}
+	}()
+
+	receive := func() *providerTypes.BlockInfo {
+		select {
+		case b := <-incoming:
+			return b
+		case <-time.After(5 * time.Second):
+			t.Fatal("no block received")
+			return nil
+		}
+	}
+	b := receive()
+	assert.Equal(t, uint64(5), b.Height)
+	assert.Equal(t, big.NewInt(1), b.Messages[0].Sn)
+
+	// the new blocks are read on the next polls
+	node.addTx(8, "0x02", emitMessageLog(pollConnection, "0x1.eth", 2))
+	b = receive()
+	assert.Equal(t, uint64(8), b.Height)
+	assert.Equal(t, big.NewInt(2), b.Messages[0].Sn)
+
+	// the next height is returned for the websocket to resume from
+	cancel()
+	r := <-done
+	assert.ErrorIs(t, r.err, context.Canceled)
+	assert.Equal(t, int64(9), r.height)
+}
+
+func TestListenerAutoRetriesWebsocket(t *testing.T) {
+	node := newPollNode()
+	node.addTx(5, "0x01", emitMessageLog(pollConnection, "0x1.eth", 1))
+	p := newPollProvider(t, node)
+	p.cfg.ListenerMode = ListenerModeAuto
+	p.cfg.WebsocketFailures = 1
+	p.cfg.WebsocketRetry = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	incoming := make(chan *providerTypes.BlockInfo, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- p.Listener(ctx, providerTypes.LastProcessedTx{Height: 3}, incoming)
+	}()
+
+	websockets := func() int {
+		node.mu.Lock()
+		defer node.mu.Unlock()
+		return node.websockets
+	}
+	// the websocket is tried again after each period of polling
+	assert.Eventually(t, func() bool { return websockets() >= 3 }, 5*time.Second, 10*time.Millisecond)
+
+	// the polling resumes from the height it reached, the messages are read once
+	node.addTx(8, "0x02", emitMessageLog(pollConnection, "0x1.eth", 2))
+	var sns []int64
+	assert.Eventually(t, func() bool {
+		for {
+			select {
+			case b := <-incoming:
+				sns = append(sns, b.Messages[0].Sn.Int64())
+			default:
+				return len(sns) >= 2
+			}
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []int64{1, 2}, sns)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestBatchErrors(t *testing.T) {
+	node := newPollNode()
+	p := newPollProvider(t, node)
+
+	// the error of a call fails the batch
+	_, err := p.client.GetTransactionResults([]types.HexBytes{"0x05"})
+	assert.ErrorContains(t, err, "icx_getTransactionResult")
+	assert.ErrorContains(t, err, "unexpected transaction 0x05")
+
+	// an endpoint without batch support answers with a single error
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch is not allowed"}}`))
+	}))
+	defer srv.Close()
+	c := &Client{JsonRpcClient: client.NewJsonRpcClient(srv.Client(), srv.URL), log: zap.NewNop()}
+	_, err = c.GetBlocksByHeight([]int64{1})
+	assert.ErrorContains(t, err, "batch is not allowed")
+}
+
+func TestValidateListenerMode(t *testing.T) {
+	for _, mode := range []string{"", ListenerModeAuto, ListenerModeWebsocket, ListenerModePolling} {
+		assert.NoError(t, validateListenerMode(mode))
+	}
+	assert.EqualError(t, validateListenerMode("http"), "listener-mode http must be one of auto, websocket or polling")
+}
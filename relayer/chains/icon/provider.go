@@ -11,6 +11,7 @@ import (
 	"github.com/icon-project/centralized-relay/relayer/events"
 	"github.com/icon-project/centralized-relay/relayer/kms"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
 	"github.com/icon-project/goloop/module"
 	"github.com/pkg/errors"
@@ -24,6 +25,16 @@ type Config struct {
 	StepMin               int64 `json:"step-min" yaml:"step-min"`
 	StepLimit             int64 `json:"step-limit" yaml:"step-limit"`
 	StepAdjustment        int64 `json:"step-adjustment" yaml:"step-adjustment"`
+	// ListenerMode is how the listener reads the events: websocket, polling,
+	// or auto which polls after websocket-failures consecutive failures and
+	// tries the websocket again after websocket-retry
+	ListenerMode      string        `json:"listener-mode" yaml:"listener-mode"`
+	WebsocketFailures int           `json:"websocket-failures" yaml:"websocket-failures"`
+	WebsocketRetry    time.Duration `json:"websocket-retry" yaml:"websocket-retry"`
+	// PollInterval is the wait for new blocks when polling
+	PollInterval time.Duration `json:"poll-interval" yaml:"poll-interval"`
+	// PollBatchSize is the number of calls sent in a json-rpc batch when polling
+	PollBatchSize int `json:"poll-batch-size" yaml:"poll-batch-size"`
 }
 
 // NewProvider returns new Icon provider
@@ -35,21 +46,39 @@ func (c *Config) NewProvider(ctx context.Context, log *zap.Logger, homepath stri
 		return nil, err
 	}
 
-	client := NewClient(ctx, c.RPCUrl, log)
+	pool, err := rpcpool.New(chainName, "rpc", c.RPCEndpoints(), c.RPCPool, probeHeight, log)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := c.RPCAuthClient()
+	if err != nil {
+		return nil, err
+	}
+	pool.SetAuth(auth)
+	client := NewClient(ctx, pool, log)
 	NetworkInfo, err := client.GetNetworkInfo()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get network id: %v", err)
 	}
+	// the health checks run until the provider is closed
+	ctx, cancel := context.WithCancel(ctx)
 
 	c.ChainName = chainName
 	c.HomeDir = homepath
 
+	// the monitors are closed on changes so the listener connects to the new endpoint
+	pool.OnChange(func(url string) {
+		client.CloseAllMonitor()
+	})
+	go pool.Start(ctx)
+
 	return &Provider{
 		log:       log.With(zap.Stringp("nid ", &c.NID), zap.Stringp("name", &c.ChainName)),
 		client:    client,
 		cfg:       c,
 		networkID: NetworkInfo.NetworkID,
 		contracts: c.eventMap(),
+		cancel:    cancel,
 	}, nil
 }
 
@@ -62,6 +91,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("contracts are not valid: %s", err)
 	}
 
+	if err := validateListenerMode(c.ListenerMode); err != nil {
+		return err
+	}
+
 	// TODO: validation for keystore
 	// TODO: contractaddress validation
 	// TODO: account should have some balance no balance then use another accoutn
@@ -76,6 +109,21 @@ func (c *Config) sanitize() error {
 	if c.Decimals == 0 {
 		c.Decimals = providerTypes.DefaultCoinDecimals
 	}
+	if c.ListenerMode == "" {
+		c.ListenerMode = ListenerModeAuto
+	}
+	if c.WebsocketFailures <= 0 {
+		c.WebsocketFailures = DefaultWebsocketFailures
+	}
+	if c.WebsocketRetry <= 0 {
+		c.WebsocketRetry = DefaultWebsocketRetry
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = DefaultPollInterval
+	}
+	if c.PollBatchSize <= 0 {
+		c.PollBatchSize = DefaultPollBatchSize
+	}
 	return nil
 }
 
@@ -101,6 +149,8 @@ type Provider struct {
 	contracts           map[string]providerTypes.EventMap
 	networkID           types.HexInt
 	LastSavedHeightFunc func() uint64
+	// cancel stops the health checks of the pool
+	cancel context.CancelFunc
 }
 
 func (p *Provider) GetLastProcessedBlockHeight(ctx context.Context) (uint64, error) {
@@ -109,6 +159,19 @@ func (p *Provider) GetLastProcessedBlockHeight(ctx context.Context) (uint64, err
 
 func (p *Provider) QueryBlockMessages(ctx context.Context, fromHeight, toHeight uint64) ([]*providerTypes.Message, error) {
 	var messages []*providerTypes.Message
+	// the endpoints without websocket are read with http requests
+	if p.cfg.ListenerMode == ListenerModePolling {
+		filters := p.GetMonitorEventFilters()
+		for from := int64(fromHeight); from <= int64(toHeight); from += int64(p.cfg.PollBatchSize) {
+			to := min(int64(toHeight), from+int64(p.cfg.PollBatchSize)-1)
+			msgs, err := p.blockMessages(from, to, filters)
+			if err != nil {
+				return nil, err
+			}
+			messages = append(messages, msgs...)
+		}
+		return messages, nil
+	}
 	eventReq := &types.EventRequest{
 		Height:           types.NewHexInt(int64(fromHeight)),
 		EventFilter:      p.GetMonitorEventFilters(),
@@ -163,6 +226,33 @@ func (p *Provider) Init(ctx context.Context, homepath string, kms kms.KMS) error
 	return nil
 }
 
+// Close stops the health checks of the pool and closes the monitors and the
+// connections of the client
+func (p *Provider) Close() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.client.CloseAllMonitor()
+	p.client.pool.Close()
+	return nil
+}
+
+// EndpointReaders returns a provider per rpc endpoint for the quorum reads
+func (p *Provider) EndpointReaders(ctx context.Context) ([]*provider.EndpointReader, error) {
+	var readers []*provider.EndpointReader
+	for _, url := range p.client.pool.Endpoints() {
+		pool, err := p.client.pool.Pin(url)
+		if err != nil {
+			return nil, err
+		}
+		reader := *p
+		// closing the reader only closes its own client and pinned pool
+		reader.client, reader.cancel = NewClient(ctx, pool, p.log), nil
+		readers = append(readers, &provider.EndpointReader{Endpoint: pool.Label(), Provider: &reader})
+	}
+	return readers, nil
+}
+
 func (p *Provider) Type() string {
 	return "icon"
 }
@@ -334,19 +424,51 @@ func (p *Provider) SetFee(ctx context.Context, networkID string, msgFee, resFee
 
 // ClaimFees
 func (p *Provider) ClaimFee(ctx context.Context) error {
-	msg := p.NewIconMessage(types.Address(p.cfg.Contracts[providerTypes.ConnectionContract]), map[string]interface{}{}, MethodClaimFees)
+	_, err := p.ClaimFeeAmount(ctx)
+	return err
+}
+
+// ClaimFeeAmount claims the fees and returns the ICX transferred by the
+// connection in the claim transaction
+func (p *Provider) ClaimFeeAmount(ctx context.Context) (*big.Int, error) {
+	conn := types.Address(p.cfg.Contracts[providerTypes.ConnectionContract])
+	msg := p.NewIconMessage(conn, map[string]interface{}{}, MethodClaimFees)
 	txHash, err := p.SendTransaction(ctx, msg)
 	if err != nil {
-		return fmt.Errorf("ClaimFees: %v", err)
+		return nil, fmt.Errorf("ClaimFees: %v", err)
 	}
 	txr, err := p.client.WaitForResults(ctx, &types.TransactionHashParam{Hash: types.NewHexBytes(txHash)})
 	if err != nil {
-		return fmt.Errorf("ClaimFees: WaitForResults: %v", err)
+		return nil, fmt.Errorf("ClaimFees: WaitForResults: %v", err)
 	}
 	if txr.Status != types.NewHexInt(1) {
-		return fmt.Errorf("ClaimFees: failed to claim fees: %s", txr.TxHash)
+		return nil, fmt.Errorf("ClaimFees: failed to claim fees: %s", txr.TxHash)
 	}
-	return nil
+	return claimedAmount(conn, txr.EventLogs)
+}
+
+// claimedAmount sums the ICXTransfer events of the connection, the amount is
+// indexed or in the data of the event
+func claimedAmount(conn types.Address, logs []types.EventLogStr) (*big.Int, error) {
+	amount := new(big.Int)
+	for _, log := range logs {
+		if log.Addr != conn || len(log.Indexed) < 3 || log.Indexed[0] != EventICXTransfer || log.Indexed[1] != string(conn) {
+			continue
+		}
+		value := ""
+		switch {
+		case len(log.Indexed) > 3:
+			value = log.Indexed[3]
+		case len(log.Data) > 0:
+			value = log.Data[0]
+		}
+		transferred, err := types.HexInt(value).BigInt()
+		if err != nil {
+			return nil, fmt.Errorf("ClaimFees: invalid transfer amount %q: %w", value, err)
+		}
+		amount.Add(amount, transferred)
+	}
+	return amount, nil
 }
 
 // ExecuteRollback
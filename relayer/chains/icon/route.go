@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/icon-project/centralized-relay/relayer/chains/icon/types"
 	"github.com/icon-project/centralized-relay/relayer/events"
 	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/icon-project/goloop/module"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -124,17 +127,7 @@ func (p *Provider) SendTransaction(ctx context.Context, msg *IconMessage) ([]byt
 		return nil, err
 	}
 
-	txParam := types.TransactionParam{
-		Version:     types.NewHexInt(JsonrpcApiVersion),
-		FromAddress: types.NewAddress(wallet.Address().Bytes()),
-		ToAddress:   msg.Address,
-		NetworkID:   p.NetworkID(),
-		DataType:    "call",
-		Data: types.CallData{
-			Method: msg.Method,
-			Params: msg.Params,
-		},
-	}
+	txParam := p.newTransactionParam(wallet, msg)
 
 	stepsHexInt := types.NewHexInt(2_000_000)
 	if p.cfg.StepDefault > 0 {
@@ -176,6 +169,56 @@ func (p *Provider) SendTransaction(ctx context.Context, msg *IconMessage) ([]byt
 	return txParam.TxHash.Value()
 }
 
+func (p *Provider) newTransactionParam(wallet module.Wallet, msg *IconMessage) types.TransactionParam {
+	return types.TransactionParam{
+		Version:     types.NewHexInt(JsonrpcApiVersion),
+		FromAddress: types.NewAddress(wallet.Address().Bytes()),
+		ToAddress:   msg.Address,
+		NetworkID:   p.NetworkID(),
+		DataType:    "call",
+		Data: types.CallData{
+			Method: msg.Method,
+			Params: msg.Params,
+		},
+	}
+}
+
+// EstimateCost returns the expected fee of delivering the message in loop
+func (p *Provider) EstimateCost(ctx context.Context, message *providerTypes.Message) (*big.Int, error) {
+	wallet, err := p.Wallet()
+	if err != nil {
+		return nil, err
+	}
+	iconMessage, err := p.MakeIconMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := p.client.EstimateStep(p.newTransactionParam(wallet, iconMessage))
+	if err != nil {
+		return nil, fmt.Errorf("failed estimating step: %w", err)
+	}
+	stepsUsed, err := steps.BigInt()
+	if err != nil {
+		return nil, err
+	}
+	stepsUsed.Add(stepsUsed, new(big.Int).Div(new(big.Int).Mul(stepsUsed, big.NewInt(p.cfg.StepAdjustment)), big.NewInt(100)))
+
+	var stepPrice types.HexInt
+	if err := p.client.Call(p.prepareCallParams(MethodGetStepPrice, GovernanceAddress, nil), &stepPrice); err != nil {
+		return nil, fmt.Errorf("failed to get step price: %w", err)
+	}
+	price, err := stepPrice.BigInt()
+	if err != nil {
+		return nil, err
+	}
+	return stepsUsed.Mul(stepsUsed, price), nil
+}
+
+// MaxTxWait returns the wait for the result of a transaction before it fails
+func (p *Provider) MaxTxWait() time.Duration {
+	return time.Duration(providerTypes.MaxTxRetry) * DefaultGetTransactionResultPollingInterval
+}
+
 func (p *Provider) WaitForTxResult(
 	ctx context.Context,
 	txHash []byte,
@@ -201,6 +244,7 @@ func (p *Provider) WaitForTxResult(
 	}
 
 	res.Height = height
+	res.GasUsed, res.TxFee = p.txFee(txRes)
 
 	if status, err := txRes.Status.Int(); status != 1 || err != nil {
 		res.Code = providerTypes.Failed
@@ -211,6 +255,19 @@ func (p *Provider) WaitForTxResult(
 	}
 }
 
+// txFee returns the steps used and the fee paid for the transaction
+func (p *Provider) txFee(result *types.TransactionResult) (uint64, *big.Int) {
+	stepUsed, err := result.StepUsed.BigInt()
+	if err != nil {
+		return 0, nil
+	}
+	stepPrice, err := result.StepPrice.BigInt()
+	if err != nil {
+		return stepUsed.Uint64(), nil
+	}
+	return stepUsed.Uint64(), new(big.Int).Mul(stepUsed, stepPrice)
+}
+
 func (p *Provider) LogSuccessTx(method string, result *types.TransactionResult) {
 	stepUsed, err := result.StepUsed.Value()
 	if err != nil {
@@ -0,0 +1,24 @@
+package plugin
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// CheckConfig checks the required fields and that the plugin command is an
+// executable, the options are checked by the plugin when it is configured
+func (pc *Config) CheckConfig() []error {
+	var errs []error
+	if pc.NID == "" {
+		errs = append(errs, fmt.Errorf("nid is required"))
+	}
+	if pc.Command == "" {
+		errs = append(errs, fmt.Errorf("command is required"))
+	} else if _, err := exec.LookPath(pc.Command); err != nil {
+		errs = append(errs, fmt.Errorf("command %s is not executable: %w", pc.Command, err))
+	}
+	if pc.StartTimeout < 0 {
+		errs = append(errs, fmt.Errorf("start-timeout cannot be negative"))
+	}
+	return errs
+}
@@ -0,0 +1,401 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"sync"
+	"time"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/icon-project/centralized-relay/relayer/kms"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// clients are the started plugin processes keyed by the chain name and the
+// config they serve, the providers of an unchanged chain built on a config
+// reload share the process of the running provider and it is stopped when
+// the last of them is closed
+var clients = struct {
+	sync.Mutex
+	m map[string]*client
+}{m: make(map[string]*client)}
+
+var (
+	// exitPollInterval is the interval the plugin processes are checked at
+	exitPollInterval = time.Second
+	// minRestartDelay and maxRestartDelay bound the backoff of the restarts
+	// of an exited process, the delay is reset once the process stayed up
+	// for maxRestartDelay
+	minRestartDelay = time.Second
+	maxRestartDelay = time.Minute
+)
+
+var (
+	errPluginExited = errors.New("plugin process exited")
+	errClosed       = errors.New("plugin is closed")
+)
+
+// Cleanup stops the plugin processes, it is called before the relayer exits
+func Cleanup() {
+	goplugin.CleanupClients()
+}
+
+// client is the connection of the relayer to a plugin process, the process
+// is started again when it exits
+type client struct {
+	key string
+	// refs counts the providers using the process, guarded by clients
+	refs int
+	cfg  *Config
+	log  *zap.Logger
+	// done is closed when the last provider releases the process
+	done chan struct{}
+
+	mu     sync.Mutex
+	conn   *grpc.ClientConn
+	broker *goplugin.GRPCBroker
+	// process is nil when the connection was not opened by startClient
+	process         *goplugin.Client
+	closed          bool
+	callbacks       map[uint64]pendingCallback
+	nextCallback    uint64
+	lastSavedHeight func() uint64
+	kms             kms.KMS
+
+	// configMu serializes the configuration, the plugin calls the host
+	// service while it is initialized
+	configMu sync.Mutex
+	// host is the broker id of the host service, 0 until it is served
+	host uint32
+	// configured is the config sent to the plugin, homePath and debug are
+	// sent again to a restarted process
+	configured string
+	homePath   string
+	debug      bool
+}
+
+// pendingCallback is the callback of a message routed by the plugin
+type pendingCallback struct {
+	key      *types.MessageKey
+	callback types.TxResponseFunc
+}
+
+func newClient(broker *goplugin.GRPCBroker, conn *grpc.ClientConn) *client {
+	return &client{
+		conn:      conn,
+		broker:    broker,
+		callbacks: make(map[uint64]pendingCallback),
+	}
+}
+
+// startClient starts the plugin process of the config, the process of the
+// same config is shared, it is restarted by its client when it exits
+func startClient(cfg *Config, log *zap.Logger) (*client, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	key := cfg.ChainName + "\x00" + string(data)
+
+	clients.Lock()
+	defer clients.Unlock()
+	if c, ok := clients.m[key]; ok {
+		c.refs++
+		return c, nil
+	}
+
+	process, c, err := launch(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+	c.process = process
+	c.key = key
+	c.refs = 1
+	c.cfg = cfg
+	c.log = log
+	c.done = make(chan struct{})
+	clients.m[key] = c
+	go c.watch()
+	return c, nil
+}
+
+// launch starts the plugin process and connects to it
+func launch(cfg *Config, log *zap.Logger) (*goplugin.Client, *client, error) {
+	cmd := exec.Command(cfg.Command, cfg.Args...)
+	// the variables of the config take precedence over the environment
+	cmd.Env = os.Environ()
+	names := make([]string, 0, len(cfg.Env))
+	for name := range cfg.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmd.Env = append(cmd.Env, name+"="+cfg.Env[name])
+	}
+	process := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig:  Handshake,
+		Plugins:          goplugin.PluginSet{pluginName: &chainPlugin{}},
+		Cmd:              cmd,
+		SkipHostEnv:      true,
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		StartTimeout:     cfg.startTimeout(),
+		Managed:          true,
+		Logger:           newHCLogger(log),
+	})
+	rpcClient, err := process.Client()
+	if err != nil {
+		process.Kill()
+		return nil, nil, fmt.Errorf("failed to start plugin %s: %w", cfg.Command, err)
+	}
+	raw, err := rpcClient.Dispense(pluginName)
+	if err != nil {
+		process.Kill()
+		return nil, nil, fmt.Errorf("failed to connect to plugin %s: %w", cfg.Command, err)
+	}
+	return process, raw.(*client), nil
+}
+
+// close releases the process, it is stopped when no provider uses it
+func (c *client) close() {
+	if c.done == nil {
+		return
+	}
+	clients.Lock()
+	c.refs--
+	if c.refs > 0 {
+		clients.Unlock()
+		return
+	}
+	if clients.m[c.key] == c {
+		delete(clients.m, c.key)
+	}
+	clients.Unlock()
+	c.mu.Lock()
+	c.closed = true
+	process := c.process
+	c.mu.Unlock()
+	close(c.done)
+	process.Kill()
+}
+
+// connection returns the connection to the current process
+func (c *client) connection() *grpc.ClientConn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn
+}
+
+func (c *client) exited() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.process.Exited()
+}
+
+// watch fails the pending callbacks when the process exits, so that their
+// messages are retried, and starts the process again with a backoff
+func (c *client) watch() {
+	ticker := time.NewTicker(exitPollInterval)
+	defer ticker.Stop()
+	delay := minRestartDelay
+	started := time.Now()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+		}
+		if !c.exited() {
+			continue
+		}
+		c.failCallbacks(errPluginExited)
+		if time.Since(started) >= maxRestartDelay {
+			delay = minRestartDelay
+		}
+		c.log.Warn("plugin process exited, restarting it", zap.String("command", c.cfg.Command), zap.Duration("delay", delay))
+		select {
+		case <-c.done:
+			return
+		case <-time.After(delay):
+		}
+		started = time.Now()
+		if err := c.restart(); err != nil {
+			if errors.Is(err, errClosed) {
+				return
+			}
+			c.log.Error("failed to restart plugin", zap.String("command", c.cfg.Command), zap.Error(err))
+		} else {
+			c.log.Info("plugin process restarted", zap.String("command", c.cfg.Command))
+		}
+		delay = min(delay*2, maxRestartDelay)
+	}
+}
+
+// restart starts a new process and sends it the config of the exited one,
+// the new process is stopped when it cannot be configured so it is started
+// again by watch
+func (c *client) restart() error {
+	process, raw, err := launch(c.cfg, c.log)
+	if err != nil {
+		return err
+	}
+	c.configMu.Lock()
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		c.configMu.Unlock()
+		process.Kill()
+		return errClosed
+	}
+	c.process, c.conn, c.broker = process, raw.conn, raw.broker
+	c.mu.Unlock()
+	c.host = 0
+	configured, homePath, debug := c.configured, c.homePath, c.debug
+	c.configured = ""
+	c.configMu.Unlock()
+	if configured == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.startTimeout())
+	defer cancel()
+	if err := c.configure(ctx, c.cfg, homePath, debug); err != nil {
+		process.Kill()
+		return err
+	}
+	return nil
+}
+
+// configure sends the config to the plugin and initializes its provider, the
+// config already sent is not sent again
+func (c *client) configure(ctx context.Context, cfg *Config, homePath string, debug bool) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	if c.configured == string(data) {
+		return nil
+	}
+	c.mu.Lock()
+	conn, broker := c.conn, c.broker
+	c.mu.Unlock()
+	if c.host == 0 {
+		c.host = broker.NextId()
+		go broker.AcceptAndServe(c.host, func(opts []grpc.ServerOption) *grpc.Server {
+			s := grpc.NewServer(opts...)
+			s.RegisterService(c.hostServiceDesc(), nil)
+			return s
+		})
+	}
+	req := configureRequest{
+		Config:    data,
+		ChainName: cfg.ChainName,
+		HomeDir:   homePath,
+		Debug:     debug,
+		Host:      c.host,
+	}
+	if _, err := invoke[empty](ctx, conn, providerService, methodConfigure, req); err != nil {
+		return fmt.Errorf("failed to configure plugin: %w", err)
+	}
+	if _, err := invoke[empty](ctx, conn, providerService, methodInit, homePath); err != nil {
+		return fmt.Errorf("failed to initialize plugin: %w", err)
+	}
+	c.configured, c.homePath, c.debug = string(data), homePath, debug
+	return nil
+}
+
+func (c *client) setKMS(k kms.KMS) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.kms = k
+}
+
+func (c *client) setLastSavedHeightFunc(f func() uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastSavedHeight = f
+}
+
+// addCallback registers the callback of a routed message and returns its id
+func (c *client) addCallback(key *types.MessageKey, callback types.TxResponseFunc) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextCallback++
+	c.callbacks[c.nextCallback] = pendingCallback{key: key, callback: callback}
+	return c.nextCallback
+}
+
+// takeCallback removes the callback, a message is only called back once
+func (c *client) takeCallback(id uint64) types.TxResponseFunc {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pending := c.callbacks[id]
+	delete(c.callbacks, id)
+	return pending.callback
+}
+
+// failCallbacks calls back the routed messages with the error, their results
+// are lost with the process
+func (c *client) failCallbacks(err error) {
+	c.mu.Lock()
+	pending := c.callbacks
+	c.callbacks = make(map[uint64]pendingCallback)
+	c.mu.Unlock()
+	for _, p := range pending {
+		p.callback(p.key, &types.TxResponse{Code: types.Failed}, err)
+	}
+}
+
+// hostServiceDesc is the service of the relayer called by the plugin
+func (c *client) hostServiceDesc() *grpc.ServiceDesc {
+	return serviceDesc(hostService, map[string]handler{
+		methodCallback: handle(func(_ context.Context, req callbackRequest) (empty, error) {
+			callback := c.takeCallback(req.Callback)
+			if callback == nil {
+				return empty{}, fmt.Errorf("unknown callback %d", req.Callback)
+			}
+			callback(req.Key, req.Response, stringError(req.Error))
+			return empty{}, nil
+		}),
+		methodLastSavedHeight: handle(func(context.Context, empty) (uint64, error) {
+			c.mu.Lock()
+			lastSavedHeight := c.lastSavedHeight
+			c.mu.Unlock()
+			if lastSavedHeight == nil {
+				return 0, nil
+			}
+			return lastSavedHeight(), nil
+		}),
+		methodEncrypt: handle(func(ctx context.Context, data []byte) ([]byte, error) {
+			k, err := c.kmsProvider()
+			if err != nil {
+				return nil, err
+			}
+			return k.Encrypt(ctx, data)
+		}),
+		methodDecrypt: handle(func(ctx context.Context, data []byte) ([]byte, error) {
+			k, err := c.kmsProvider()
+			if err != nil {
+				return nil, err
+			}
+			return k.Decrypt(ctx, data)
+		}),
+	})
+}
+
+func (c *client) kmsProvider() (kms.KMS, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.kms == nil {
+		return nil, fmt.Errorf("kms is not initialized")
+	}
+	return c.kms, nil
+}
@@ -0,0 +1,65 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+// defaultStartTimeout bounds the start of the plugin process and its handshake
+const defaultStartTimeout = time.Minute
+
+// Config is the config of a chain served by an external plugin binary, the
+// common fields and the options are sent to the plugin as they are
+type Config struct {
+	provider.CommonConfig `json:",inline" yaml:",inline"`
+	// Command is the path of the plugin binary
+	Command string   `json:"command" yaml:"command" jsonschema:"required"`
+	Args    []string `json:"args,omitempty" yaml:"args,omitempty"`
+	// Env is added to the environment of the plugin process
+	Env          map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	StartTimeout time.Duration     `json:"start-timeout" yaml:"start-timeout"`
+	// Options are the settings of the chain read by the plugin
+	Options map[string]any `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+func (pc *Config) NewProvider(ctx context.Context, logger *zap.Logger, homePath string, debug bool, chainName string) (provider.ChainProvider, error) {
+	pc.HomeDir = homePath
+	pc.ChainName = chainName
+
+	if err := pc.Validate(); err != nil {
+		return nil, err
+	}
+	// the plugin process is started by Init, the commands which do not
+	// initialize the chains do not start it
+	return &Provider{
+		log:   logger.With(zap.String("nid", pc.NID), zap.String("name", chainName)),
+		cfg:   pc,
+		debug: debug,
+	}, nil
+}
+
+func (pc *Config) Validate() error {
+	if err := pc.CommonConfig.Validate(); err != nil {
+		return err
+	}
+	if pc.Command == "" {
+		return fmt.Errorf("command cannot be empty")
+	}
+	return nil
+}
+
+func (pc *Config) GetConnContract() string {
+	return pc.Contracts[types.ConnectionContract]
+}
+
+func (pc *Config) startTimeout() time.Duration {
+	if pc.StartTimeout > 0 {
+		return pc.StartTimeout
+	}
+	return defaultStartTimeout
+}
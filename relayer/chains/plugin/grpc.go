@@ -0,0 +1,256 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Handshake is shared by the relayer and the plugins, a plugin binary started
+// without it exits with a message instead of serving
+var Handshake = goplugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "CENTRALIZED_RELAY_PLUGIN",
+	MagicCookieValue: "chain-provider",
+}
+
+const (
+	// pluginName is the name the chain provider is dispensed under
+	pluginName = "chain"
+	// providerService is served by the plugin, hostService is served by the
+	// relayer to the plugin through the go-plugin broker
+	providerService = "centralizedrelay.plugin.v1.Provider"
+	hostService     = "centralizedrelay.plugin.v1.Host"
+)
+
+// The services are described without generated code: every method takes and
+// returns a BytesValue holding the json of its request and response.
+const (
+	methodConfigure               = "Configure"
+	methodInit                    = "Init"
+	methodQueryLatestHeight       = "QueryLatestHeight"
+	methodQueryTransactionReceipt = "QueryTransactionReceipt"
+	methodFinalityBlock           = "FinalityBlock"
+	methodRoute                   = "Route"
+	methodShouldReceiveMessage    = "ShouldReceiveMessage"
+	methodShouldSendMessage       = "ShouldSendMessage"
+	methodMessageReceived         = "MessageReceived"
+	methodSetAdmin                = "SetAdmin"
+	methodGenerateMessages        = "GenerateMessages"
+	methodFetchTxMessages         = "FetchTxMessages"
+	methodQueryBalance            = "QueryBalance"
+	methodNewKeystore             = "NewKeystore"
+	methodRestoreKeystore         = "RestoreKeystore"
+	methodImportKeystore          = "ImportKeystore"
+	methodRevertMessage           = "RevertMessage"
+	methodGetFee                  = "GetFee"
+	methodSetFee                  = "SetFee"
+	methodClaimFee                = "ClaimFee"
+	streamListener                = "Listener"
+
+	methodCallback        = "Callback"
+	methodLastSavedHeight = "LastSavedHeight"
+	methodEncrypt         = "Encrypt"
+	methodDecrypt         = "Decrypt"
+)
+
+// message carries the tx info left out of the json of the messages
+type message struct {
+	Message *types.Message `json:"message"`
+	TxInfo  []byte         `json:"tx-info,omitempty"`
+}
+
+func toMessage(m *types.Message) *message {
+	if m == nil {
+		return nil
+	}
+	return &message{Message: m, TxInfo: m.TxInfo}
+}
+
+func (m *message) value() *types.Message {
+	if m == nil || m.Message == nil {
+		return nil
+	}
+	m.Message.TxInfo = m.TxInfo
+	return m.Message
+}
+
+func toMessages(msgs []*types.Message) []*message {
+	out := make([]*message, 0, len(msgs))
+	for _, m := range msgs {
+		out = append(out, toMessage(m))
+	}
+	return out
+}
+
+func messageValues(msgs []*message) []*types.Message {
+	out := make([]*types.Message, 0, len(msgs))
+	for _, m := range msgs {
+		out = append(out, m.value())
+	}
+	return out
+}
+
+type configureRequest struct {
+	// Config is the json of the chain config, the options included
+	Config    json.RawMessage `json:"config"`
+	ChainName string          `json:"chain-name"`
+	HomeDir   string          `json:"home-dir"`
+	Debug     bool            `json:"debug"`
+	// Host is the broker id of the host service
+	Host uint32 `json:"host"`
+}
+
+type block struct {
+	Height   uint64     `json:"height"`
+	Messages []*message `json:"messages"`
+}
+
+type routeRequest struct {
+	// Callback is the id of the callback of the message on the host
+	Callback uint64   `json:"callback"`
+	Message  *message `json:"message"`
+}
+
+type callbackRequest struct {
+	Callback uint64            `json:"callback"`
+	Key      *types.MessageKey `json:"key"`
+	Response *types.TxResponse `json:"response"`
+	Error    string            `json:"error,omitempty"`
+}
+
+type heightRange struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+type importKeystoreRequest struct {
+	Path       string `json:"path"`
+	Passphrase string `json:"passphrase"`
+}
+
+type getFeeRequest struct {
+	Network     string `json:"network"`
+	ResponseFee bool   `json:"response-fee"`
+}
+
+type setFeeRequest struct {
+	Network string   `json:"network"`
+	MsgFee  *big.Int `json:"msg-fee"`
+	ResFee  *big.Int `json:"res-fee"`
+}
+
+type empty struct{}
+
+// handler serves a method of a service from the json of its request
+type handler func(ctx context.Context, in []byte) (any, error)
+
+// handle decodes the request of the method and returns its response
+func handle[Req, Resp any](fn func(context.Context, Req) (Resp, error)) handler {
+	return func(ctx context.Context, in []byte) (any, error) {
+		var req Req
+		if len(in) > 0 {
+			if err := json.Unmarshal(in, &req); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+			}
+		}
+		return fn(ctx, req)
+	}
+}
+
+// serviceDesc describes the service with the handlers of its methods
+func serviceDesc(name string, methods map[string]handler, streams ...grpc.StreamDesc) *grpc.ServiceDesc {
+	desc := &grpc.ServiceDesc{
+		ServiceName: name,
+		// the handlers are bound to the methods, no server type is required
+		HandlerType: (*any)(nil),
+		Streams:     streams,
+	}
+	for method, h := range methods {
+		h := h
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: method,
+			Handler: func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				in := new(wrapperspb.BytesValue)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				out, err := h(ctx, in.Value)
+				if err != nil {
+					return nil, err
+				}
+				return encode(out)
+			},
+		})
+	}
+	return desc
+}
+
+// invoke calls the method of the service with the json of the request
+func invoke[Resp any](ctx context.Context, conn grpc.ClientConnInterface, service, method string, req any) (Resp, error) {
+	var resp Resp
+	in, err := encode(req)
+	if err != nil {
+		return resp, err
+	}
+	out := new(wrapperspb.BytesValue)
+	if err := conn.Invoke(ctx, "/"+service+"/"+method, in, out); err != nil {
+		return resp, remoteError(err)
+	}
+	if len(out.Value) == 0 {
+		return resp, nil
+	}
+	if err := json.Unmarshal(out.Value, &resp); err != nil {
+		return resp, fmt.Errorf("invalid %s response: %w", method, err)
+	}
+	return resp, nil
+}
+
+func encode(v any) (*wrapperspb.BytesValue, error) {
+	if v == nil {
+		return wrapperspb.Bytes(nil), nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return wrapperspb.Bytes(b), nil
+}
+
+func decode(in *wrapperspb.BytesValue, v any) error {
+	return json.Unmarshal(in.Value, v)
+}
+
+// remoteError returns the errors of the other side with their message, the
+// errors of the transport are kept
+func remoteError(err error) error {
+	if s, ok := status.FromError(err); ok && s.Code() == codes.Unknown {
+		return errors.New(s.Message())
+	}
+	return err
+}
+
+// errorString returns the message of the error, empty for nil
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// stringError returns the error of the message, nil for an empty message
+func stringError(msg string) error {
+	if msg == "" {
+		return nil
+	}
+	return errors.New(msg)
+}
@@ -0,0 +1,97 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// hcLogger writes the logs of go-plugin and the stderr of the plugin process
+// to the zap logger of the relayer
+type hcLogger struct {
+	hclog.Logger
+	log  *zap.Logger
+	name string
+}
+
+func newHCLogger(log *zap.Logger) hclog.Logger {
+	return &hcLogger{Logger: hclog.NewNullLogger(), log: log}
+}
+
+func (l *hcLogger) Log(level hclog.Level, msg string, args ...any) {
+	switch level {
+	case hclog.Trace, hclog.Debug:
+		l.Debug(msg, args...)
+	case hclog.Warn:
+		l.Warn(msg, args...)
+	case hclog.Error:
+		l.Error(msg, args...)
+	default:
+		l.Info(msg, args...)
+	}
+}
+
+func (l *hcLogger) Trace(msg string, args ...any) { l.log.Debug(msg, fields(args)...) }
+func (l *hcLogger) Debug(msg string, args ...any) { l.log.Debug(msg, fields(args)...) }
+func (l *hcLogger) Info(msg string, args ...any)  { l.log.Info(msg, fields(args)...) }
+func (l *hcLogger) Warn(msg string, args ...any)  { l.log.Warn(msg, fields(args)...) }
+func (l *hcLogger) Error(msg string, args ...any) { l.log.Error(msg, fields(args)...) }
+
+func (l *hcLogger) IsTrace() bool { return l.log.Core().Enabled(zapcore.DebugLevel) }
+func (l *hcLogger) IsDebug() bool { return l.log.Core().Enabled(zapcore.DebugLevel) }
+func (l *hcLogger) IsInfo() bool  { return l.log.Core().Enabled(zapcore.InfoLevel) }
+func (l *hcLogger) IsWarn() bool  { return l.log.Core().Enabled(zapcore.WarnLevel) }
+func (l *hcLogger) IsError() bool { return l.log.Core().Enabled(zapcore.ErrorLevel) }
+
+func (l *hcLogger) With(args ...any) hclog.Logger {
+	return &hcLogger{Logger: l.Logger, log: l.log.With(fields(args)...), name: l.name}
+}
+
+func (l *hcLogger) Name() string { return l.name }
+
+func (l *hcLogger) Named(name string) hclog.Logger {
+	if l.name != "" {
+		name = l.name + "." + name
+	}
+	return l.ResetNamed(name)
+}
+
+func (l *hcLogger) ResetNamed(name string) hclog.Logger {
+	return &hcLogger{Logger: l.Logger, log: l.log.Named(name), name: name}
+}
+
+// fields converts the key value pairs of hclog, the timestamps of the plugin
+// logs are dropped for the time of the relayer
+func fields(args []any) []zap.Field {
+	out := make([]zap.Field, 0, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		key := fmt.Sprint(args[i])
+		if i+1 == len(args) {
+			out = append(out, zap.Any("extra", args[i]))
+			break
+		}
+		if key == "timestamp" {
+			continue
+		}
+		out = append(out, zap.Any(key, args[i+1]))
+	}
+	return out
+}
+
+// newPluginLogger returns the logger of the plugin process, go-plugin reads
+// the json lines written to stderr and logs them on the relayer
+func newPluginLogger() *zap.Logger {
+	encoder := zap.NewProductionEncoderConfig()
+	encoder.MessageKey = "@message"
+	encoder.LevelKey = "@level"
+	encoder.EncodeLevel = zapcore.LowercaseLevelEncoder
+	encoder.TimeKey = zapcore.OmitKey
+	encoder.NameKey = "@module"
+	encoder.CallerKey = zapcore.OmitKey
+	encoder.StacktraceKey = zapcore.OmitKey
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoder), zapcore.Lock(os.Stderr), zapcore.DebugLevel)
+	return zap.New(core)
+}
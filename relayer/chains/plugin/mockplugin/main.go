@@ -0,0 +1,62 @@
+// Command mockplugin serves the mock chain as a plugin chain, it is the
+// reference of the plugin binaries and is run by the tests of the plugin chains.
+//
+// The messages of the chain are read from the options of the chain config:
+//
+//	options:
+//	  block-duration: 1s
+//	  messages:
+//	    - {sn: 1, src: mock-1, dst: mock-2, eventType: emitMessage, messageHeight: 10}
+//	  received:
+//	    - {sn: 2, src: mock-2, dst: mock-1, eventType: emitMessage}
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/chains/mockchain"
+	"github.com/icon-project/centralized-relay/relayer/chains/plugin"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+type options struct {
+	BlockDuration time.Duration `json:"block-duration"`
+	// Messages are emitted by the chain at their height
+	Messages []*types.Message `json:"messages"`
+	// Received are the messages the chain expects to receive
+	Received []*types.Message `json:"received"`
+}
+
+type config struct {
+	NID         string  `json:"nid"`
+	StartHeight uint64  `json:"start-height"`
+	Options     options `json:"options"`
+}
+
+func main() {
+	plugin.Serve(newConfig)
+}
+
+func newConfig(data []byte) (provider.Config, error) {
+	var cfg config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &mockchain.MockProviderConfig{
+		NId:             cfg.NID,
+		BlockDuration:   cfg.Options.BlockDuration,
+		StartHeight:     cfg.StartHeight,
+		SendMessages:    messageMap(cfg.Options.Messages),
+		ReceiveMessages: messageMap(cfg.Options.Received),
+	}, nil
+}
+
+func messageMap(msgs []*types.Message) map[types.MessageKey]*types.Message {
+	m := make(map[types.MessageKey]*types.Message, len(msgs))
+	for _, msg := range msgs {
+		m[*msg.MessageKey()] = msg
+	}
+	return m
+}
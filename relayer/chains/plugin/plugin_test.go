@@ -0,0 +1,365 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/icon-project/centralized-relay/relayer/chains/mockchain"
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/kms"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testConfig serves the mock chain with the kms, the last saved height and the
+// asynchronous callbacks of the relayer
+type testConfig struct {
+	mockchain.MockProviderConfig
+}
+
+func (c *testConfig) NewProvider(ctx context.Context, log *zap.Logger, homePath string, debug bool, chainName string) (provider.ChainProvider, error) {
+	p, err := c.MockProviderConfig.NewProvider(ctx, log, homePath, debug, chainName)
+	if err != nil {
+		return nil, err
+	}
+	return &testProvider{MockProvider: p.(*mockchain.MockProvider)}, nil
+}
+
+type testProvider struct {
+	*mockchain.MockProvider
+	kms             kms.KMS
+	lastSavedHeight func() uint64
+}
+
+func (p *testProvider) Init(_ context.Context, _ string, k kms.KMS) error {
+	p.kms = k
+	return nil
+}
+
+func (p *testProvider) SetLastSavedHeightFunc(f func() uint64) {
+	p.lastSavedHeight = f
+}
+
+func (p *testProvider) FinalityBlock(context.Context) uint64 {
+	return p.lastSavedHeight()
+}
+
+func (p *testProvider) QueryTransactionReceipt(context.Context, string) (*types.Receipt, error) {
+	return nil, errors.New("tx not found")
+}
+
+// NewKeystore returns the passphrase encrypted and decrypted by the relayer
+func (p *testProvider) NewKeystore(passphrase string) (string, error) {
+	ctx := context.Background()
+	encrypted, err := p.kms.Encrypt(ctx, []byte(passphrase))
+	if err != nil {
+		return "", err
+	}
+	if string(encrypted) == passphrase {
+		return "", errors.New("not encrypted")
+	}
+	decrypted, err := p.kms.Decrypt(ctx, encrypted)
+	return string(decrypted), err
+}
+
+func (p *testProvider) Route(ctx context.Context, message *types.Message, callback types.TxResponseFunc) error {
+	if message.Sn.Sign() == 0 {
+		return errors.New("invalid sn")
+	}
+	// the result of sn 3 is never sent, as if the process exited
+	if message.Sn.Cmp(big.NewInt(3)) == 0 {
+		return nil
+	}
+	go callback(message.MessageKey(), &types.TxResponse{TxHash: "0x01", Code: types.Success}, nil)
+	return nil
+}
+
+func testFactory(data []byte) (provider.Config, error) {
+	var cfg struct {
+		NID         string `json:"nid"`
+		StartHeight uint64 `json:"start-height"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	msg := &types.Message{
+		Src:           cfg.NID,
+		Dst:           "mock-2",
+		Sn:            big.NewInt(1),
+		EventType:     events.EmitMessage,
+		MessageHeight: cfg.StartHeight,
+		TxInfo:        []byte("tx-info"),
+	}
+	return &testConfig{mockchain.MockProviderConfig{
+		NId:          cfg.NID,
+		StartHeight:  cfg.StartHeight,
+		SendMessages: map[types.MessageKey]*types.Message{*msg.MessageKey(): msg},
+	}}, nil
+}
+
+// xorKMS is a reversible kms of the relayer
+type xorKMS struct{}
+
+func (xorKMS) Init(context.Context) (*string, error) { return nil, nil }
+
+func (xorKMS) Encrypt(_ context.Context, data []byte) ([]byte, error) { return xor(data), nil }
+
+func (xorKMS) Decrypt(_ context.Context, data []byte) ([]byte, error) { return xor(data), nil }
+
+func xor(data []byte) []byte {
+	out := make([]byte, len(data))
+	for i, b := range data {
+		out[i] = b ^ 0x5a
+	}
+	return out
+}
+
+func testPluginConfig(command string) *Config {
+	return &Config{
+		CommonConfig: provider.CommonConfig{NID: "mock-1", StartHeight: 10},
+		Command:      command,
+	}
+}
+
+// newTestProvider returns a provider connected to a plugin served in the
+// process of the test
+func newTestProvider(t *testing.T) *Provider {
+	rpcClient, _ := goplugin.TestPluginGRPCConn(t, map[string]goplugin.Plugin{
+		pluginName: &chainPlugin{factory: testFactory},
+	})
+	t.Cleanup(func() { rpcClient.Close() })
+	raw, err := rpcClient.Dispense(pluginName)
+	require.NoError(t, err)
+
+	home := t.TempDir()
+	prov, err := testPluginConfig("mockplugin").NewProvider(context.Background(), zap.NewNop(), home, false, "mock")
+	require.NoError(t, err)
+	p := prov.(*Provider)
+	p.client = raw.(*client)
+	p.SetLastSavedHeightFunc(func() uint64 { return 7 })
+	require.NoError(t, p.Init(context.Background(), home, xorKMS{}))
+	return p
+}
+
+func TestProviderQueries(t *testing.T) {
+	p := newTestProvider(t)
+	ctx := context.Background()
+
+	assert.Equal(t, "mock-1", p.NID())
+	assert.Equal(t, "plugin", p.Type())
+
+	height, err := p.QueryLatestHeight(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), height)
+
+	// the last saved height is read from the relayer
+	assert.Equal(t, uint64(7), p.FinalityBlock(ctx))
+
+	_, err = p.QueryTransactionReceipt(ctx, "0x01")
+	assert.EqualError(t, err, "tx not found")
+
+	// the keystores are encrypted with the kms of the relayer
+	passphrase, err := p.NewKeystore("secret")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", passphrase)
+
+	ok, err := p.ShouldSendMessage(ctx, &types.Message{Sn: big.NewInt(1)})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestProviderListener(t *testing.T) {
+	p := newTestProvider(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blocks := make(chan *types.BlockInfo)
+	done := make(chan error, 1)
+	go func() {
+		done <- p.Listener(ctx, types.LastProcessedTx{}, blocks)
+	}()
+
+	select {
+	case b := <-blocks:
+		assert.Equal(t, uint64(10), b.Height)
+		require.Len(t, b.Messages, 1)
+		assert.Equal(t, big.NewInt(1), b.Messages[0].Sn)
+		assert.Equal(t, "mock-2", b.Messages[0].Dst)
+		assert.Equal(t, []byte("tx-info"), b.Messages[0].TxInfo)
+	case err := <-done:
+		t.Fatalf("listener returned: %v", err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("no block received")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("listener did not return")
+	}
+}
+
+func TestProviderRoute(t *testing.T) {
+	p := newTestProvider(t)
+	ctx := context.Background()
+
+	type result struct {
+		key      *types.MessageKey
+		response *types.TxResponse
+		err      error
+	}
+	results := make(chan result, 1)
+	msg := &types.Message{Src: "mock-2", Dst: "mock-1", Sn: big.NewInt(2), EventType: events.EmitMessage}
+	err := p.Route(ctx, msg, func(key *types.MessageKey, response *types.TxResponse, err error) {
+		results <- result{key, response, err}
+	})
+	require.NoError(t, err)
+
+	select {
+	case r := <-results:
+		require.NoError(t, r.err)
+		assert.Equal(t, msg.MessageKey(), r.key)
+		assert.Equal(t, "0x01", r.response.TxHash)
+		assert.Equal(t, types.Success, r.response.Code)
+	case <-time.After(10 * time.Second):
+		t.Fatal("no callback")
+	}
+
+	// the callback of a message the plugin refused is dropped
+	err = p.Route(ctx, &types.Message{Sn: big.NewInt(0)}, func(*types.MessageKey, *types.TxResponse, error) {
+		t.Error("unexpected callback")
+	})
+	assert.EqualError(t, err, "invalid sn")
+	assert.Empty(t, p.client.callbacks)
+
+	// the pending callbacks fail when the process exits, so the messages are retried
+	pending := &types.Message{Src: "mock-2", Dst: "mock-1", Sn: big.NewInt(3), EventType: events.EmitMessage}
+	err = p.Route(ctx, pending, func(key *types.MessageKey, response *types.TxResponse, err error) {
+		results <- result{key, response, err}
+	})
+	require.NoError(t, err)
+	p.client.failCallbacks(errPluginExited)
+	select {
+	case r := <-results:
+		assert.ErrorIs(t, r.err, errPluginExited)
+		assert.Equal(t, pending.MessageKey(), r.key)
+		assert.Equal(t, types.Failed, r.response.Code)
+	case <-time.After(10 * time.Second):
+		t.Fatal("no callback")
+	}
+	assert.Empty(t, p.client.callbacks)
+}
+
+func TestProviderNotStarted(t *testing.T) {
+	prov, err := testPluginConfig("mockplugin").NewProvider(context.Background(), zap.NewNop(), t.TempDir(), false, "mock")
+	require.NoError(t, err)
+
+	_, err = prov.QueryLatestHeight(context.Background())
+	assert.ErrorIs(t, err, errNotStarted)
+
+	_, err = (&Config{CommonConfig: provider.CommonConfig{NID: "mock-1"}}).NewProvider(context.Background(), zap.NewNop(), t.TempDir(), false, "mock")
+	assert.EqualError(t, err, "command cannot be empty")
+}
+
+// TestPluginProcess runs the reference plugin binary
+func TestPluginProcess(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the mock plugin")
+	}
+	bin := filepath.Join(t.TempDir(), "mockplugin")
+	build := exec.Command("go", "build", "-o", bin, "./mockplugin")
+	out, err := build.CombinedOutput()
+	require.NoError(t, err, string(out))
+	t.Cleanup(Cleanup)
+
+	pollInterval, restartDelay := exitPollInterval, minRestartDelay
+	exitPollInterval, minRestartDelay = 10*time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() { exitPollInterval, minRestartDelay = pollInterval, restartDelay })
+
+	ctx := context.Background()
+	home := t.TempDir()
+	newProvider := func() *Provider {
+		cfg := testPluginConfig(bin)
+		cfg.Options = map[string]any{
+			"messages": []map[string]any{{"sn": 1, "src": "mock-1", "dst": "mock-2", "eventType": events.EmitMessage, "messageHeight": 11}},
+		}
+		prov, err := cfg.NewProvider(ctx, zap.NewNop(), home, false, "mock")
+		require.NoError(t, err)
+		require.NoError(t, prov.Init(ctx, home, xorKMS{}))
+		return prov.(*Provider)
+	}
+	p := newProvider()
+
+	height, err := p.QueryLatestHeight(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), height)
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	blocks := make(chan *types.BlockInfo)
+	go p.Listener(listenCtx, types.LastProcessedTx{}, blocks)
+	var msgs []*types.Message
+	timeout := time.After(10 * time.Second)
+	for len(msgs) == 0 {
+		select {
+		case b := <-blocks:
+			msgs = b.Messages
+		case <-timeout:
+			t.Fatal("no message received")
+		}
+	}
+	assert.Equal(t, big.NewInt(1), msgs[0].Sn)
+	cancel()
+
+	responses := make(chan *types.TxResponse, 1)
+	err = p.Route(ctx, msgs[0], func(_ *types.MessageKey, response *types.TxResponse, _ error) {
+		responses <- response
+	})
+	require.NoError(t, err)
+	select {
+	case r := <-responses:
+		assert.Equal(t, types.Success, r.Code)
+	case <-time.After(10 * time.Second):
+		t.Fatal("no callback")
+	}
+
+	// a crashed process is started again and configured with the chain
+	pid := func() int {
+		p.client.mu.Lock()
+		defer p.client.mu.Unlock()
+		return p.client.process.ReattachConfig().Pid
+	}
+	crashed := pid()
+	proc, err := os.FindProcess(crashed)
+	require.NoError(t, err)
+	require.NoError(t, proc.Kill())
+	assert.Eventually(t, func() bool {
+		if p.client.exited() || pid() == crashed {
+			return false
+		}
+		height, err := p.QueryLatestHeight(ctx)
+		return err == nil && height == 10
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// the provider of an unchanged config shares the process
+	shared := newProvider()
+	assert.Same(t, p.client, shared.client)
+	require.NoError(t, p.Close())
+	assert.False(t, shared.client.exited())
+	process := shared.client.process
+	require.NoError(t, shared.Close())
+	assert.True(t, process.Exited())
+}
@@ -0,0 +1,255 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/icon-project/centralized-relay/relayer/kms"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var errNotStarted = errors.New("plugin is not started")
+
+// Provider is the chain provider served by a plugin process
+type Provider struct {
+	log   *zap.Logger
+	cfg   *Config
+	debug bool
+
+	mu              sync.Mutex
+	client          *client
+	lastSavedHeight func() uint64
+}
+
+func (p *Provider) NID() string {
+	return p.cfg.NID
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.ChainName
+}
+
+func (p *Provider) Type() string {
+	return "plugin"
+}
+
+func (p *Provider) Config() provider.Config {
+	return p.cfg
+}
+
+// Init starts the plugin process and initializes the provider it serves
+func (p *Provider) Init(ctx context.Context, homePath string, kms kms.KMS) error {
+	p.mu.Lock()
+	c := p.client
+	if c == nil {
+		var err error
+		if c, err = startClient(p.cfg, p.log); err != nil {
+			p.mu.Unlock()
+			return err
+		}
+		p.client = c
+	}
+	lastSavedHeight := p.lastSavedHeight
+	p.mu.Unlock()
+
+	c.setKMS(kms)
+	if lastSavedHeight != nil {
+		c.setLastSavedHeightFunc(lastSavedHeight)
+	}
+	return c.configure(ctx, p.cfg, homePath, p.debug)
+}
+
+// Close releases the plugin process, the process shared with the provider
+// of a running chain is kept
+func (p *Provider) Close() error {
+	p.mu.Lock()
+	c := p.client
+	p.client = nil
+	p.mu.Unlock()
+	if c != nil {
+		c.close()
+	}
+	return nil
+}
+
+func (p *Provider) conn() (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client == nil {
+		return nil, errNotStarted
+	}
+	return p.client.connection(), nil
+}
+
+// invokeProvider calls the method of the provider served by the plugin
+func invokeProvider[Resp any](ctx context.Context, p *Provider, method string, req any) (Resp, error) {
+	conn, err := p.conn()
+	if err != nil {
+		var zero Resp
+		return zero, err
+	}
+	return invoke[Resp](ctx, conn, providerService, method, req)
+}
+
+func (p *Provider) QueryLatestHeight(ctx context.Context) (uint64, error) {
+	return invokeProvider[uint64](ctx, p, methodQueryLatestHeight, nil)
+}
+
+func (p *Provider) QueryTransactionReceipt(ctx context.Context, txHash string) (*types.Receipt, error) {
+	return invokeProvider[*types.Receipt](ctx, p, methodQueryTransactionReceipt, txHash)
+}
+
+func (p *Provider) FinalityBlock(ctx context.Context) uint64 {
+	height, err := invokeProvider[uint64](ctx, p, methodFinalityBlock, nil)
+	if err != nil {
+		p.log.Error("failed to get finality block", zap.Error(err))
+	}
+	return height
+}
+
+// Listener streams the blocks of the plugin until the context is done
+func (p *Provider) Listener(ctx context.Context, lastProcessedTx types.LastProcessedTx, blockInfo chan *types.BlockInfo) error {
+	conn, err := p.conn()
+	if err != nil {
+		return err
+	}
+	desc := &grpc.StreamDesc{StreamName: streamListener, ServerStreams: true}
+	stream, err := conn.NewStream(ctx, desc, "/"+providerService+"/"+streamListener)
+	if err != nil {
+		return remoteError(err)
+	}
+	in, err := encode(lastProcessedTx)
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return remoteError(err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		out := new(wrapperspb.BytesValue)
+		if err := stream.RecvMsg(out); err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return remoteError(err)
+		}
+		var b block
+		if err := decode(out, &b); err != nil {
+			return fmt.Errorf("invalid block: %w", err)
+		}
+		select {
+		case blockInfo <- &types.BlockInfo{Height: b.Height, Messages: messageValues(b.Messages)}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Route sends the message to the plugin, the callback is called when the
+// plugin sends the result of the message
+func (p *Provider) Route(ctx context.Context, message *types.Message, callback types.TxResponseFunc) error {
+	p.mu.Lock()
+	c := p.client
+	p.mu.Unlock()
+	if c == nil {
+		return errNotStarted
+	}
+	id := c.addCallback(message.MessageKey(), callback)
+	if _, err := invoke[empty](ctx, c.connection(), providerService, methodRoute, routeRequest{Callback: id, Message: toMessage(message)}); err != nil {
+		c.takeCallback(id)
+		return err
+	}
+	return nil
+}
+
+func (p *Provider) ShouldReceiveMessage(ctx context.Context, message *types.Message) (bool, error) {
+	return invokeProvider[bool](ctx, p, methodShouldReceiveMessage, toMessage(message))
+}
+
+func (p *Provider) ShouldSendMessage(ctx context.Context, message *types.Message) (bool, error) {
+	return invokeProvider[bool](ctx, p, methodShouldSendMessage, toMessage(message))
+}
+
+// SetLastSavedHeightFunc sets the function the plugin reads the last saved
+// height of the chain with
+func (p *Provider) SetLastSavedHeightFunc(f func() uint64) {
+	p.mu.Lock()
+	p.lastSavedHeight = f
+	c := p.client
+	p.mu.Unlock()
+	if c != nil {
+		c.setLastSavedHeightFunc(f)
+	}
+}
+
+func (p *Provider) MessageReceived(ctx context.Context, message *types.Message) (bool, error) {
+	return invokeProvider[bool](ctx, p, methodMessageReceived, toMessage(message))
+}
+
+func (p *Provider) SetAdmin(ctx context.Context, admin string) error {
+	_, err := invokeProvider[empty](ctx, p, methodSetAdmin, admin)
+	return err
+}
+
+func (p *Provider) GenerateMessages(ctx context.Context, fromHeight, toHeight uint64) ([]*types.Message, error) {
+	msgs, err := invokeProvider[[]*message](ctx, p, methodGenerateMessages, heightRange{From: fromHeight, To: toHeight})
+	if err != nil {
+		return nil, err
+	}
+	return messageValues(msgs), nil
+}
+
+func (p *Provider) FetchTxMessages(ctx context.Context, txHash string) ([]*types.Message, error) {
+	msgs, err := invokeProvider[[]*message](ctx, p, methodFetchTxMessages, txHash)
+	if err != nil {
+		return nil, err
+	}
+	return messageValues(msgs), nil
+}
+
+func (p *Provider) QueryBalance(ctx context.Context, addr string) (*types.Coin, error) {
+	return invokeProvider[*types.Coin](ctx, p, methodQueryBalance, addr)
+}
+
+func (p *Provider) NewKeystore(passphrase string) (string, error) {
+	return invokeProvider[string](context.Background(), p, methodNewKeystore, passphrase)
+}
+
+func (p *Provider) RestoreKeystore(ctx context.Context) error {
+	_, err := invokeProvider[empty](ctx, p, methodRestoreKeystore, nil)
+	return err
+}
+
+func (p *Provider) ImportKeystore(ctx context.Context, keyPath, passphrase string) (string, error) {
+	return invokeProvider[string](ctx, p, methodImportKeystore, importKeystoreRequest{Path: keyPath, Passphrase: passphrase})
+}
+
+func (p *Provider) RevertMessage(ctx context.Context, sn *big.Int) error {
+	_, err := invokeProvider[empty](ctx, p, methodRevertMessage, sn)
+	return err
+}
+
+func (p *Provider) GetFee(ctx context.Context, networkID string, responseFee bool) (uint64, error) {
+	return invokeProvider[uint64](ctx, p, methodGetFee, getFeeRequest{Network: networkID, ResponseFee: responseFee})
+}
+
+func (p *Provider) SetFee(ctx context.Context, networkID string, msgFee, resFee *big.Int) error {
+	_, err := invokeProvider[empty](ctx, p, methodSetFee, setFeeRequest{Network: networkID, MsgFee: msgFee, ResFee: resFee})
+	return err
+}
+
+func (p *Provider) ClaimFee(ctx context.Context) error {
+	_, err := invokeProvider[empty](ctx, p, methodClaimFee, nil)
+	return err
+}
@@ -0,0 +1,279 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// hostTimeout bounds the calls of the plugin to the relayer
+const hostTimeout = 30 * time.Second
+
+var errNotConfigured = errors.New("plugin is not configured")
+
+// Factory returns the config of the chain served by the plugin from the json
+// of the chain config of the relayer: the common fields, command and options
+type Factory func(config []byte) (provider.Config, error)
+
+// Serve serves the chain provider of the config returned by the factory, it is
+// called by the main function of the plugin binaries and returns when the
+// relayer stops the plugin
+func Serve(factory Factory) {
+	goplugin.Serve(&goplugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         goplugin.PluginSet{pluginName: &chainPlugin{factory: factory, log: newPluginLogger()}},
+		GRPCServer:      goplugin.DefaultGRPCServer,
+	})
+}
+
+// chainPlugin is the go-plugin plugin of the chain providers, the factory and
+// the logger are only set in the plugin process
+type chainPlugin struct {
+	goplugin.NetRPCUnsupportedPlugin
+	factory Factory
+	log     *zap.Logger
+}
+
+func (p *chainPlugin) GRPCServer(broker *goplugin.GRPCBroker, s *grpc.Server) error {
+	log := p.log
+	if log == nil {
+		log = zap.NewNop()
+	}
+	srv := &server{factory: p.factory, broker: broker, log: log}
+	s.RegisterService(srv.serviceDesc(), nil)
+	return nil
+}
+
+func (p *chainPlugin) GRPCClient(_ context.Context, broker *goplugin.GRPCBroker, conn *grpc.ClientConn) (any, error) {
+	return newClient(broker, conn), nil
+}
+
+// server serves the provider built by the factory in the plugin process
+type server struct {
+	factory Factory
+	broker  *goplugin.GRPCBroker
+	log     *zap.Logger
+
+	mu       sync.RWMutex
+	provider provider.ChainProvider
+	// host is the connection to the host service of the relayer
+	host *grpc.ClientConn
+}
+
+func (s *server) serviceDesc() *grpc.ServiceDesc {
+	return serviceDesc(providerService, map[string]handler{
+		methodConfigure: handle(s.configure),
+		methodInit:      handle(s.init),
+		methodQueryLatestHeight: handle(func(ctx context.Context, _ empty) (uint64, error) {
+			return call(s, func(p provider.ChainProvider) (uint64, error) { return p.QueryLatestHeight(ctx) })
+		}),
+		methodQueryTransactionReceipt: handle(func(ctx context.Context, txHash string) (*types.Receipt, error) {
+			return call(s, func(p provider.ChainProvider) (*types.Receipt, error) { return p.QueryTransactionReceipt(ctx, txHash) })
+		}),
+		methodFinalityBlock: handle(func(ctx context.Context, _ empty) (uint64, error) {
+			return call(s, func(p provider.ChainProvider) (uint64, error) { return p.FinalityBlock(ctx), nil })
+		}),
+		methodRoute: handle(s.route),
+		methodShouldReceiveMessage: handle(func(ctx context.Context, m *message) (bool, error) {
+			return call(s, func(p provider.ChainProvider) (bool, error) { return p.ShouldReceiveMessage(ctx, m.value()) })
+		}),
+		methodShouldSendMessage: handle(func(ctx context.Context, m *message) (bool, error) {
+			return call(s, func(p provider.ChainProvider) (bool, error) { return p.ShouldSendMessage(ctx, m.value()) })
+		}),
+		methodMessageReceived: handle(func(ctx context.Context, m *message) (bool, error) {
+			return call(s, func(p provider.ChainProvider) (bool, error) { return p.MessageReceived(ctx, m.value()) })
+		}),
+		methodSetAdmin: handle(func(ctx context.Context, admin string) (empty, error) {
+			return call(s, func(p provider.ChainProvider) (empty, error) { return empty{}, p.SetAdmin(ctx, admin) })
+		}),
+		methodGenerateMessages: handle(func(ctx context.Context, r heightRange) ([]*message, error) {
+			return call(s, func(p provider.ChainProvider) ([]*message, error) {
+				msgs, err := p.GenerateMessages(ctx, r.From, r.To)
+				return toMessages(msgs), err
+			})
+		}),
+		methodFetchTxMessages: handle(func(ctx context.Context, txHash string) ([]*message, error) {
+			return call(s, func(p provider.ChainProvider) ([]*message, error) {
+				msgs, err := p.FetchTxMessages(ctx, txHash)
+				return toMessages(msgs), err
+			})
+		}),
+		methodQueryBalance: handle(func(ctx context.Context, addr string) (*types.Coin, error) {
+			return call(s, func(p provider.ChainProvider) (*types.Coin, error) { return p.QueryBalance(ctx, addr) })
+		}),
+		methodNewKeystore: handle(func(_ context.Context, passphrase string) (string, error) {
+			return call(s, func(p provider.ChainProvider) (string, error) { return p.NewKeystore(passphrase) })
+		}),
+		methodRestoreKeystore: handle(func(ctx context.Context, _ empty) (empty, error) {
+			return call(s, func(p provider.ChainProvider) (empty, error) { return empty{}, p.RestoreKeystore(ctx) })
+		}),
+		methodImportKeystore: handle(func(ctx context.Context, req importKeystoreRequest) (string, error) {
+			return call(s, func(p provider.ChainProvider) (string, error) { return p.ImportKeystore(ctx, req.Path, req.Passphrase) })
+		}),
+		methodRevertMessage: handle(func(ctx context.Context, sn *big.Int) (empty, error) {
+			return call(s, func(p provider.ChainProvider) (empty, error) { return empty{}, p.RevertMessage(ctx, sn) })
+		}),
+		methodGetFee: handle(func(ctx context.Context, req getFeeRequest) (uint64, error) {
+			return call(s, func(p provider.ChainProvider) (uint64, error) { return p.GetFee(ctx, req.Network, req.ResponseFee) })
+		}),
+		methodSetFee: handle(func(ctx context.Context, req setFeeRequest) (empty, error) {
+			return call(s, func(p provider.ChainProvider) (empty, error) {
+				return empty{}, p.SetFee(ctx, req.Network, req.MsgFee, req.ResFee)
+			})
+		}),
+		methodClaimFee: handle(func(ctx context.Context, _ empty) (empty, error) {
+			return call(s, func(p provider.ChainProvider) (empty, error) { return empty{}, p.ClaimFee(ctx) })
+		}),
+	}, grpc.StreamDesc{
+		StreamName:    streamListener,
+		Handler:       s.listener,
+		ServerStreams: true,
+	})
+}
+
+// call calls the configured provider
+func call[T any](s *server, fn func(provider.ChainProvider) (T, error)) (T, error) {
+	s.mu.RLock()
+	p := s.provider
+	s.mu.RUnlock()
+	if p == nil {
+		var zero T
+		return zero, errNotConfigured
+	}
+	return fn(p)
+}
+
+// configure builds the provider of the chain config, the provider replaces
+// the previous one
+func (s *server) configure(ctx context.Context, req configureRequest) (empty, error) {
+	cfg, err := s.factory(req.Config)
+	if err != nil {
+		return empty{}, fmt.Errorf("invalid config: %w", err)
+	}
+	host, err := s.broker.Dial(req.Host)
+	if err != nil {
+		return empty{}, fmt.Errorf("failed to connect to the relayer: %w", err)
+	}
+	// the provider outlives the request
+	p, err := cfg.NewProvider(context.WithoutCancel(ctx), s.log, req.HomeDir, req.Debug, req.ChainName)
+	if err != nil {
+		host.Close()
+		return empty{}, err
+	}
+	p.SetLastSavedHeightFunc(func() uint64 {
+		ctx, cancel := context.WithTimeout(context.Background(), hostTimeout)
+		defer cancel()
+		height, err := invoke[uint64](ctx, host, hostService, methodLastSavedHeight, nil)
+		if err != nil {
+			s.log.Error("failed to get the last saved height", zap.Error(err))
+		}
+		return height
+	})
+
+	s.mu.Lock()
+	previous := s.host
+	s.provider, s.host = p, host
+	s.mu.Unlock()
+	if previous != nil {
+		previous.Close()
+	}
+	return empty{}, nil
+}
+
+func (s *server) init(ctx context.Context, homePath string) (empty, error) {
+	s.mu.RLock()
+	p, host := s.provider, s.host
+	s.mu.RUnlock()
+	if p == nil {
+		return empty{}, errNotConfigured
+	}
+	return empty{}, p.Init(context.WithoutCancel(ctx), homePath, &hostKMS{conn: host})
+}
+
+// route routes the message, the result is sent to the callback of the host
+// when the provider calls back
+func (s *server) route(ctx context.Context, req routeRequest) (empty, error) {
+	s.mu.RLock()
+	p, host := s.provider, s.host
+	s.mu.RUnlock()
+	if p == nil {
+		return empty{}, errNotConfigured
+	}
+	// the providers may call back after the request
+	err := p.Route(context.WithoutCancel(ctx), req.Message.value(), func(key *types.MessageKey, response *types.TxResponse, err error) {
+		ctx, cancel := context.WithTimeout(context.Background(), hostTimeout)
+		defer cancel()
+		callback := callbackRequest{Callback: req.Callback, Key: key, Response: response, Error: errorString(err)}
+		if _, err := invoke[empty](ctx, host, hostService, methodCallback, callback); err != nil {
+			s.log.Error("failed to send the result of the message", zap.Uint64("callback", req.Callback), zap.Error(err))
+		}
+	})
+	return empty{}, err
+}
+
+// listener streams the blocks of the provider until the relayer closes the stream
+func (s *server) listener(_ any, stream grpc.ServerStream) error {
+	s.mu.RLock()
+	p := s.provider
+	s.mu.RUnlock()
+	if p == nil {
+		return errNotConfigured
+	}
+	in := new(wrapperspb.BytesValue)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	var last types.LastProcessedTx
+	if err := decode(in, &last); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	blocks := make(chan *types.BlockInfo)
+	done := make(chan error, 1)
+	go func() {
+		done <- p.Listener(ctx, last, blocks)
+	}()
+	for {
+		select {
+		case err := <-done:
+			return err
+		case b := <-blocks:
+			out, err := encode(&block{Height: b.Height, Messages: toMessages(b.Messages)})
+			if err != nil {
+				return err
+			}
+			if err := stream.SendMsg(out); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// hostKMS decrypts the keystores of the plugin with the kms of the relayer
+type hostKMS struct {
+	conn grpc.ClientConnInterface
+}
+
+func (k *hostKMS) Init(context.Context) (*string, error) {
+	return nil, fmt.Errorf("the kms key is created by the relayer")
+}
+
+func (k *hostKMS) Encrypt(ctx context.Context, data []byte) ([]byte, error) {
+	return invoke[[]byte](ctx, k.conn, hostService, methodEncrypt, data)
+}
+
+func (k *hostKMS) Decrypt(ctx context.Context, data []byte) ([]byte, error) {
+	return invoke[[]byte](ctx, k.conn, hostService, methodDecrypt, data)
+}
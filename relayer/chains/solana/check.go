@@ -0,0 +1,67 @@
+package solana
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
+)
+
+// CheckConfig checks the required fields and the base58 addresses of the config
+func (pc *Config) CheckConfig() []error {
+	var errs []error
+	if len(rpcpool.Merge(pc.RPCUrl, pc.RPCUrls)) == 0 {
+		errs = append(errs, fmt.Errorf("rpc-url or rpc-urls is required"))
+	}
+	if pc.NID == "" {
+		errs = append(errs, fmt.Errorf("nid is required"))
+	}
+	checkAddress := func(field, addr string, required bool) {
+		if addr == "" {
+			if required {
+				errs = append(errs, fmt.Errorf("%s is required", field))
+			}
+			return
+		}
+		if _, err := solana.PublicKeyFromBase58(addr); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s is not a base58 address: %w", field, addr, err))
+		}
+	}
+	checkAddress("address", pc.Address, true)
+	checkAddress("xcall-program", pc.XcallProgram, true)
+	checkAddress("connection-program", pc.ConnectionProgram, true)
+	checkAddress("alt-address", pc.AltAddress, false)
+	for i, addr := range pc.OtherConnections {
+		checkAddress(fmt.Sprintf("other-connections[%d]", i), addr, true)
+	}
+	for i, dapp := range pc.Dapps {
+		checkAddress(fmt.Sprintf("dapps[%d].program-id", i), dapp.ProgramID, true)
+	}
+	return errs
+}
+
+// CheckContracts checks that the programs are deployed and executable
+func (p *Provider) CheckContracts(ctx context.Context) map[string]error {
+	errs := make(map[string]error)
+	for name, addr := range p.cfg.ContractsAddress() {
+		if addr == "" {
+			continue
+		}
+		program, err := solana.PublicKeyFromBase58(addr)
+		if err != nil {
+			errs[name] = err
+			continue
+		}
+		account, err := p.client.GetAccountInfoRaw(ctx, program)
+		switch {
+		case err != nil:
+			errs[name] = err
+		case account == nil || !account.Executable:
+			errs[name] = fmt.Errorf("no program deployed at %s", addr)
+		default:
+			errs[name] = nil
+		}
+	}
+	return errs
+}
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	solrpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
 	"github.com/icon-project/centralized-relay/relayer/chains/solana/types"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
 	"go.uber.org/zap"
 )
@@ -18,8 +20,12 @@ type Config struct {
 	Disabled  bool   `yaml:"disabled" json:"disabled"`
 	ChainName string `yaml:"-"`
 
-	RPCUrl  string `yaml:"rpc-url" json:"rpc-url"`
-	Address string `yaml:"address" json:"address"`
+	RPCUrl  string         `yaml:"rpc-url" json:"rpc-url"`
+	RPCUrls []string       `yaml:"rpc-urls,omitempty" json:"rpc-urls,omitempty"`
+	RPCPool rpcpool.Config `yaml:"rpc-pool,omitempty" json:"rpc-pool,omitempty"`
+	Address string         `yaml:"address" json:"address"`
+
+	provider.RPCAuthConfig `yaml:",inline" json:",inline"`
 
 	XcallProgram string `yaml:"xcall-program" json:"xcall-program"`
 
@@ -32,7 +38,7 @@ type Config struct {
 
 	AltAddress string `yaml:"alt-address" json:"alt-address"` // address lookup table address
 
-	NID         string `yaml:"nid" json:"nid"`
+	NID         string `yaml:"nid" json:"nid" jsonschema:"required"`
 	HomeDir     string `yaml:"home-dir" json:"home-dir"`
 	StartTxSign string `yaml:"start-tx-sign" json:"start-tx-sign"`
 
@@ -50,11 +56,29 @@ func (pc *Config) NewProvider(ctx context.Context, logger *zap.Logger, homePath
 		return nil, err
 	}
 
-	client := NewClient(solrpc.New(pc.RPCUrl))
+	poolConfig := pc.RPCPool
+	if poolConfig.RateLimit == 0 {
+		poolConfig.RateLimit = DefaultRateLimit
+	}
+	pool, err := rpcpool.New(chainName, "rpc", rpcpool.Merge(pc.RPCUrl, pc.RPCUrls), poolConfig, probeHeight, logger)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := pc.RPCAuthClient()
+	if err != nil {
+		return nil, err
+	}
+	pool.SetAuth(auth)
+	// the health checks run until the provider is closed
+	ctx, cancel := context.WithCancel(ctx)
+	go pool.Start(ctx)
+
+	client := newPoolClient(pool)
 
 	xcallIdl := IDL{}
 	if pc.XcallProgram != "" {
 		if err := client.FetchIDL(ctx, pc.XcallProgram, &xcallIdl); err != nil {
+			cancel()
 			return nil, fmt.Errorf("failed to fetch xcall idl: %w", err)
 		}
 	}
@@ -62,6 +86,7 @@ func (pc *Config) NewProvider(ctx context.Context, logger *zap.Logger, homePath
 	connIdl := IDL{}
 	if pc.ConnectionProgram != "" {
 		if err := client.FetchIDL(ctx, pc.ConnectionProgram, &connIdl); err != nil {
+			cancel()
 			return nil, fmt.Errorf("failed to fetch conn idl: %w", err)
 		}
 	}
@@ -77,6 +102,8 @@ func (pc *Config) NewProvider(ctx context.Context, logger *zap.Logger, homePath
 		connIdl:     &connIdl,
 		pdaRegistry: pdaRegistry,
 		staticAlts:  make(types.AddressTables),
+		pool:        pool,
+		cancel:      cancel,
 	}, nil
 }
 
@@ -111,3 +138,16 @@ func (pc *Config) ContractsAddress() relayertypes.ContractConfigMap {
 
 	return addresses
 }
+
+// newPoolClient returns the client of the endpoints of the pool
+func newPoolClient(pool *rpcpool.Pool) IClient {
+	return NewClient(solrpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(pool.URL(), &jsonrpc.RPCClientOpts{
+		HTTPClient: pool.HTTPClient(nil),
+	})))
+}
+
+// DefaultRateLimit keeps the requests under the limit of the public solana endpoints
+const DefaultRateLimit = 10
+
+// probeHeight returns the latest slot of the endpoint
+var probeHeight = rpcpool.JSONRPCProbe("getSlot", nil, rpcpool.ParseNumber)
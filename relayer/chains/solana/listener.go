@@ -103,7 +103,6 @@ func (p *Provider) listenByPolling(ctx context.Context, fromSignature string, bl
 					continue
 				}
 				sign := txSigns[i].Signature
-				time.Sleep(500 * time.Millisecond)
 				if _, err := p.processTxSignature(ctx, sign, blockInfo); err != nil {
 					p.log.Error("failed to process tx signature", zap.String("signature", sign.String()), zap.Error(err))
 				}
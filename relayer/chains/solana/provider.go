@@ -12,6 +12,7 @@ import (
 	"github.com/icon-project/centralized-relay/relayer/chains/solana/types"
 	"github.com/icon-project/centralized-relay/relayer/kms"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
 	"github.com/near/borsh-go"
 	"go.uber.org/zap"
@@ -29,6 +30,9 @@ type Provider struct {
 
 	pdaRegistry *types.PDARegistry
 	staticAlts  types.AddressTables
+	pool        *rpcpool.Pool
+	// cancel stops the health checks of the pool
+	cancel context.CancelFunc
 }
 
 func (p *Provider) QueryLatestHeight(ctx context.Context) (uint64, error) {
@@ -66,6 +70,31 @@ func (p *Provider) Init(ctx context.Context, homePath string, kms kms.KMS) error
 }
 
 // Type returns chain-type
+// Close stops the health checks of the pool and closes its connections
+func (p *Provider) Close() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.pool.Close()
+	return nil
+}
+
+// EndpointReaders returns a provider per rpc endpoint for the quorum reads
+func (p *Provider) EndpointReaders(ctx context.Context) ([]*provider.EndpointReader, error) {
+	var readers []*provider.EndpointReader
+	for _, url := range p.pool.Endpoints() {
+		pool, err := p.pool.Pin(url)
+		if err != nil {
+			return nil, err
+		}
+		reader := *p
+		// closing the reader only closes its own client and pinned pool
+		reader.client, reader.pool, reader.cancel = newPoolClient(pool), pool, nil
+		readers = append(readers, &provider.EndpointReader{Endpoint: pool.Label(), Provider: &reader})
+	}
+	return readers, nil
+}
+
 func (p *Provider) Type() string {
 	return types.ChainType
 }
@@ -374,21 +403,28 @@ func (p *Provider) SetFee(ctx context.Context, networkID string, msgFee, resFee
 }
 
 func (p *Provider) ClaimFee(ctx context.Context) error {
+	_, err := p.ClaimFeeAmount(ctx)
+	return err
+}
+
+// ClaimFeeAmount claims the fees and returns the lamports moved out of the
+// claim fees account by the claim transaction
+func (p *Provider) ClaimFeeAmount(ctx context.Context) (*big.Int, error) {
 	discriminator, err := p.connIdl.GetInstructionDiscriminator(types.MethodClaimFees)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	instructionData := discriminator
 
 	claimFeeAddr, err := p.pdaRegistry.ConnClaimFees.GetAddress()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	connConfigAddr, err := p.pdaRegistry.ConnConfig.GetAddress()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	instructions := []solana.Instruction{
@@ -417,21 +453,55 @@ func (p *Provider) ClaimFee(ctx context.Context) error {
 
 	tx, err := p.prepareTx(ctx, instructions, signers)
 	if err != nil {
-		return fmt.Errorf("failed to prepare and simulate tx: %w", err)
+		return nil, fmt.Errorf("failed to prepare and simulate tx: %w", err)
 	}
 
 	txSign, err := p.client.SendTx(ctx, tx)
 	if err != nil {
-		return fmt.Errorf("failed to send tx: %w", err)
+		return nil, fmt.Errorf("failed to send tx: %w", err)
 	}
 
 	if _, err := p.waitForTxConfirmation(defaultTxConfirmationTime, txSign); err != nil {
-		return fmt.Errorf("failed to confirm tx %s: %w", txSign.String(), err)
+		return nil, fmt.Errorf("failed to confirm tx %s: %w", txSign.String(), err)
 	}
 
 	p.log.Info("claim fees successful", zap.String("tx-sign", txSign.String()))
 
-	return nil
+	txVersion := uint64(0)
+	txn, err := p.client.GetTransaction(ctx, txSign, &solrpc.GetTransactionOpts{
+		MaxSupportedTransactionVersion: &txVersion,
+		Commitment:                     solrpc.CommitmentConfirmed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim fee tx %s: %w", txSign.String(), err)
+	}
+	return claimedAmount(txn, claimFeeAddr)
+}
+
+// claimedAmount returns the decrease of the balance of the account in the
+// transaction
+func claimedAmount(txn *solrpc.GetTransactionResult, account solana.PublicKey) (*big.Int, error) {
+	if txn == nil || txn.Transaction == nil || txn.Meta == nil {
+		return nil, fmt.Errorf("transaction meta not found")
+	}
+	tx, err := txn.Transaction.GetTransaction()
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction not found")
+	}
+	for i, key := range tx.Message.AccountKeys {
+		if !key.Equals(account) {
+			continue
+		}
+		if i >= len(txn.Meta.PreBalances) || i >= len(txn.Meta.PostBalances) {
+			return nil, fmt.Errorf("balances of account %s not found", account)
+		}
+		pre := new(big.Int).SetUint64(txn.Meta.PreBalances[i])
+		return pre.Sub(pre, new(big.Int).SetUint64(txn.Meta.PostBalances[i])), nil
+	}
+	return nil, fmt.Errorf("account %s not found in the transaction", account)
 }
 
 func (p *Provider) QueryBalance(ctx context.Context, addr string) (*relayertypes.Coin, error) {
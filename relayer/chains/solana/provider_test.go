@@ -5,11 +5,13 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/gagliardetto/solana-go"
+	solrpc "github.com/gagliardetto/solana-go/rpc"
 	"github.com/icon-project/centralized-relay/relayer/chains/solana/alt"
 	"github.com/icon-project/centralized-relay/relayer/chains/solana/types"
 	"github.com/near/borsh-go"
@@ -228,3 +230,21 @@ func reateLookupTableAccount(ctx context.Context) (*solana.PublicKey, error) {
 
 	return nil, lastErr
 }
+
+func TestClaimedAmount(t *testing.T) {
+	wallet, claimFees := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	data := fmt.Sprintf(`{
+		"slot": 10,
+		"transaction": {"signatures": [], "message": {"accountKeys": [%q, %q], "header": {}, "instructions": []}},
+		"meta": {"fee": 5000, "preBalances": [1000000, 2500000], "postBalances": [3495000, 890880]}
+	}`, wallet, claimFees)
+	txn := new(solrpc.GetTransactionResult)
+	assert.NoError(t, json.Unmarshal([]byte(data), txn))
+
+	amount, err := claimedAmount(txn, claimFees)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1609120), amount)
+
+	_, err = claimedAmount(txn, solana.NewWallet().PublicKey())
+	assert.ErrorContains(t, err, "not found in the transaction")
+}
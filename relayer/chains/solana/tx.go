@@ -85,7 +85,7 @@ func (p *Provider) Route(ctx context.Context, message *relayertypes.Message, cal
 		return fmt.Errorf("failed to send tx: %w", err)
 	}
 
-	p.executeRouteCallback(txSign, message, callback)
+	p.executeRouteCallback(ctx, txSign, message, callback)
 
 	return nil
 }
@@ -148,6 +148,7 @@ func (p *Provider) waitForTxConfirmation(timeout time.Duration, sign solana.Sign
 }
 
 func (p *Provider) executeRouteCallback(
+	ctx context.Context,
 	sign solana.Signature,
 	msg *relayertypes.Message,
 	callback relayertypes.TxResponseFunc,
@@ -162,6 +163,7 @@ func (p *Provider) executeRouteCallback(
 			msg.MessageKey(),
 			&relayertypes.TxResponse{
 				TxHash: sign.String(),
+				TxFee:  p.txFee(ctx, sign),
 			},
 			err,
 		)
@@ -172,12 +174,29 @@ func (p *Provider) executeRouteCallback(
 				Height: int64(txResult.Slot),
 				TxHash: sign.String(),
 				Code:   relayertypes.Success,
+				TxFee:  p.txFee(ctx, sign),
 			},
 			nil,
 		)
 	}
 }
 
+// txFee returns the fee paid for the transaction in lamports, nil if the transaction is not found
+func (p *Provider) txFee(ctx context.Context, sign solana.Signature) *big.Int {
+	txVersion := uint64(0)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	txn, err := p.client.GetTransaction(ctx, sign, &solrpc.GetTransactionOpts{
+		MaxSupportedTransactionVersion: &txVersion,
+		Commitment:                     solrpc.CommitmentConfirmed,
+	})
+	if err != nil || txn.Meta == nil {
+		p.log.Warn("failed to get transaction fee", zap.String("tx_hash", sign.String()), zap.Error(err))
+		return nil
+	}
+	return new(big.Int).SetUint64(txn.Meta.Fee)
+}
+
 func (p *Provider) createLookupTableAccount(ctx context.Context) (*solana.PublicKey, error) {
 	recentSlot, err := p.client.GetLatestSlot(ctx, solrpc.CommitmentFinalized)
 	if err != nil {
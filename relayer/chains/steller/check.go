@@ -0,0 +1,120 @@
+package steller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
+	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	"go.uber.org/zap"
+)
+
+// CheckConfig checks the required fields and the strkey addresses of the config
+func (pc *Config) CheckConfig() []error {
+	var errs []error
+	if len(rpcpool.Merge(pc.HorizonUrl, pc.HorizonUrls)) == 0 {
+		errs = append(errs, fmt.Errorf("horizon-url or horizon-urls is required"))
+	}
+	if len(rpcpool.Merge(pc.SorobanUrl, pc.SorobanUrls)) == 0 {
+		errs = append(errs, fmt.Errorf("soroban-url or soroban-urls is required"))
+	}
+	if pc.NID == "" {
+		errs = append(errs, fmt.Errorf("nid is required"))
+	}
+	if pc.NetworkPassphrase == "" {
+		errs = append(errs, fmt.Errorf("network-passphrase is required"))
+	}
+	if pc.Address == "" {
+		errs = append(errs, fmt.Errorf("address is required"))
+	} else if !strkey.IsValidEd25519PublicKey(pc.Address) {
+		errs = append(errs, fmt.Errorf("address %s is not a G... account strkey", pc.Address))
+	}
+	if pc.Contracts[relayertypes.ConnectionContract] == "" {
+		errs = append(errs, fmt.Errorf("contracts.%s is required", relayertypes.ConnectionContract))
+	}
+	for _, name := range pc.Contracts.Names() {
+		addr := pc.Contracts[name]
+		if addr == "" {
+			continue
+		}
+		if _, err := strkey.Decode(strkey.VersionByteContract, addr); err != nil {
+			errs = append(errs, fmt.Errorf("contracts.%s %s is not a C... contract strkey: %w", name, addr, err))
+		}
+	}
+	for _, name := range pc.Contracts.UnknownKeys() {
+		errs = append(errs, fmt.Errorf("contracts.%s is not a known contract", name))
+	}
+	return errs
+}
+
+// CheckNetwork compares the network passphrase of horizon with the configured one
+func (p *Provider) CheckNetwork(ctx context.Context) error {
+	passphrase, err := p.client.NetworkPassphrase()
+	if err != nil {
+		return err
+	}
+	if passphrase != p.cfg.NetworkPassphrase {
+		return fmt.Errorf("horizon network passphrase %q does not match %q", passphrase, p.cfg.NetworkPassphrase)
+	}
+	return nil
+}
+
+// DetectNetwork reads the network passphrase of the horizon endpoints
+func (pc *Config) DetectNetwork(ctx context.Context) (string, error) {
+	pool, err := rpcpool.New(pc.ChainName, "horizon", rpcpool.Merge(pc.HorizonUrl, pc.HorizonUrls), pc.RPCPool, probeHorizonHeight, zap.NewNop())
+	if err != nil {
+		return "", err
+	}
+	auth, err := pc.RPCAuthClient()
+	if err != nil {
+		return "", err
+	}
+	pool.SetAuth(auth)
+	client := &Client{horizon: &horizonclient.Client{
+		HorizonURL: pool.URL(),
+		HTTP:       pool.HTTPClient(nil),
+		AppName:    "centralized-relay",
+	}}
+	return client.NetworkPassphrase()
+}
+
+// CheckContracts checks that the instances of the contracts exist in the ledger
+func (p *Provider) CheckContracts(ctx context.Context) map[string]error {
+	errs := make(map[string]error)
+	for name, addr := range p.cfg.Contracts {
+		if addr == "" {
+			continue
+		}
+		key, err := p.contractInstanceKey(addr)
+		if err != nil {
+			errs[name] = err
+			continue
+		}
+		res, err := p.client.GetLedgerEntries(ctx, []string{key})
+		if err == nil && len(res.Entries) == 0 {
+			err = fmt.Errorf("no contract deployed at %s", addr)
+		}
+		errs[name] = err
+	}
+	return errs
+}
+
+// contractInstanceKey returns the base64 ledger key of the instance of the contract
+func (p *Provider) contractInstanceKey(addr string) (string, error) {
+	contract, err := p.scContractAddr(addr)
+	if err != nil {
+		return "", err
+	}
+	key := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   *contract,
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+			Durability: xdr.ContractDataDurabilityPersistent,
+		},
+	}
+	return xdr.MarshalBase64(key)
+}
@@ -24,6 +24,10 @@ type IClient interface {
 
 	GetLatestLedger(ctx context.Context) (*sorobanclient.LatestLedgerResponse, error)
 
+	GetLedgerEntries(ctx context.Context, keys []string) (*sorobanclient.LedgerEntriesResponse, error)
+
+	NetworkPassphrase() (string, error)
+
 	GetEvents(ctx context.Context, eventFilter types.GetEventFilter) (*sorobanclient.LedgerEventResponse, error)
 
 	ParseTxnEvents(txn *horizon.Transaction, fl types.EventFilter) ([]types.Event, error)
@@ -58,6 +62,19 @@ func (cl *Client) GetLatestLedger(ctx context.Context) (*sorobanclient.LatestLed
 	return cl.soroban.GetLatestLedger(ctx)
 }
 
+func (cl *Client) GetLedgerEntries(ctx context.Context, keys []string) (*sorobanclient.LedgerEntriesResponse, error) {
+	return cl.soroban.GetLedgerEntries(ctx, keys)
+}
+
+// NetworkPassphrase returns the passphrase of the network served by horizon
+func (cl *Client) NetworkPassphrase() (string, error) {
+	root, err := cl.horizon.Root()
+	if err != nil {
+		return "", err
+	}
+	return root.NetworkPassphrase, nil
+}
+
 func (cl *Client) GetEvents(ctx context.Context, eventFilter types.GetEventFilter) (*sorobanclient.LedgerEventResponse, error) {
 	return cl.soroban.GetEvents(ctx, eventFilter)
 }
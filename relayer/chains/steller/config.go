@@ -2,13 +2,14 @@ package steller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"net/http"
 	"sync"
 	"time"
 
 	"github.com/icon-project/centralized-relay/relayer/chains/steller/sorobanclient"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	"github.com/icon-project/centralized-relay/relayer/types"
 	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
 	"github.com/stellar/go/clients/horizonclient"
@@ -17,13 +18,18 @@ import (
 
 type Config struct {
 	provider.CommonConfig
+	provider.RPCAuthConfig `json:",inline" yaml:",inline"`
+
 	ChainID           string                         `json:"chain-id" yaml:"chain-id"`
 	ChainName         string                         `json:"-t" yaml:"-"`
 	HorizonUrl        string                         `json:"horizon-url" yaml:"horizon-url"`
 	SorobanUrl        string                         `json:"soroban-url" yaml:"soroban-url"`
+	HorizonUrls       []string                       `json:"horizon-urls,omitempty" yaml:"horizon-urls,omitempty"`
+	SorobanUrls       []string                       `json:"soroban-urls,omitempty" yaml:"soroban-urls,omitempty"`
+	RPCPool           rpcpool.Config                 `json:"rpc-pool,omitempty" yaml:"rpc-pool,omitempty"`
 	Address           string                         `json:"address" yaml:"address"`
 	Contracts         relayertypes.ContractConfigMap `json:"contracts" yaml:"contracts"`
-	NID               string                         `json:"nid" yaml:"nid"`
+	NID               string                         `json:"nid" yaml:"nid" jsonschema:"required"`
 	HomeDir           string                         `json:"home-dir" yaml:"home-dir"`
 	MaxInclusionFee   uint64                         `json:"max-inclusion-fee" yaml:"max-inclusion-fee"` // in stroop: the smallest unit of a lumen, one ten-millionth of a lumen (.0000001 XLM).
 	NetworkPassphrase string                         `json:"network-passphrase" yaml:"network-passphrase"`
@@ -40,26 +46,55 @@ func (pc *Config) NewProvider(ctx context.Context, logger *zap.Logger, homePath
 		return nil, err
 	}
 
-	httpClient := http.Client{}
+	horizonPool, err := rpcpool.New(chainName, "horizon", rpcpool.Merge(pc.HorizonUrl, pc.HorizonUrls), pc.RPCPool, probeHorizonHeight, logger)
+	if err != nil {
+		return nil, err
+	}
+	sorobanPool, err := rpcpool.New(chainName, "soroban", rpcpool.Merge(pc.SorobanUrl, pc.SorobanUrls), pc.RPCPool, probeSorobanHeight, logger)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := pc.RPCAuthClient()
+	if err != nil {
+		return nil, err
+	}
+	horizonPool.SetAuth(auth)
+	sorobanPool.SetAuth(auth)
+
+	client, err := newPoolClient(horizonPool, sorobanPool)
+	if err != nil {
+		return nil, err
+	}
+	// the health checks run until the provider is closed
+	ctx, cancel := context.WithCancel(ctx)
+	go horizonPool.Start(ctx)
+	go sorobanPool.Start(ctx)
+
+	return &Provider{
+		log:         logger.With(zap.String("nid ", pc.NID), zap.String("name", pc.ChainName)),
+		cfg:         pc,
+		client:      client,
+		txmut:       &sync.Mutex{},
+		horizonPool: horizonPool,
+		sorobanPool: sorobanPool,
+		cancel:      cancel,
+	}, nil
+}
+
+// newPoolClient returns the client of the endpoints of the horizon and soroban pools
+func newPoolClient(horizonPool, sorobanPool *rpcpool.Pool) (IClient, error) {
 	horizonClient := &horizonclient.Client{
-		HorizonURL: pc.HorizonUrl,
-		HTTP:       &httpClient,
+		HorizonURL: horizonPool.URL(),
+		HTTP:       horizonPool.HTTPClient(nil),
 		AppName:    "centralized-relay",
 	}
 
-	sorobanclient, err := sorobanclient.New(pc.SorobanUrl, &httpClient)
+	sorobanclient, err := sorobanclient.New(sorobanPool.URL(), sorobanPool.HTTPClient(nil))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create soroban client: %v", err)
 	}
 
-	client := NewClient(horizonClient, sorobanclient)
-
-	return &Provider{
-		log:    logger.With(zap.String("nid ", pc.NID), zap.String("name", pc.ChainName)),
-		cfg:    pc,
-		client: client,
-		txmut:  &sync.Mutex{},
-	}, nil
+	return NewClient(horizonClient, sorobanclient), nil
 }
 
 func (pc *Config) SetWallet(addr string) {
@@ -83,3 +118,25 @@ func (pc *Config) Enabled() bool {
 func (pc *Config) ContractsAddress() types.ContractConfigMap {
 	return pc.Contracts
 }
+
+// probeHorizonHeight returns the latest ledger ingested by the horizon endpoint
+var probeHorizonHeight = rpcpool.HTTPProbe("/", func(body []byte) (uint64, error) {
+	var root struct {
+		HistoryLatestLedger uint64 `json:"history_latest_ledger"`
+	}
+	if err := json.Unmarshal(body, &root); err != nil {
+		return 0, err
+	}
+	return root.HistoryLatestLedger, nil
+})
+
+// probeSorobanHeight returns the latest ledger of the soroban rpc endpoint
+var probeSorobanHeight = rpcpool.JSONRPCProbe("getLatestLedger", nil, func(result json.RawMessage) (uint64, error) {
+	var ledger struct {
+		Sequence uint64 `json:"sequence"`
+	}
+	if err := json.Unmarshal(result, &ledger); err != nil {
+		return 0, err
+	}
+	return ledger.Sequence, nil
+})
@@ -11,6 +11,7 @@ import (
 	evtypes "github.com/icon-project/centralized-relay/relayer/events"
 	"github.com/icon-project/centralized-relay/relayer/kms"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
 	"github.com/stellar/go/keypair"
 	"go.uber.org/zap"
@@ -24,6 +25,10 @@ type Provider struct {
 	wallet              *keypair.Full
 	txmut               *sync.Mutex
 	LastSavedHeightFunc func() uint64
+	horizonPool         *rpcpool.Pool
+	sorobanPool         *rpcpool.Pool
+	// cancel stops the health checks of the pools
+	cancel context.CancelFunc
 }
 
 func (p *Provider) QueryLatestHeight(ctx context.Context) (uint64, error) {
@@ -50,6 +55,45 @@ func (p *Provider) Init(ctx context.Context, homePath string, kms kms.KMS) error
 	return nil
 }
 
+// Close stops the health checks of the pools and closes their connections
+func (p *Provider) Close() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.horizonPool.Close()
+	p.sorobanPool.Close()
+	return nil
+}
+
+// EndpointReaders returns a provider per pair of horizon and soroban endpoints
+// for the quorum reads, the lists must have the same length
+func (p *Provider) EndpointReaders(ctx context.Context) ([]*provider.EndpointReader, error) {
+	horizonUrls, sorobanUrls := p.horizonPool.Endpoints(), p.sorobanPool.Endpoints()
+	if len(horizonUrls) != len(sorobanUrls) {
+		return nil, fmt.Errorf("quorum reads need as many horizon as soroban endpoints")
+	}
+	var readers []*provider.EndpointReader
+	for i := range horizonUrls {
+		horizonPool, err := p.horizonPool.Pin(horizonUrls[i])
+		if err != nil {
+			return nil, err
+		}
+		sorobanPool, err := p.sorobanPool.Pin(sorobanUrls[i])
+		if err != nil {
+			return nil, err
+		}
+		client, err := newPoolClient(horizonPool, sorobanPool)
+		if err != nil {
+			return nil, err
+		}
+		reader := *p
+		// closing the reader only closes its own client and pinned pools
+		reader.client, reader.horizonPool, reader.sorobanPool, reader.cancel = client, horizonPool, sorobanPool, nil
+		readers = append(readers, &provider.EndpointReader{Endpoint: sorobanPool.Label(), Provider: &reader})
+	}
+	return readers, nil
+}
+
 // Type returns chain-type
 func (p *Provider) Type() string {
 	return types.ChainType
@@ -75,6 +75,15 @@ func (c *Client) GetLatestLedger(ctx context.Context) (*LatestLedgerResponse, er
 	return ledgerRes, nil
 }
 
+// GetLedgerEntries returns the entries of the base64 encoded ledger keys which exist
+func (c *Client) GetLedgerEntries(ctx context.Context, keys []string) (*LedgerEntriesResponse, error) {
+	entries := &LedgerEntriesResponse{}
+	if err := c.CallContext(ctx, entries, "getLedgerEntries", map[string][]string{"keys": keys}); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
 func (c *Client) CallContext(ctx context.Context, result interface{}, method string, params interface{}) error {
 	if result != nil && reflect.TypeOf(result).Kind() != reflect.Ptr {
 		return fmt.Errorf("call result parameter must be pointer or nil interface: %v", result)
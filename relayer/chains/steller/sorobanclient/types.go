@@ -22,6 +22,17 @@ type LatestLedgerResponse struct {
 	Sequence        uint64 `json:"sequence"`
 }
 
+type LedgerEntriesResponse struct {
+	Entries      []LedgerEntry `json:"entries"`
+	LatestLedger uint64        `json:"latestLedger"`
+}
+
+type LedgerEntry struct {
+	Key                string `json:"key"`
+	XDR                string `json:"xdr"`
+	LastModifiedLedger uint64 `json:"lastModifiedLedgerSeq"`
+}
+
 type CallResult struct {
 	Xdr  string   `json:"xdr"`
 	Auth []string `json:"auth"`
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strconv"
 
 	"github.com/icon-project/centralized-relay/relayer/chains/steller/sorobanclient"
@@ -45,6 +46,7 @@ func (p *Provider) Route(ctx context.Context, message *relayertypes.Message, cal
 	cbTxRes := &relayertypes.TxResponse{
 		Height: int64(txRes.Ledger),
 		TxHash: txRes.Hash,
+		TxFee:  p.txFee(txRes),
 	}
 	if txRes.Status != "SUCCESS" {
 		cbTxRes.Code = relayertypes.Failed
@@ -57,6 +59,16 @@ func (p *Provider) Route(ctx context.Context, message *relayertypes.Message, cal
 	return nil
 }
 
+// txFee returns the fee charged for the transaction in stroops, nil if the result cannot be decoded
+func (p *Provider) txFee(txRes *sorobanclient.TransactionResponse) *big.Int {
+	var result xdr.TransactionResult
+	if err := xdr.SafeUnmarshalBase64(txRes.ResultXdr, &result); err != nil {
+		p.log.Warn("failed to decode transaction result", zap.String("tx_hash", txRes.Hash), zap.Error(err))
+		return nil
+	}
+	return big.NewInt(int64(result.FeeCharged))
+}
+
 func (p *Provider) sendCallTransaction(callArgs xdr.InvokeContractArgs) (*sorobanclient.TransactionResponse, error) {
 	p.txmut.Lock()
 	defer p.txmut.Unlock()
@@ -0,0 +1,124 @@
+package sui
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
+	"go.uber.org/zap"
+)
+
+// objectID matches the sui addresses and object ids
+var objectID = regexp.MustCompile(`^0x[0-9a-fA-F]{1,64}$`)
+
+// CheckConfig checks the required fields and the object ids of the config
+func (pc *Config) CheckConfig() []error {
+	var errs []error
+	if len(rpcpool.Merge(pc.RPCUrl, pc.RPCUrls)) == 0 {
+		errs = append(errs, fmt.Errorf("rpc-url or rpc-urls is required"))
+	}
+	if pc.NID == "" {
+		errs = append(errs, fmt.Errorf("nid is required"))
+	}
+	if pc.ConnectionModule == "" {
+		errs = append(errs, fmt.Errorf("connection-module is required"))
+	}
+	for _, id := range pc.objectIDs() {
+		if id.value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", id.field))
+		} else if !objectID.MatchString(id.value) {
+			errs = append(errs, fmt.Errorf("%s %s is not a 0x object id", id.field, id.value))
+		}
+	}
+	return errs
+}
+
+type objectIDField struct {
+	field string
+	value string
+}
+
+// objectIDs returns the address and the object ids of the config
+func (pc *Config) objectIDs() []objectIDField {
+	ids := []objectIDField{
+		{"address", pc.Address},
+		{"xcall-package-id", pc.XcallPkgID},
+		{"xcall-storage-id", pc.XcallStorageID},
+		{"connection-id", pc.ConnectionID},
+		{"connection-cap-id", pc.ConnectionCapID},
+	}
+	for i, dapp := range pc.Dapps {
+		ids = append(ids, objectIDField{fmt.Sprintf("dapps[%d].package-id", i), dapp.PkgID})
+		names := make([]string, 0, len(dapp.Constants))
+		for name := range dapp.Constants {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			ids = append(ids, objectIDField{fmt.Sprintf("dapps[%d].constants.%s", i, name), dapp.Constants[name]})
+		}
+		for j, module := range dapp.Modules {
+			ids = append(ids,
+				objectIDField{fmt.Sprintf("dapps[%d].modules[%d].cap-id", i, j), module.CapID},
+				objectIDField{fmt.Sprintf("dapps[%d].modules[%d].config-id", i, j), module.ConfigID},
+			)
+		}
+	}
+	return ids
+}
+
+// CheckNetwork compares the chain identifier of the endpoint with the chain-id
+func (p *Provider) CheckNetwork(ctx context.Context) error {
+	if p.cfg.ChainID == "" {
+		return fmt.Errorf("chain-id is not set")
+	}
+	id, err := p.client.GetChainIdentifier(ctx)
+	if err != nil {
+		return err
+	}
+	if id != p.cfg.ChainID {
+		return fmt.Errorf("rpc chain identifier %s does not match chain-id %s", id, p.cfg.ChainID)
+	}
+	return nil
+}
+
+// DetectNetwork reads the chain identifier of the rpc endpoints
+func (pc *Config) DetectNetwork(ctx context.Context) (string, error) {
+	pool, err := rpcpool.New(pc.ChainName, "rpc", rpcpool.Merge(pc.RPCUrl, pc.RPCUrls), pc.RPCPool, probeHeight, zap.NewNop())
+	if err != nil {
+		return "", err
+	}
+	auth, err := pc.RPCAuthClient()
+	if err != nil {
+		return "", err
+	}
+	pool.SetAuth(auth)
+	client, err := newPoolClient(pool, zap.NewNop())
+	if err != nil {
+		return "", err
+	}
+	return client.GetChainIdentifier(ctx)
+}
+
+// CheckContracts checks that the packages and objects of the config exist
+func (p *Provider) CheckContracts(ctx context.Context) map[string]error {
+	errs := make(map[string]error)
+	// the first id is the address of the wallet
+	for _, id := range p.cfg.objectIDs()[1:] {
+		if id.value == "" {
+			continue
+		}
+		objID, err := sui_types.NewObjectIdFromHex(id.value)
+		if err != nil {
+			errs[id.field] = err
+			continue
+		}
+		_, err = p.client.GetObject(ctx, *objID, &types.SuiObjectDataOptions{})
+		errs[id.field] = err
+	}
+	return errs
+}
@@ -22,6 +22,7 @@ const (
 	suiU64                                    = "u64"
 	suiBool                                   = "bool"
 	moveCall        suisdkClient.UnsafeMethod = "moveCall"
+	getChainID      suisdkClient.SuiMethod    = "getChainIdentifier"
 
 	CallArgPure   = "pure"
 	CallArgObject = "object"
@@ -39,6 +40,8 @@ type IClient interface {
 
 	GetObject(ctx context.Context, objID sui_types.ObjectID, options *types.SuiObjectDataOptions) (*types.SuiObjectResponse, error)
 
+	GetChainIdentifier(ctx context.Context) (string, error)
+
 	GetCoins(ctx context.Context, accountAddress string) (types.Coins, error)
 
 	MoveCall(
@@ -102,6 +105,15 @@ func (c Client) MoveCall(
 	return c.rpc.MoveCall(ctx, signer, packageId, module, function, typeArgs, arguments, gas, gasBudget)
 }
 
+// GetChainIdentifier returns the identifier of the genesis checkpoint of the network
+func (c Client) GetChainIdentifier(ctx context.Context) (string, error) {
+	var id string
+	if err := c.rpc.CallContext(ctx, &id, getChainID); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
 func (c Client) GetObject(ctx context.Context, objID sui_types.ObjectID, options *types.SuiObjectDataOptions) (*types.SuiObjectResponse, error) {
 	res, err := c.rpc.GetObject(ctx, objID, options)
 	if err != nil {
@@ -3,11 +3,13 @@ package sui
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"sync"
 	"time"
 
 	suisdkClient "github.com/coming-chat/go-sui/v2/client"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	"github.com/icon-project/centralized-relay/relayer/types"
 
 	"go.uber.org/zap"
@@ -15,11 +17,15 @@ import (
 
 type Config struct {
 	provider.CommonConfig
-	ChainName string `yaml:"-" json:"-"`
-	ChainID   string `yaml:"chain-id" json:"chain-id"`
-	RPCUrl    string `yaml:"rpc-url" json:"rpc-url"`
-	Address   string `yaml:"address" json:"address"`
-	NID       string `yaml:"nid" json:"nid"`
+	ChainName string         `yaml:"-" json:"-"`
+	ChainID   string         `yaml:"chain-id" json:"chain-id"`
+	RPCUrl    string         `yaml:"rpc-url" json:"rpc-url"`
+	RPCUrls   []string       `yaml:"rpc-urls,omitempty" json:"rpc-urls,omitempty"`
+	RPCPool   rpcpool.Config `yaml:"rpc-pool,omitempty" json:"rpc-pool,omitempty"`
+	Address   string         `yaml:"address" json:"address"`
+	NID       string         `yaml:"nid" json:"nid" jsonschema:"required"`
+
+	provider.RPCAuthConfig `yaml:",inline" json:",inline"`
 
 	XcallPkgID     string `yaml:"xcall-package-id" json:"xcall-package-id"`
 	XcallStorageID string `yaml:"xcall-storage-id" json:"xcall-storage-id"`
@@ -64,20 +70,52 @@ func (pc *Config) NewProvider(ctx context.Context, logger *zap.Logger, homePath
 	if err := pc.Validate(); err != nil {
 		return nil, err
 	}
-	rpcClient, err := suisdkClient.Dial(pc.RPCUrl)
+	pool, err := rpcpool.New(chainName, "rpc", rpcpool.Merge(pc.RPCUrl, pc.RPCUrls), pc.RPCPool, probeHeight, logger)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := pc.RPCAuthClient()
+	if err != nil {
+		return nil, err
+	}
+	pool.SetAuth(auth)
+
+	client, err := newPoolClient(pool, logger)
 	if err != nil {
 		return nil, err
 	}
-	client := NewClient(rpcClient, logger)
+	// the health checks run until the provider is closed
+	ctx, cancel := context.WithCancel(ctx)
+	go pool.Start(ctx)
 
 	return &Provider{
 		log:    logger.With(zap.String("nid ", pc.NID), zap.String("name", pc.ChainName)),
 		cfg:    pc,
 		client: client,
 		txmut:  &sync.Mutex{},
+		pool:   pool,
+		cancel: cancel,
 	}, nil
 }
 
+// newPoolClient returns the client of the endpoints of the pool
+func newPoolClient(pool *rpcpool.Pool, logger *zap.Logger) (*Client, error) {
+	rpcClient, err := suisdkClient.DialWithClient(pool.URL(), &http.Client{
+		Transport: pool.Transport(&http.Transport{
+			MaxIdleConns:    3,
+			IdleConnTimeout: 30 * time.Second,
+		}),
+		Timeout: 30 * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(rpcClient, logger), nil
+}
+
+// probeHeight returns the sequence number of the latest checkpoint of the endpoint
+var probeHeight = rpcpool.JSONRPCProbe("sui_getLatestCheckpointSequenceNumber", nil, rpcpool.ParseNumber)
+
 func (pc *Config) SetWallet(addr string) {
 	pc.Address = addr
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/icon-project/centralized-relay/relayer/chains/sui/types"
 	"github.com/icon-project/centralized-relay/relayer/kms"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
 	"go.uber.org/zap"
 )
@@ -45,6 +46,9 @@ type Provider struct {
 	kms                 kms.KMS
 	txmut               *sync.Mutex
 	LastSavedHeightFunc func() uint64
+	pool                *rpcpool.Pool
+	// cancel stops the health checks of the pool
+	cancel context.CancelFunc
 }
 
 func (p *Provider) QueryLatestHeight(ctx context.Context) (uint64, error) {
@@ -69,6 +73,35 @@ func (p *Provider) Init(ctx context.Context, homePath string, kms kms.KMS) error
 	return nil
 }
 
+// Close stops the health checks of the pool and closes its connections
+func (p *Provider) Close() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.pool.Close()
+	return nil
+}
+
+// EndpointReaders returns a provider per rpc endpoint for the quorum reads
+func (p *Provider) EndpointReaders(ctx context.Context) ([]*provider.EndpointReader, error) {
+	var readers []*provider.EndpointReader
+	for _, url := range p.pool.Endpoints() {
+		pool, err := p.pool.Pin(url)
+		if err != nil {
+			return nil, err
+		}
+		client, err := newPoolClient(pool, p.log)
+		if err != nil {
+			return nil, err
+		}
+		reader := *p
+		// closing the reader only closes its own client and pinned pool
+		reader.client, reader.pool, reader.cancel = client, pool, nil
+		readers = append(readers, &provider.EndpointReader{Endpoint: pool.Label(), Provider: &reader})
+	}
+	return readers, nil
+}
+
 // Type returns chain-type
 func (p *Provider) Type() string {
 	return types.ChainType
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
@@ -379,6 +380,10 @@ func (p *Provider) executeRouteCallBack(txRes *types.SuiTransactionBlockResponse
 	}
 
 	res.Height = txnData.Checkpoint.Int64()
+	if txRes.Effects.Data.V1 != nil {
+		res.GasUsed = txRes.Effects.Data.V1.GasUsed.ComputationCost.Uint64()
+		res.TxFee = big.NewInt(txRes.Effects.Data.GasFee())
+	}
 	success := txRes.Effects.Data.IsSuccess()
 	if !success {
 		res.Code = relayertypes.Failed
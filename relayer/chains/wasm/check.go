@@ -0,0 +1,115 @@
+package wasm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
+	relayTypes "github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+// CheckConfig checks the required fields and the bech32 addresses of the config
+func (pc *Config) CheckConfig() []error {
+	var errs []error
+	if len(pc.RPCEndpoints()) == 0 {
+		errs = append(errs, fmt.Errorf("rpc-url or rpc-urls is required"))
+	}
+	if pc.NID == "" {
+		errs = append(errs, fmt.Errorf("nid is required"))
+	}
+	if pc.KeyringBackend == "" {
+		errs = append(errs, fmt.Errorf("keyring-backend is required"))
+	}
+	if pc.AccountPrefix == "" {
+		errs = append(errs, fmt.Errorf("account-prefix is required"))
+	}
+	if pc.Denomination == "" {
+		errs = append(errs, fmt.Errorf("denomination is required"))
+	}
+	if pc.GasPrices == "" {
+		errs = append(errs, fmt.Errorf("gas-prices is required"))
+	}
+	if pc.Address == "" {
+		errs = append(errs, fmt.Errorf("address is required"))
+	} else if err := pc.checkBech32(pc.Address); err != nil {
+		errs = append(errs, fmt.Errorf("address %s: %w", pc.Address, err))
+	}
+	if pc.Contracts[relayTypes.ConnectionContract] == "" {
+		errs = append(errs, fmt.Errorf("contracts.%s is required", relayTypes.ConnectionContract))
+	}
+	for _, name := range pc.Contracts.Names() {
+		addr := pc.Contracts[name]
+		if addr == "" {
+			continue
+		}
+		if err := pc.checkBech32(addr); err != nil {
+			errs = append(errs, fmt.Errorf("contracts.%s %s: %w", name, addr, err))
+		}
+	}
+	for _, name := range pc.Contracts.UnknownKeys() {
+		errs = append(errs, fmt.Errorf("contracts.%s is not a known contract", name))
+	}
+	return errs
+}
+
+// checkBech32 checks that addr is a bech32 address with the account prefix
+func (pc *Config) checkBech32(addr string) error {
+	prefix, _, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return fmt.Errorf("not a bech32 address: %w", err)
+	}
+	if pc.AccountPrefix != "" && prefix != pc.AccountPrefix {
+		return fmt.Errorf("prefix %s does not match account-prefix %s", prefix, pc.AccountPrefix)
+	}
+	return nil
+}
+
+// CheckNetwork compares the chain id of the node with the nid
+func (p *Provider) CheckNetwork(ctx context.Context) error {
+	status, err := p.client.GetNetworkInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if status.NodeInfo.Network != p.NID() {
+		return fmt.Errorf("rpc chain id %s does not match nid %s", status.NodeInfo.Network, p.NID())
+	}
+	return nil
+}
+
+// DetectNetwork reads the chain id of the node of the rpc endpoints
+func (pc *Config) DetectNetwork(ctx context.Context) (string, error) {
+	pool, err := rpcpool.New(pc.ChainName, "rpc", pc.RPCEndpoints(), pc.RPCPool, probeHeight, zap.NewNop())
+	if err != nil {
+		return "", err
+	}
+	auth, err := pc.RPCAuthClient()
+	if err != nil {
+		return "", err
+	}
+	pool.SetAuth(auth)
+	client, err := http.NewWithClient(pool.URL(), "/websocket", pool.HTTPClient(nil))
+	if err != nil {
+		return "", err
+	}
+	status, err := client.Status(ctx)
+	if err != nil {
+		return "", err
+	}
+	return status.NodeInfo.Network, nil
+}
+
+// CheckContracts checks that the contracts are instantiated
+func (p *Provider) CheckContracts(ctx context.Context) map[string]error {
+	errs := make(map[string]error)
+	for name, addr := range p.cfg.Contracts {
+		if addr == "" {
+			continue
+		}
+		_, err := p.client.QueryContractInfo(ctx, addr)
+		errs[name] = err
+	}
+	return errs
+}
@@ -21,6 +21,7 @@ import (
 
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/icon-project/centralized-relay/relayer/chains/wasm/types"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	"github.com/spf13/pflag"
 )
 
@@ -28,6 +29,7 @@ type IClient interface {
 	HTTP(rpcUrl string) (*http.HTTP, error)
 	IsConnected() bool
 	Reconnect() error
+	Disconnect()
 	GetLatestBlockHeight(ctx context.Context) (uint64, error)
 	GetTransactionReceipt(ctx context.Context, txHash string) (*txTypes.GetTxResponse, error)
 	GetBalance(ctx context.Context, addr string, denomination string) (*sdkTypes.Coin, error)
@@ -38,6 +40,7 @@ type IClient interface {
 	TxSearch(ctx context.Context, param types.TxSearchParam) (*coretypes.ResultTxSearch, error)
 	GetAccountInfo(ctx context.Context, addr string) (sdkTypes.AccountI, error)
 	QuerySmartContract(ctx context.Context, address string, queryData []byte) (*wasmTypes.QuerySmartContractStateResponse, error)
+	QueryContractInfo(ctx context.Context, address string) (*wasmTypes.QueryContractInfoResponse, error)
 	CreateAccount(name, pass string) (string, string, error)
 	ImportArmor(uid string, armor []byte, passphrase string) error
 	GetArmor(uid, passphrase string) (string, error)
@@ -52,11 +55,14 @@ type IClient interface {
 }
 
 type Client struct {
-	ctx sdkClient.Context
+	ctx  sdkClient.Context
+	pool *rpcpool.Pool
+	// events serves the subscriptions, the comet client only the queries
+	events *subscriber
 }
 
-func newClient(ctx sdkClient.Context) *Client {
-	return &Client{ctx}
+func newClient(ctx sdkClient.Context, pool *rpcpool.Pool) *Client {
+	return &Client{ctx, pool, newSubscriber(pool)}
 }
 
 func (c *Client) BuildTxFactory() (tx.Factory, error) {
@@ -88,7 +94,7 @@ func (c *Client) BroadcastTx(txBytes []byte) (*sdkTypes.TxResponse, error) {
 }
 
 func (c *Client) HTTP(rpcUrl string) (*http.HTTP, error) {
-	return http.New(rpcUrl, "/websocket")
+	return http.NewWithClient(rpcUrl, "/websocket", c.pool.HTTPClient(nil))
 }
 
 func (c *Client) GetLatestBlockHeight(ctx context.Context) (uint64, error) {
@@ -204,6 +210,12 @@ func (c *Client) SetAddress(addr sdkTypes.AccAddress) sdkTypes.AccAddress {
 	return addr
 }
 
+// QueryContractInfo returns the code id and the admin of the contract
+func (c *Client) QueryContractInfo(ctx context.Context, address string) (*wasmTypes.QueryContractInfoResponse, error) {
+	queryClient := wasmTypes.NewQueryClient(c.ctx)
+	return queryClient.ContractInfo(ctx, &wasmTypes.QueryContractInfoRequest{Address: address})
+}
+
 func (c *Client) QuerySmartContract(ctx context.Context, address string, queryData []byte) (*wasmTypes.QuerySmartContractStateResponse, error) {
 	queryClient := wasmTypes.NewQueryClient(c.ctx)
 	return queryClient.SmartContractState(ctx, &wasmTypes.QuerySmartContractStateRequest{
@@ -238,29 +250,40 @@ func (c *Client) GetNetworkInfo(ctx context.Context) (*coretypes.ResultStatus, e
 
 // Subscribe
 func (c *Client) Subscribe(ctx context.Context, _, query string) (<-chan coretypes.ResultEvent, error) {
-	return c.ctx.Client.(*http.HTTP).Subscribe(ctx, "subscribe", query)
+	return c.events.subscribe(ctx, query)
 }
 
 // Unsubscribe
 func (c *Client) Unsubscribe(ctx context.Context, _, query string) error {
-	return c.ctx.Client.(*http.HTTP).Unsubscribe(ctx, "unsubscribe", query)
+	return c.events.unsubscribe(ctx, query)
 }
 
-// IsConnected returns if the client is connected to the network
+// IsConnected returns if the websocket of the client is connected
 func (c *Client) IsConnected() bool {
-	return c.ctx.Client.(*http.HTTP).IsRunning()
+	return c.events.running()
 }
 
-// RestartClient restarts the client
+// Reconnect connects the client to the current endpoint of the pool
 func (c *Client) Reconnect() error {
-	client, err := c.HTTP(c.ctx.NodeURI)
+	nodeURI := c.pool.Current()
+	client, err := c.HTTP(nodeURI)
 	if err != nil {
 		return err
 	}
+	if err := c.events.start(); err != nil {
+		return err
+	}
 	c.ctx.Client = client
+	c.ctx.NodeURI = nodeURI
 	return nil
 }
 
+// Disconnect stops the websocket of the client, the listener reconnects
+// and subscribes again to the current endpoint of the pool
+func (c *Client) Disconnect() {
+	c.events.stop()
+}
+
 // Signs the message with private key
 // TODO: not sure if this is the right way to sign the message
 func (c *Client) SignMessage(uid string, message []byte) []byte {
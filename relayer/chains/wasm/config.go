@@ -2,6 +2,7 @@ package wasm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/icon-project/centralized-relay/relayer/chains/wasm/types"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	relayTypes "github.com/icon-project/centralized-relay/relayer/types"
 
 	"go.uber.org/zap"
@@ -55,14 +57,38 @@ func (pc *Config) NewProvider(ctx context.Context, log *zap.Logger, homePath str
 		return nil, err
 	}
 
-	clientContext, err := pc.newClientContext(ctx)
+	pool, err := rpcpool.New(chainName, "rpc", pc.RPCEndpoints(), pc.RPCPool, probeHeight, log)
 	if err != nil {
 		return nil, err
 	}
+	auth, err := pc.RPCAuthClient()
+	if err != nil {
+		return nil, err
+	}
+	pool.SetAuth(auth)
+
+	// the client context and the health checks live until the provider is closed
+	ctx, cancel := context.WithCancel(ctx)
+	clientContext, err := pc.newClientContext(ctx, pool)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
 
 	contracts := pc.eventMap()
 
-	ws := newClient(clientContext)
+	ws := newClient(clientContext, pool)
+	if err := ws.events.start(); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// the queries fail over through the pool, the subscriptions are moved
+	// to the new endpoint when the listener reconnects
+	pool.OnChange(func(url string) {
+		ws.Disconnect()
+	})
+	go pool.Start(ctx)
 
 	return &Provider{
 		logger:      log.With(zap.Stringp("nid", &pc.NID), zap.Stringp("name", &pc.ChainName)),
@@ -71,6 +97,8 @@ func (pc *Config) NewProvider(ctx context.Context, log *zap.Logger, homePath str
 		contracts:   contracts,
 		eventList:   pc.GetMonitorEventFilters(contracts),
 		routerMutex: new(sync.Mutex),
+		pool:        pool,
+		cancel:      cancel,
 	}, nil
 }
 
@@ -100,10 +128,13 @@ func (pc *Config) sanitize() (*Config, error) {
 	if pc.Decimals == 0 {
 		pc.Decimals = relayTypes.DefaultCoinDecimals
 	}
+	if pc.TxConfirmationInterval <= 0 {
+		pc.TxConfirmationInterval = 6 * time.Second
+	}
 	return pc, nil
 }
 
-func (c *Config) newClientContext(ctx context.Context) (sdkClient.Context, error) {
+func (c *Config) newClientContext(ctx context.Context, pool *rpcpool.Pool) (sdkClient.Context, error) {
 	codec := c.MakeCodec(moduleBasics, strings.Split(c.ExtraCodec, ",")...)
 
 	keyRing, err := keyring.New(
@@ -121,7 +152,8 @@ func (c *Config) newClientContext(ctx context.Context) (sdkClient.Context, error
 		return sdkClient.Context{}, err
 	}
 
-	cometRPCClient, err := http.New(c.RPCUrl, "/websocket")
+	nodeURI := pool.Current()
+	cometRPCClient, err := http.NewWithClient(nodeURI, "/websocket", pool.HTTPClient(nil))
 	if err != nil {
 		return sdkClient.Context{}, err
 	}
@@ -135,7 +167,7 @@ func (c *Config) newClientContext(ctx context.Context) (sdkClient.Context, error
 		CmdContext:        ctx,
 		ChainID:           networkInfo.NodeInfo.Network,
 		Client:            cometRPCClient,
-		NodeURI:           c.RPCUrl,
+		NodeURI:           nodeURI,
 		Codec:             codec.Codec,
 		Keyring:           keyRing,
 		KeyringDir:        c.KeyringDir,
@@ -146,9 +178,24 @@ func (c *Config) newClientContext(ctx context.Context) (sdkClient.Context, error
 		Simulate:          c.Simulate,
 		InterfaceRegistry: codec.InterfaceRegistry,
 		AccountRetriever:  authtypes.AccountRetriever{},
-	}, cometRPCClient.Start()
+	}, nil
 }
 
+// probeHeight returns the latest block height from the status of the endpoint
+var probeHeight = rpcpool.HTTPProbe("/status", func(body []byte) (uint64, error) {
+	var status struct {
+		Result struct {
+			SyncInfo struct {
+				LatestBlockHeight json.RawMessage `json:"latest_block_height"`
+			} `json:"sync_info"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return 0, err
+	}
+	return rpcpool.ParseNumber(status.Result.SyncInfo.LatestBlockHeight)
+})
+
 func (p *Config) GetConnContract() string {
 	return p.Contracts[relayTypes.ConnectionContract]
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/icon-project/centralized-relay/relayer/events"
 	"github.com/icon-project/centralized-relay/relayer/kms"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	relayTypes "github.com/icon-project/centralized-relay/relayer/types"
 	"github.com/icon-project/centralized-relay/utils/retry"
 	jsoniter "github.com/json-iterator/go"
@@ -38,6 +39,9 @@ type Provider struct {
 	eventList           []sdkTypes.Event
 	LastSavedHeightFunc func() uint64
 	routerMutex         *sync.Mutex
+	pool                *rpcpool.Pool
+	// cancel stops the health checks of the pool and the client context
+	cancel context.CancelFunc
 }
 
 func (p *Provider) QueryLatestHeight(ctx context.Context) (uint64, error) {
@@ -93,6 +97,37 @@ func (p *Provider) Wallet() sdkTypes.AccAddress {
 	return p.wallet.GetAddress()
 }
 
+// Close stops the health checks of the pool and closes the websocket and the
+// connections of the client
+func (p *Provider) Close() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.client.Disconnect()
+	p.pool.Close()
+	return nil
+}
+
+// EndpointReaders returns a provider per rpc endpoint for the quorum reads
+func (p *Provider) EndpointReaders(ctx context.Context) ([]*provider.EndpointReader, error) {
+	var readers []*provider.EndpointReader
+	for _, url := range p.pool.Endpoints() {
+		pool, err := p.pool.Pin(url)
+		if err != nil {
+			return nil, err
+		}
+		clientContext, err := p.cfg.newClientContext(ctx, pool)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pool.Label(), err)
+		}
+		reader := *p
+		// closing the reader only closes its own client and pinned pool
+		reader.client, reader.pool, reader.cancel = newClient(clientContext, pool), pool, nil
+		readers = append(readers, &provider.EndpointReader{Endpoint: pool.Label(), Provider: &reader})
+	}
+	return readers, nil
+}
+
 func (p *Provider) Type() string {
 	return types.ChainType
 }
@@ -187,6 +222,26 @@ func (p *Provider) Route(ctx context.Context, message *relayTypes.Message, callb
 
 // call the smart contract to send the message
 func (p *Provider) call(ctx context.Context, message *relayTypes.Message) (*sdkTypes.TxResponse, error) {
+	msg, err := p.newExecuteContractMsg(message)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := []sdkTypes.Msg{msg}
+
+	res, err := p.sendMessage(ctx, msgs...)
+	if err != nil {
+		if strings.Contains(err.Error(), errors.ErrWrongSequence.Error()) {
+			if mmErr := p.handleSequence(ctx); mmErr != nil {
+				return res, fmt.Errorf("failed to handle sequence mismatch error: %v || %v", mmErr, err)
+			}
+			return p.sendMessage(ctx, msgs...)
+		}
+	}
+	return res, err
+}
+
+func (p *Provider) newExecuteContractMsg(message *relayTypes.Message) (*wasmTypes.MsgExecuteContract, error) {
 	rawMsg, err := p.getRawContractMessage(message)
 	if err != nil {
 		return nil, err
@@ -205,24 +260,48 @@ func (p *Provider) call(ctx context.Context, message *relayTypes.Message) (*sdkT
 		return nil, fmt.Errorf("unknown event type: %s ", message.EventType)
 	}
 
-	msg := &wasmTypes.MsgExecuteContract{
+	return &wasmTypes.MsgExecuteContract{
 		Sender:   p.Wallet().String(),
 		Contract: contract,
 		Msg:      rawMsg,
+	}, nil
+}
+
+// EstimateCost returns the expected fee of delivering the message in the fee denomination
+func (p *Provider) EstimateCost(ctx context.Context, message *relayTypes.Message) (*big.Int, error) {
+	msg, err := p.newExecuteContractMsg(message)
+	if err != nil {
+		return nil, err
+	}
+	txf, err := p.client.BuildTxFactory()
+	if err != nil {
+		return nil, err
 	}
+	txf = txf.
+		WithGasPrices(p.cfg.GasPrices).
+		WithGasAdjustment(p.cfg.GasAdjustment).
+		WithAccountNumber(p.wallet.GetAccountNumber()).
+		WithSequence(p.wallet.GetSequence())
 
-	msgs := []sdkTypes.Msg{msg}
+	_, gas, err := p.client.EstimateGas(txf, msg)
+	if err != nil {
+		return nil, err
+	}
+	cost := p.gasFee(gas)
+	if cost == nil {
+		return nil, fmt.Errorf("failed to parse gas prices: %s", p.cfg.GasPrices)
+	}
+	return cost, nil
+}
 
-	res, err := p.sendMessage(ctx, msgs...)
+// gasFee returns the fee of the gas limit at the configured gas price,
+// the fee is charged on the gas limit and not on the gas used
+func (p *Provider) gasFee(gas uint64) *big.Int {
+	gasPrices, err := sdkTypes.ParseDecCoins(p.cfg.GasPrices)
 	if err != nil {
-		if strings.Contains(err.Error(), errors.ErrWrongSequence.Error()) {
-			if mmErr := p.handleSequence(ctx); mmErr != nil {
-				return res, fmt.Errorf("failed to handle sequence mismatch error: %v || %v", mmErr, err)
-			}
-			return p.sendMessage(ctx, msgs...)
-		}
+		return nil
 	}
-	return res, err
+	return gasPrices.AmountOf(p.cfg.Denomination).MulInt64(int64(gas)).Ceil().TruncateInt().BigInt()
 }
 
 func (p *Provider) sendMessage(ctx context.Context, msgs ...sdkTypes.Msg) (*sdkTypes.TxResponse, error) {
@@ -385,9 +464,11 @@ func (p *Provider) subscribeTxResult(ctx context.Context, tx *sdkTypes.TxRespons
 
 			res := &types.TxResult{
 				TxResult: &relayTypes.TxResponse{
-					Height: txRes.Height,
-					TxHash: tx.TxHash,
-					Data:   string(txRes.Result.Data),
+					Height:  txRes.Height,
+					TxHash:  tx.TxHash,
+					Data:    string(txRes.Result.Data),
+					GasUsed: uint64(txRes.Result.GasUsed),
+					TxFee:   p.gasFee(uint64(txRes.Result.GasWanted)),
 				},
 			}
 			if uint32(txRes.Result.Code) != types.CodeTypeOK {
@@ -523,11 +604,70 @@ func (p *Provider) SetFee(ctx context.Context, networkdID string, msgFee, resFee
 
 // ClaimFee
 func (p *Provider) ClaimFee(ctx context.Context) error {
+	_, err := p.ClaimFeeAmount(ctx)
+	return err
+}
+
+// claimTimeout bounds the wait of the result of the claim transaction
+const claimTimeout = time.Minute
+
+// ClaimFeeAmount claims the fees and returns the coins of the denomination
+// transferred by the connection in the claim transaction
+func (p *Provider) ClaimFeeAmount(ctx context.Context) (*big.Int, error) {
 	msg := &relayTypes.Message{
 		EventType: events.ClaimFee,
 	}
-	_, err := p.call(ctx, msg)
-	return err
+	res, err := p.call(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, claimTimeout)
+	defer cancel()
+	ticker := time.NewTicker(p.cfg.TxConfirmationInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := p.client.GetTransactionReceipt(ctx, res.TxHash)
+		if err == nil {
+			if receipt.TxResponse.Code != abci.CodeTypeOK {
+				return nil, fmt.Errorf("failed to claim fees: %s", receipt.TxResponse.RawLog)
+			}
+			return transferredAmount(receipt.TxResponse.Events, p.cfg.Contracts[relayTypes.ConnectionContract], p.cfg.Denomination)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("claim fee tx %s not found: %w", res.TxHash, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// transferredAmount sums the coins of the denomination sent by the sender in
+// the bank transfer events
+func transferredAmount(txEvents []abci.Event, sender, denom string) (*big.Int, error) {
+	amount := new(big.Int)
+	for _, event := range txEvents {
+		if event.Type != "transfer" {
+			continue
+		}
+		var from, coins string
+		for _, attr := range event.Attributes {
+			switch attr.Key {
+			case "sender":
+				from = attr.Value
+			case "amount":
+				coins = attr.Value
+			}
+		}
+		if from != sender {
+			continue
+		}
+		parsed, err := sdkTypes.ParseCoinsNormalized(coins)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transfer amount %q: %w", coins, err)
+		}
+		amount.Add(amount, parsed.AmountOf(denom).BigInt())
+	}
+	return amount, nil
 }
 
 // GetFee returns the fee for the given networkID
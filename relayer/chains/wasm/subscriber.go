@@ -0,0 +1,146 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/gorilla/websocket"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
+)
+
+var errSubscriberStopped = errors.New("websocket subscriber is not running")
+
+// subscriber receives the events of the comet websocket of the current
+// endpoint of the pool, the handshake carries the rpc-auth headers and
+// http-options of the pool which the comet websocket client does not take
+type subscriber struct {
+	pool *rpcpool.Pool
+
+	mu            sync.RWMutex
+	conn          *websocket.Conn
+	subscriptions map[string]chan coretypes.ResultEvent
+}
+
+func newSubscriber(pool *rpcpool.Pool) *subscriber {
+	return &subscriber{
+		pool:          pool,
+		subscriptions: make(map[string]chan coretypes.ResultEvent),
+	}
+}
+
+// start dials the websocket of the current endpoint of the pool
+func (s *subscriber) start() error {
+	header := make(http.Header)
+	if err := s.pool.Auth().SetHeader(header); err != nil {
+		return err
+	}
+	url := strings.Replace(s.pool.Current(), "http", "ws", 1) + "/websocket"
+	conn, _, err := s.pool.Auth().WebsocketDialer().Dial(url, header) //nolint:bodyclose
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	old := s.conn
+	s.conn = conn
+	s.mu.Unlock()
+	if old != nil {
+		old.Close()
+	}
+	go s.readLoop(conn)
+	return nil
+}
+
+// stop closes the websocket, the channels of the subscriptions are never
+// closed so that the receivers do not read an empty event
+func (s *subscriber) stop() {
+	s.mu.Lock()
+	conn := s.conn
+	s.conn = nil
+	s.subscriptions = make(map[string]chan coretypes.ResultEvent)
+	s.mu.Unlock()
+	if conn != nil {
+		conn.Close()
+	}
+}
+
+func (s *subscriber) running() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.conn != nil
+}
+
+func (s *subscriber) subscribe(ctx context.Context, query string) (<-chan coretypes.ResultEvent, error) {
+	if err := s.send(ctx, "subscribe", query); err != nil {
+		return nil, err
+	}
+	out := make(chan coretypes.ResultEvent, 1)
+	s.mu.Lock()
+	s.subscriptions[query] = out
+	s.mu.Unlock()
+	return out, nil
+}
+
+func (s *subscriber) unsubscribe(ctx context.Context, query string) error {
+	s.mu.Lock()
+	delete(s.subscriptions, query)
+	s.mu.Unlock()
+	return s.send(ctx, "unsubscribe", query)
+}
+
+func (s *subscriber) send(ctx context.Context, method, query string) error {
+	req, err := rpctypes.MapToRequest(rpctypes.JSONRPCStringID(method), method, map[string]interface{}{"query": query})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return errSubscriberStopped
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		s.conn.SetWriteDeadline(deadline)
+		defer s.conn.SetWriteDeadline(time.Time{})
+	}
+	return s.conn.WriteJSON(req)
+}
+
+// readLoop forwards the events to the subscriptions of their query until
+// the connection fails, the subscriber is then no longer running
+func (s *subscriber) readLoop(conn *websocket.Conn) {
+	defer func() {
+		s.mu.Lock()
+		if s.conn == conn {
+			s.conn = nil
+		}
+		s.mu.Unlock()
+		conn.Close()
+	}()
+	for {
+		var resp rpctypes.RPCResponse
+		if err := conn.ReadJSON(&resp); err != nil {
+			return
+		}
+		if resp.Error != nil || len(resp.Result) == 0 {
+			continue
+		}
+		var event coretypes.ResultEvent
+		if err := cmtjson.Unmarshal(resp.Result, &event); err != nil {
+			continue
+		}
+		s.mu.RLock()
+		if out, ok := s.subscriptions[event.Query]; ok {
+			select {
+			case out <- event:
+			default:
+			}
+		}
+		s.mu.RUnlock()
+	}
+}
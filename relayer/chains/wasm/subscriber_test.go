@@ -0,0 +1,85 @@
+package wasm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/gorilla/websocket"
+	"github.com/icon-project/centralized-relay/relayer/rpcauth"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSubscriberAuth(t *testing.T) {
+	const query = "tm.event = 'Tx'"
+	upgrader := websocket.Upgrader{}
+	headers := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var req rpctypes.RPCRequest
+		if err := conn.ReadJSON(&req); err != nil || req.Method != "subscribe" {
+			return
+		}
+		conn.WriteJSON(rpctypes.NewRPCSuccessResponse(req.ID, struct{}{}))
+		conn.WriteJSON(rpctypes.NewRPCSuccessResponse(req.ID, coretypes.ResultEvent{
+			Query:  query,
+			Events: map[string][]string{"tx.height": {"10"}},
+		}))
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	newPool := func(auth *rpcauth.Auth) *rpcpool.Pool {
+		pool, err := rpcpool.New("test", "rpc", []string{server.URL}, rpcpool.Config{}, probeHeight, zap.NewNop())
+		require.NoError(t, err)
+		client, err := rpcauth.New(auth, nil)
+		require.NoError(t, err)
+		pool.SetAuth(client)
+		return pool
+	}
+
+	t.Run("headers of the handshake", func(t *testing.T) {
+		s := newSubscriber(newPool(&rpcauth.Auth{Headers: map[string]rpcauth.Secret{"x-api-key": {Value: "secret"}}}))
+		require.NoError(t, s.start())
+		defer s.stop()
+		assert.Equal(t, "secret", (<-headers).Get("X-Api-Key"))
+		assert.True(t, s.running())
+
+		events, err := s.subscribe(context.Background(), query)
+		require.NoError(t, err)
+		select {
+		case event := <-events:
+			assert.Equal(t, []string{"10"}, event.Events["tx.height"])
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event received")
+		}
+
+		s.stop()
+		assert.False(t, s.running())
+		_, err = s.subscribe(context.Background(), query)
+		assert.ErrorIs(t, err, errSubscriberStopped)
+	})
+
+	t.Run("rejected without the headers", func(t *testing.T) {
+		s := newSubscriber(newPool(nil))
+		assert.Error(t, s.start())
+		assert.Empty(t, (<-headers).Get("X-Api-Key"))
+		assert.False(t, s.running())
+	})
+}
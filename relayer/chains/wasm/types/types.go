@@ -61,6 +61,8 @@ type TxResultResponse struct {
 	Result struct {
 		Code      int              `json:"code"`
 		Codespace string           `json:"codespace"`
+		GasWanted int64            `json:"gas_wanted"`
+		GasUsed   int64            `json:"gas_used"`
 		Data      []byte           `json:"data"`
 		Log       string           `json:"log"`
 		Events    []abiTypes.Event `json:"events"`
@@ -0,0 +1,251 @@
+package circuit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultThreshold = 5
+	DefaultCooldown  = time.Minute
+)
+
+type State uint8
+
+const (
+	// Closed dispatches every message
+	Closed State = iota
+	// HalfOpen dispatches a single probe message
+	HalfOpen
+	// Open stops dispatching until the cooldown elapses
+	Open
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case HalfOpen:
+		return "half-open"
+	case Open:
+		return "open"
+	}
+	return "unknown"
+}
+
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *State) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "closed":
+		*s = Closed
+	case "half-open":
+		*s = HalfOpen
+	case "open":
+		*s = Open
+	default:
+		return fmt.Errorf("unknown circuit state: %s", text)
+	}
+	return nil
+}
+
+// Config of the circuit breakers of the destination chains
+type Config struct {
+	Disabled bool `yaml:"disabled" json:"disabled"`
+	// Threshold is the number of consecutive failures which opens the circuit
+	Threshold uint `yaml:"threshold" json:"threshold"`
+	// Cooldown is the wait before probing an open circuit
+	Cooldown time.Duration `yaml:"cooldown" json:"cooldown"`
+}
+
+func (c *Config) sanitize() {
+	if c.Threshold == 0 {
+		c.Threshold = DefaultThreshold
+	}
+	if c.Cooldown == 0 {
+		c.Cooldown = DefaultCooldown
+	}
+}
+
+// Status is the snapshot of a circuit breaker
+type Status struct {
+	Chain    string    `json:"chain"`
+	State    State     `json:"state"`
+	Failures uint      `json:"failures"`
+	OpenedAt time.Time `json:"openedAt"`
+}
+
+// Breaker stops dispatching to a destination after consecutive failures
+type Breaker struct {
+	mu       sync.Mutex
+	chain    string
+	cfg      Config
+	state    State
+	failures uint
+	openedAt time.Time
+	probeAt  time.Time
+	onChange func(State)
+}
+
+func NewBreaker(chain string, cfg *Config) *Breaker {
+	b := &Breaker{chain: chain}
+	b.Configure(cfg)
+	return b
+}
+
+// Configure replaces the config of the breaker, nil config uses the defaults
+func (b *Breaker) Configure(cfg *Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if cfg == nil {
+		cfg = new(Config)
+	}
+	b.cfg = *cfg
+	b.cfg.sanitize()
+}
+
+// OnChange registers the function called on every state transition
+func (b *Breaker) OnChange(fn func(State)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onChange = fn
+}
+
+// Allow returns true if a message can be dispatched, once the cooldown of an
+// open circuit elapses a single probe is allowed per cooldown
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.cfg.Disabled {
+		return true
+	}
+	switch b.state {
+	case Open:
+		if time.Since(b.openedAt) < b.cfg.Cooldown {
+			return false
+		}
+		b.setState(HalfOpen)
+		b.probeAt = time.Now()
+		return true
+	case HalfOpen:
+		// a probe without result is given up after the cooldown
+		if time.Since(b.probeAt) < b.cfg.Cooldown {
+			return false
+		}
+		b.probeAt = time.Now()
+		return true
+	}
+	return true
+}
+
+// Success closes the circuit
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.setState(Closed)
+}
+
+// Reached records a message which reached the chain and failed for a reason of
+// its own, as a revert. It is not a failure of the chain: the consecutive
+// failures are kept and a half open circuit is closed
+func (b *Breaker) Reached() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == HalfOpen {
+		b.failures = 0
+		b.setState(Closed)
+	}
+}
+
+// Failure records a failure and returns the resulting state
+func (b *Breaker) Failure() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.cfg.Disabled {
+		return b.state
+	}
+	if b.state == HalfOpen || (b.state == Closed && b.failures >= b.cfg.Threshold) {
+		b.openedAt = time.Now()
+		b.setState(Open)
+	}
+	return b.state
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *Breaker) Status() *Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &Status{
+		Chain:    b.chain,
+		State:    b.state,
+		Failures: b.failures,
+		OpenedAt: b.openedAt,
+	}
+}
+
+func (b *Breaker) setState(state State) {
+	if b.state == state {
+		return
+	}
+	b.state = state
+	if b.onChange != nil {
+		b.onChange(state)
+	}
+}
+
+// transportErrors are the messages of the network and endpoint errors, the
+// clients of the chains often wrap them as strings. They are full phrases so
+// the text of a revert or an application error does not match them
+var transportErrors = []string{
+	"connection refused",
+	"connection reset",
+	"broken pipe",
+	"no such host",
+	"network is unreachable",
+	"tls handshake",
+	"i/o timeout",
+	"context deadline exceeded",
+	"unexpected eof",
+	"internal server error",
+	"too many requests",
+	"bad gateway",
+	"service unavailable",
+	"gateway timeout",
+	"websocket: close",
+}
+
+// IsTransportFailure returns true if the error is a failure to reach the chain:
+// a network error, a timeout or an error status of the endpoint. The errors
+// of the messages, as the reverts, are not
+func IsTransportFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, transportErr := range transportErrors {
+		if strings.Contains(msg, transportErr) {
+			return true
+		}
+	}
+	return false
+}
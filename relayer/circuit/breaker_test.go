@@ -0,0 +1,102 @@
+package circuit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	t.Run("opens after threshold", func(t *testing.T) {
+		b := NewBreaker("dst", &Config{Threshold: 2, Cooldown: time.Hour})
+		assert.True(t, b.Allow())
+		assert.Equal(t, Closed, b.Failure())
+		assert.Equal(t, Open, b.Failure())
+		assert.False(t, b.Allow())
+	})
+
+	t.Run("single probe when half open", func(t *testing.T) {
+		b := NewBreaker("dst", &Config{Threshold: 1, Cooldown: 10 * time.Millisecond})
+		b.Failure()
+		time.Sleep(20 * time.Millisecond)
+		assert.True(t, b.Allow())
+		assert.Equal(t, HalfOpen, b.State())
+		assert.False(t, b.Allow())
+	})
+
+	t.Run("probe failure reopens", func(t *testing.T) {
+		b := NewBreaker("dst", &Config{Threshold: 3, Cooldown: 10 * time.Millisecond})
+		b.Failure()
+		b.Failure()
+		b.Failure()
+		time.Sleep(20 * time.Millisecond)
+		assert.True(t, b.Allow())
+		assert.Equal(t, Open, b.Failure())
+		assert.False(t, b.Allow())
+	})
+
+	t.Run("success closes", func(t *testing.T) {
+		b := NewBreaker("dst", &Config{Threshold: 1, Cooldown: 10 * time.Millisecond})
+		var states []State
+		b.OnChange(func(s State) { states = append(states, s) })
+		b.Failure()
+		time.Sleep(20 * time.Millisecond)
+		assert.True(t, b.Allow())
+		b.Success()
+		assert.Equal(t, Closed, b.State())
+		assert.Equal(t, uint(0), b.Status().Failures)
+		assert.Equal(t, []State{Open, HalfOpen, Closed}, states)
+	})
+
+	t.Run("message failure closes half open", func(t *testing.T) {
+		b := NewBreaker("dst", &Config{Threshold: 2, Cooldown: 10 * time.Millisecond})
+		b.Failure()
+		b.Reached()
+		assert.Equal(t, Closed, b.State())
+		assert.Equal(t, uint(1), b.Status().Failures)
+		b.Failure()
+		time.Sleep(20 * time.Millisecond)
+		assert.True(t, b.Allow())
+		b.Reached()
+		assert.Equal(t, Closed, b.State())
+		assert.Equal(t, uint(0), b.Status().Failures)
+	})
+
+	t.Run("disabled never opens", func(t *testing.T) {
+		b := NewBreaker("dst", &Config{Disabled: true, Threshold: 1})
+		b.Failure()
+		b.Failure()
+		assert.True(t, b.Allow())
+	})
+}
+
+func TestIsTransportFailure(t *testing.T) {
+	transport := []error{
+		&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+		fmt.Errorf("failed to send tx: %w", context.DeadlineExceeded),
+		fmt.Errorf("Post \"https://rpc\": %v", "dial tcp: lookup rpc: no such host"),
+		errors.New("http status 503 Service Unavailable"),
+		errors.New("429 Too Many Requests"),
+		errors.New("unexpected EOF"),
+	}
+	for _, err := range transport {
+		assert.True(t, IsTransportFailure(err), err.Error())
+	}
+	message := []error{
+		nil,
+		errors.New("execution reverted: invalid signature"),
+		errors.New("failed to prepare and simulate tx: custom program error: 0x1771"),
+		errors.New("failed to execute message: out of gas"),
+		errors.New("execution reverted: request timeout not reached"),
+		errors.New("execution reverted: invalid http status of the oracle"),
+		errors.New("failed to decode the message: eof of the payload"),
+	}
+	for _, err := range message {
+		assert.False(t, IsTransportFailure(err), err)
+	}
+}
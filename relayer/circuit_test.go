@@ -0,0 +1,33 @@
+package relayer
+
+import (
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/circuit"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitCountsTransportFailures(t *testing.T) {
+	src, dst := newCostProvider(t, "mock-1", 0, 0), newCostProvider(t, "mock-2", 0, 0)
+	rly := newTestRelayer(t, filepath.Join(t.TempDir(), "db"), src, dst)
+	rly.SetCircuitBreaker(&circuit.Config{Threshold: 1, Cooldown: time.Hour})
+	srcRuntime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	dstRuntime, err := rly.FindChainRuntime("mock-2")
+	require.NoError(t, err)
+
+	message := types.NewRouteMessage(emitMessage(1))
+	message.ToggleProcessing()
+	rly.HandleMessageFailed(message, dstRuntime, srcRuntime, "0x01", errors.New("execution reverted"))
+	assert.Equal(t, circuit.Closed, dstRuntime.Circuit.State())
+
+	message.ToggleProcessing()
+	rly.HandleMessageFailed(message, dstRuntime, srcRuntime, "", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+	assert.Equal(t, circuit.Open, dstRuntime.Circuit.State())
+}
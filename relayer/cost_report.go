@@ -0,0 +1,70 @@
+package relayer
+
+import (
+	"context"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/fee"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+// ClaimFee claims the fees of the chain and records the claimed amount read
+// from the claim transaction, the claims of the chains which do not report
+// the amount are not recorded
+func (r *Relayer) ClaimFee(ctx context.Context, nid string) error {
+	chain, err := r.FindChainRuntime(nid)
+	if err != nil {
+		return err
+	}
+	claimer, ok := chain.Provider.(provider.FeeClaimer)
+	if !ok {
+		chain.log.Warn("claimed amount is not reported by the chain, the claim is not recorded")
+		return chain.Provider.ClaimFee(ctx)
+	}
+	amount, err := claimer.ClaimFeeAmount(ctx)
+	if err != nil {
+		return err
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil
+	}
+	claim := &types.FeeClaim{
+		Chain:     nid,
+		Amount:    amount,
+		Timestamp: time.Now().Unix(),
+	}
+	if err := r.claimStore.StoreClaim(claim); err != nil {
+		r.log.Error("error occured when storing fee claim", zap.Error(err))
+	}
+	return nil
+}
+
+// CostReport summarises the delivery costs and the claimed fees between from and to,
+// route margins are included when a price feed is configured
+func (r *Relayer) CostReport(ctx context.Context, from, to time.Time) (*fee.Report, error) {
+	costs, err := r.costStore.GetCosts(from.Unix(), to.Unix())
+	if err != nil {
+		return nil, err
+	}
+	claims, err := r.claimStore.GetClaims(from.Unix(), to.Unix())
+	if err != nil {
+		return nil, err
+	}
+	report := fee.NewReport(from.Unix(), to.Unix(), costs, claims)
+	if feed := r.priceFeed(); feed != nil {
+		report.ApplyPrices(ctx, feed)
+	}
+	return report, nil
+}
+
+func (r *Relayer) priceFeed() fee.PriceFeed {
+	if r.feeManager != nil {
+		return r.feeManager.PriceFeed()
+	}
+	if r.profitability != nil {
+		return r.profitability.PriceFeed()
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package relayer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/election"
+	"github.com/icon-project/centralized-relay/relayer/metrics"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"go.uber.org/zap"
+)
+
+// SetElection enables the active/passive mode, the standby keeps its listeners
+// and store up to date while only the leader routes the messages
+func (r *Relayer) SetElection(cfg *election.Config) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	elector, err := election.New(r.log, cfg)
+	if err != nil {
+		return err
+	}
+	elector.OnChange(func(role election.Role) {
+		if role == election.Leader {
+			metrics.Leader.Set(1)
+			return
+		}
+		metrics.Leader.Set(0)
+	})
+	r.elector = elector
+	r.takeover = new(takeover)
+	return nil
+}
+
+// takeover records the destinations whose wallet had no transaction in
+// flight since the relayer took the lease
+type takeover struct {
+	mu      sync.Mutex
+	lease   context.Context
+	since   time.Time
+	settled map[string]bool
+}
+
+// txSettled returns true once the wallet of the destination has no transaction
+// in flight in the current lease. The previous leader usually shares the
+// wallet, its last transactions land first so the message received check sees
+// them and the messages are not sent twice. Past the transaction wait of the
+// chain the wallet is considered stuck and the destination is routed
+func (r *Relayer) txSettled(lease context.Context, dst *ChainRuntime) bool {
+	checker, ok := dst.Provider.(provider.PendingTxChecker)
+	if r.elector == nil || !ok {
+		return true
+	}
+	t := r.takeover
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.lease != lease {
+		t.lease, t.since, t.settled = lease, time.Now(), make(map[string]bool)
+	}
+	nid := dst.Provider.NID()
+	if t.settled[nid] {
+		return true
+	}
+	pending, err := checker.PendingTx(lease)
+	if err == nil && !pending {
+		t.settled[nid] = true
+		return true
+	}
+	if waiter, ok := dst.Provider.(provider.TxWaiter); ok && time.Since(t.since) > waiter.MaxTxWait() {
+		dst.log.Warn("transactions still in flight after the transaction wait, routing", zap.Duration("wait", waiter.MaxTxWait()), zap.Error(err))
+		t.settled[nid] = true
+		return true
+	}
+	if err != nil {
+		dst.log.Warn("failed to check the transactions in flight", zap.Error(err))
+		return false
+	}
+	dst.log.Debug("waiting for the transactions in flight of the previous leader")
+	return false
+}
+
+// StartElection campaigns for the leadership until the context is done
+func (r *Relayer) StartElection(ctx context.Context) {
+	if r.elector == nil {
+		return
+	}
+	r.elector.Run(ctx)
+}
+
+// isLeader returns true if the relayer routes the messages,
+// it always does without leader election
+func (r *Relayer) isLeader() bool {
+	return r.elector == nil || r.elector.IsLeader()
+}
+
+// leaderContext returns the context of the routing, it is cancelled when the
+// relayer loses the lease. False if the relayer is a standby
+func (r *Relayer) leaderContext(ctx context.Context) (context.Context, bool) {
+	if r.elector == nil {
+		return ctx, true
+	}
+	return r.elector.LeaseContext()
+}
+
+// ElectionStatus returns the role of the relayer, nil without leader election
+func (r *Relayer) ElectionStatus() *election.Status {
+	if r.elector == nil {
+		return nil
+	}
+	return r.elector.Status()
+}
@@ -0,0 +1,351 @@
+package election
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultBackend       = "file"
+	DefaultTTL           = 5 * time.Second
+	DefaultRenewInterval = time.Second
+)
+
+// Role of the instance in the election
+type Role string
+
+const (
+	Leader  Role = "leader"
+	Standby Role = "standby"
+)
+
+// Lease is the leadership lease kept by the backend
+type Lease struct {
+	Holder string    `json:"holder"`
+	Expiry time.Time `json:"expiry"`
+	// Term is incremented every time the lease changes hands
+	Term uint64 `json:"term"`
+}
+
+// HeldBy returns true if the lease is held by id and is not expired
+func (l *Lease) HeldBy(id string, now time.Time) bool {
+	return l != nil && l.Holder == id && now.Before(l.Expiry)
+}
+
+// Backend stores the leadership lease shared by the instances
+type Backend interface {
+	// Acquire takes or renews the lease for id, the returned lease is
+	// the current one which is held by another instance when it fails
+	Acquire(ctx context.Context, id string, ttl time.Duration) (*Lease, error)
+	// Release gives up the lease if it is held by id
+	Release(ctx context.Context, id string) error
+	Close() error
+}
+
+// BackendFactory creates a backend from the election config
+type BackendFactory func(cfg *Config) (Backend, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = make(map[string]BackendFactory)
+)
+
+// RegisterBackend makes a lease backend available by name
+func RegisterBackend(name string, factory BackendFactory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = factory
+}
+
+// Backends returns the names of the registered backends
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Config of the active/passive leader election
+type Config struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// ID identifies the instance, defaults to hostname-pid
+	ID string `yaml:"id" json:"id"`
+	// Backend is the name of the lease backend
+	Backend string `yaml:"backend" json:"backend"`
+	// File is the lease file of the file backend, on storage shared by the instances
+	File string `yaml:"file" json:"file"`
+	// Options are passed to the other backends
+	Options map[string]string `yaml:"options,omitempty" json:"options,omitempty"`
+	// TTL is the validity of the lease, the standby takes over after it expires
+	TTL time.Duration `yaml:"ttl" json:"ttl"`
+	// RenewInterval is the interval of the lease renewal
+	RenewInterval time.Duration `yaml:"renew-interval" json:"renew-interval"`
+	// TakeoverDelay is the wait of a new leader before routing, defaults to the ttl
+	TakeoverDelay time.Duration `yaml:"takeover-delay" json:"takeover-delay"`
+}
+
+func (c *Config) sanitize() error {
+	if c.ID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return err
+		}
+		c.ID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	if c.Backend == "" {
+		c.Backend = DefaultBackend
+	}
+	if c.TTL == 0 {
+		c.TTL = DefaultTTL
+	}
+	if c.RenewInterval == 0 {
+		c.RenewInterval = DefaultRenewInterval
+	}
+	if c.RenewInterval >= c.TTL {
+		return fmt.Errorf("election renew-interval %s must be shorter than the ttl %s", c.RenewInterval, c.TTL)
+	}
+	if c.TakeoverDelay == 0 {
+		c.TakeoverDelay = c.TTL
+	}
+	return nil
+}
+
+// Status is the snapshot of the election
+type Status struct {
+	ID          string    `json:"id"`
+	Backend     string    `json:"backend"`
+	Role        Role      `json:"role"`
+	Holder      string    `json:"holder"`
+	Term        uint64    `json:"term"`
+	LeaderSince time.Time `json:"leaderSince"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Elector keeps the lease while it is the leader and takes it over
+// from the backend when the leader stops renewing it
+type Elector struct {
+	log     *zap.Logger
+	cfg     *Config
+	backend Backend
+
+	mu          sync.RWMutex
+	lease       *Lease
+	leading     bool
+	leaderSince time.Time
+	// validUntil is the local expiry of the lease, it is computed from
+	// the start of the renewal so it never outlives the stored one
+	validUntil time.Time
+	err        error
+	onChange   func(Role)
+	// leaseCtx is cancelled when the instance loses the lease
+	leaseCtx    context.Context
+	leaseCancel context.CancelFunc
+	runCtx      context.Context
+}
+
+// New creates the elector with the backend named in the config
+func New(log *zap.Logger, cfg *Config) (*Elector, error) {
+	if err := cfg.sanitize(); err != nil {
+		return nil, err
+	}
+	backendsMu.RLock()
+	factory, ok := backends[cfg.Backend]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown election backend %q, available: %v", cfg.Backend, Backends())
+	}
+	backend, err := factory(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create election backend %s: %w", cfg.Backend, err)
+	}
+	return NewWithBackend(log, cfg, backend)
+}
+
+// NewWithBackend creates the elector on the given backend
+func NewWithBackend(log *zap.Logger, cfg *Config, backend Backend) (*Elector, error) {
+	if err := cfg.sanitize(); err != nil {
+		return nil, err
+	}
+	return &Elector{
+		log:     log.With(zap.String("election_id", cfg.ID), zap.String("backend", cfg.Backend)),
+		cfg:     cfg,
+		backend: backend,
+	}, nil
+}
+
+// Config returns the sanitized config
+func (e *Elector) Config() *Config {
+	return e.cfg
+}
+
+// OnChange sets the callback of the role changes
+func (e *Elector) OnChange(fn func(Role)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onChange = fn
+}
+
+// Run campaigns for the lease until the context is done,
+// the lease is released on return so the standby takes over at once
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.cfg.RenewInterval)
+	defer ticker.Stop()
+	defer e.backend.Close()
+	e.mu.Lock()
+	e.runCtx = ctx
+	e.mu.Unlock()
+	for {
+		e.renew(ctx)
+		select {
+		case <-ctx.Done():
+			e.resign()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) renew(ctx context.Context) {
+	start := time.Now()
+	acquireCtx, cancel := context.WithTimeout(ctx, e.cfg.RenewInterval)
+	lease, err := e.backend.Acquire(acquireCtx, e.cfg.ID, e.cfg.TTL)
+	cancel()
+
+	e.mu.Lock()
+	e.err = err
+	if err != nil {
+		// keep leading until the local expiry, a transient error of the
+		// backend must not stop the routing while the lease is still valid
+		e.mu.Unlock()
+		e.log.Warn("failed to renew leader lease", zap.Error(err))
+		e.check(time.Now())
+		return
+	}
+	e.lease = lease
+	leading := lease.Holder == e.cfg.ID
+	if leading {
+		e.validUntil = start.Add(e.cfg.TTL - e.cfg.RenewInterval)
+	}
+	e.mu.Unlock()
+	e.setLeading(leading, lease)
+}
+
+// check steps down once the local expiry of the lease passed
+func (e *Elector) check(now time.Time) {
+	e.mu.RLock()
+	expired := e.leading && !now.Before(e.validUntil)
+	lease := e.lease
+	e.mu.RUnlock()
+	if expired {
+		e.setLeading(false, lease)
+	}
+}
+
+func (e *Elector) setLeading(leading bool, lease *Lease) {
+	e.mu.Lock()
+	if e.leading == leading {
+		e.mu.Unlock()
+		return
+	}
+	e.leading = leading
+	role := Standby
+	if leading {
+		role = Leader
+		e.leaderSince = time.Now()
+		parent := e.runCtx
+		if parent == nil {
+			parent = context.Background()
+		}
+		e.leaseCtx, e.leaseCancel = context.WithCancel(parent)
+	} else {
+		e.leaderSince = time.Time{}
+		if e.leaseCancel != nil {
+			e.leaseCancel()
+		}
+		e.leaseCtx, e.leaseCancel = nil, nil
+	}
+	onChange := e.onChange
+	e.mu.Unlock()
+
+	fields := []zap.Field{zap.String("role", string(role))}
+	if lease != nil {
+		fields = append(fields, zap.String("holder", lease.Holder), zap.Uint64("term", lease.Term))
+	}
+	if leading {
+		fields = append(fields, zap.Duration("takeover_delay", e.cfg.TakeoverDelay))
+	}
+	e.log.Info("election role changed", fields...)
+	if onChange != nil {
+		onChange(role)
+	}
+}
+
+func (e *Elector) resign() {
+	e.mu.RLock()
+	leading := e.leading
+	e.mu.RUnlock()
+	if !leading {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.RenewInterval)
+	defer cancel()
+	if err := e.backend.Release(ctx, e.cfg.ID); err != nil {
+		e.log.Warn("failed to release leader lease", zap.Error(err))
+	}
+	e.setLeading(false, nil)
+}
+
+// IsLeader returns true while the instance holds a valid lease
+// and the takeover delay has elapsed
+func (e *Elector) IsLeader() bool {
+	now := time.Now()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leading && now.Before(e.validUntil) && now.Sub(e.leaderSince) >= e.cfg.TakeoverDelay
+}
+
+// LeaseContext returns the context of the routing while the instance is the
+// leader, it is cancelled as soon as the lease is lost so the transactions in
+// flight are not waited for nor replaced by the old leader
+func (e *Elector) LeaseContext() (context.Context, bool) {
+	if !e.IsLeader() {
+		return nil, false
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leaseCtx, e.leaseCtx != nil
+}
+
+// Status returns the snapshot of the election
+func (e *Elector) Status() *Status {
+	leader := e.IsLeader()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	status := &Status{
+		ID:          e.cfg.ID,
+		Backend:     e.cfg.Backend,
+		Role:        Standby,
+		LeaderSince: e.leaderSince,
+	}
+	if leader {
+		status.Role = Leader
+	}
+	if e.lease != nil {
+		status.Holder = e.lease.Holder
+		status.Term = e.lease.Term
+	}
+	if e.err != nil {
+		status.Error = e.err.Error()
+	}
+	return status
+}
@@ -0,0 +1,169 @@
+package election
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestFileBackend(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewFileBackend(filepath.Join(t.TempDir(), "leader.json"))
+	assert.NoError(t, err)
+	defer backend.Close()
+	ttl := 100 * time.Millisecond
+
+	lease, err := backend.Acquire(ctx, "a", ttl)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", lease.Holder)
+	assert.Equal(t, uint64(1), lease.Term)
+
+	t.Run("held by another instance", func(t *testing.T) {
+		lease, err := backend.Acquire(ctx, "b", ttl)
+		assert.NoError(t, err)
+		assert.Equal(t, "a", lease.Holder)
+	})
+
+	t.Run("renew keeps the term", func(t *testing.T) {
+		lease, err := backend.Acquire(ctx, "a", ttl)
+		assert.NoError(t, err)
+		assert.Equal(t, "a", lease.Holder)
+		assert.Equal(t, uint64(1), lease.Term)
+	})
+
+	t.Run("takeover after expiry", func(t *testing.T) {
+		time.Sleep(ttl)
+		lease, err := backend.Acquire(ctx, "b", ttl)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", lease.Holder)
+		assert.Equal(t, uint64(2), lease.Term)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		assert.NoError(t, backend.Release(ctx, "a"))
+		lease, err := backend.Acquire(ctx, "a", ttl)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", lease.Holder)
+
+		assert.NoError(t, backend.Release(ctx, "b"))
+		lease, err = backend.Acquire(ctx, "a", ttl)
+		assert.NoError(t, err)
+		assert.Equal(t, "a", lease.Holder)
+		assert.Equal(t, uint64(3), lease.Term)
+	})
+}
+
+func TestElectorFailover(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "leader.json")
+	newElector := func(id string) *Elector {
+		elector, err := New(zap.NewNop(), &Config{
+			Enabled:       true,
+			ID:            id,
+			File:          file,
+			TTL:           200 * time.Millisecond,
+			RenewInterval: 20 * time.Millisecond,
+			TakeoverDelay: 50 * time.Millisecond,
+		})
+		assert.NoError(t, err)
+		return elector
+	}
+	a, b := newElector("a"), newElector("b")
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	doneA := make(chan struct{})
+	go func() {
+		a.Run(ctxA)
+		close(doneA)
+	}()
+	assert.Eventually(t, a.IsLeader, time.Second, 10*time.Millisecond)
+
+	ctxB, cancelB := context.WithCancel(context.Background())
+	doneB := make(chan struct{})
+	go func() {
+		b.Run(ctxB)
+		close(doneB)
+	}()
+	t.Cleanup(func() {
+		cancelB()
+		<-doneB
+	})
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, b.IsLeader())
+	assert.Equal(t, Standby, b.Status().Role)
+	assert.Equal(t, "a", b.Status().Holder)
+
+	// the leader releases the lease on shutdown
+	cancelA()
+	<-doneA
+	assert.False(t, a.IsLeader())
+	assert.Eventually(t, b.IsLeader, time.Second, 10*time.Millisecond)
+	assert.Equal(t, Leader, b.Status().Role)
+	assert.Equal(t, uint64(2), b.Status().Term)
+}
+
+func TestUnknownBackend(t *testing.T) {
+	_, err := New(zap.NewNop(), &Config{Backend: "etcd"})
+	assert.ErrorContains(t, err, "unknown election backend")
+}
+
+// holderBackend grants the lease to the holder it is set to
+type holderBackend struct {
+	mu     sync.Mutex
+	holder string
+}
+
+func (b *holderBackend) setHolder(holder string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.holder = holder
+}
+
+func (b *holderBackend) Acquire(_ context.Context, _ string, ttl time.Duration) (*Lease, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &Lease{Holder: b.holder, Expiry: time.Now().Add(ttl), Term: 1}, nil
+}
+
+func (b *holderBackend) Release(context.Context, string) error { return nil }
+
+func (b *holderBackend) Close() error { return nil }
+
+func TestLeaseContext(t *testing.T) {
+	backend := &holderBackend{holder: "a"}
+	elector, err := NewWithBackend(zap.NewNop(), &Config{
+		Enabled:       true,
+		ID:            "a",
+		TTL:           200 * time.Millisecond,
+		RenewInterval: 20 * time.Millisecond,
+		TakeoverDelay: 20 * time.Millisecond,
+	}, backend)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		elector.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	assert.Eventually(t, elector.IsLeader, time.Second, 10*time.Millisecond)
+	leaseCtx, ok := elector.LeaseContext()
+	assert.True(t, ok)
+	assert.NoError(t, leaseCtx.Err())
+
+	// the routing is cancelled as soon as the lease is lost
+	backend.setHolder("b")
+	assert.Eventually(t, func() bool {
+		return leaseCtx.Err() != nil
+	}, time.Second, 10*time.Millisecond)
+	_, ok = elector.LeaseContext()
+	assert.False(t, ok)
+}
@@ -0,0 +1,129 @@
+package election
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+// lockRetryDelay is the interval of the attempts to lock the lease file
+const lockRetryDelay = 50 * time.Millisecond
+
+func init() {
+	RegisterBackend(DefaultBackend, func(cfg *Config) (Backend, error) {
+		return NewFileBackend(cfg.File)
+	})
+}
+
+// FileBackend keeps the lease in a file on storage shared by the instances,
+// the updates are serialised by a lock file next to it
+type FileBackend struct {
+	path string
+	lock *flock.Flock
+}
+
+// NewFileBackend creates the backend on the lease file path
+func NewFileBackend(path string) (*FileBackend, error) {
+	if path == "" {
+		return nil, fmt.Errorf("election file is required by the file backend")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return &FileBackend{
+		path: path,
+		lock: flock.New(path + ".lock"),
+	}, nil
+}
+
+func (b *FileBackend) Acquire(ctx context.Context, id string, ttl time.Duration) (*Lease, error) {
+	var lease *Lease
+	err := b.withLock(ctx, func() error {
+		current, err := b.read()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if current.Holder != id && current.HeldBy(current.Holder, now) {
+			lease = current
+			return nil
+		}
+		next := &Lease{Holder: id, Expiry: now.Add(ttl), Term: current.Term}
+		if current.Holder != id || !now.Before(current.Expiry) {
+			next.Term++
+		}
+		if err := b.write(next); err != nil {
+			return err
+		}
+		lease = next
+		return nil
+	})
+	return lease, err
+}
+
+func (b *FileBackend) Release(ctx context.Context, id string) error {
+	return b.withLock(ctx, func() error {
+		current, err := b.read()
+		if err != nil {
+			return err
+		}
+		if current.Holder != id {
+			return nil
+		}
+		current.Expiry = time.Now()
+		return b.write(current)
+	})
+}
+
+func (b *FileBackend) Close() error {
+	return b.lock.Close()
+}
+
+func (b *FileBackend) withLock(ctx context.Context, fn func() error) error {
+	locked, err := b.lock.TryLockContext(ctx, lockRetryDelay)
+	if err != nil {
+		return fmt.Errorf("failed to lock lease file: %w", err)
+	}
+	if !locked {
+		return fmt.Errorf("failed to lock lease file %s", b.path)
+	}
+	defer b.lock.Unlock()
+	return fn()
+}
+
+func (b *FileBackend) read() (*Lease, error) {
+	data, err := os.ReadFile(b.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return new(Lease), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	lease := new(Lease)
+	if len(data) == 0 {
+		return lease, nil
+	}
+	if err := json.Unmarshal(data, lease); err != nil {
+		return nil, fmt.Errorf("invalid lease file %s: %w", b.path, err)
+	}
+	return lease, nil
+}
+
+// write replaces the lease file atomically
+func (b *FileBackend) write(lease *Lease) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, b.path)
+}
@@ -0,0 +1,65 @@
+package relayer
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/election"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pendingProvider is a mock chain whose wallet has transactions in flight
+// while pending is set
+type pendingProvider struct {
+	*costProvider
+	pending atomic.Bool
+	wait    time.Duration
+}
+
+func (p *pendingProvider) PendingTx(context.Context) (bool, error) {
+	return p.pending.Load(), nil
+}
+
+func (p *pendingProvider) MaxTxWait() time.Duration {
+	return p.wait
+}
+
+func TestElectionTakeover(t *testing.T) {
+	dst := &pendingProvider{costProvider: newCostProvider(t, "mock-2", 0, 0), wait: time.Minute}
+	rly := newTestRelayer(t, filepath.Join(t.TempDir(), "db"), newCostProvider(t, "mock-1", 0, 0), dst)
+	require.NoError(t, rly.SetElection(&election.Config{Enabled: true, File: filepath.Join(t.TempDir(), "leader.json")}))
+	assert.Equal(t, election.DefaultTTL, rly.elector.Config().TakeoverDelay)
+	dstRuntime, err := rly.FindChainRuntime("mock-2")
+	require.NoError(t, err)
+
+	t.Run("waits for the transactions in flight", func(t *testing.T) {
+		lease, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		dst.pending.Store(true)
+		assert.False(t, rly.txSettled(lease, dstRuntime))
+		dst.pending.Store(false)
+		assert.True(t, rly.txSettled(lease, dstRuntime))
+		// the transactions sent afterwards are the ones of the leader
+		dst.pending.Store(true)
+		assert.True(t, rly.txSettled(lease, dstRuntime))
+	})
+
+	t.Run("checked again on a new lease", func(t *testing.T) {
+		lease, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		assert.False(t, rly.txSettled(lease, dstRuntime))
+	})
+
+	t.Run("routed after the transaction wait", func(t *testing.T) {
+		lease, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		dst.wait = 10 * time.Millisecond
+		assert.False(t, rly.txSettled(lease, dstRuntime))
+		time.Sleep(20 * time.Millisecond)
+		assert.True(t, rly.txSettled(lease, dstRuntime))
+	})
+}
@@ -0,0 +1,73 @@
+package fee
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/icon-project/centralized-relay/relayer/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// amountPattern matches the amounts written as decimal strings
+const amountPattern = `^[0-9]+$`
+
+// Amount is a fee in the smallest unit of the coin. The fees of the 18
+// decimals coins overflow 64 bits, so it is read from an integer or a decimal
+// string of any size
+type Amount struct {
+	big.Int
+}
+
+// NewAmount returns the amount of the integer
+func NewAmount(x int64) *Amount {
+	a := new(Amount)
+	a.SetInt64(x)
+	return a
+}
+
+func (a *Amount) parse(s string) error {
+	if _, ok := a.SetString(strings.TrimSpace(s), 10); !ok || a.Sign() < 0 {
+		return fmt.Errorf("invalid fee amount %q", s)
+	}
+	return nil
+}
+
+// UnmarshalYAML accepts an integer or a decimal string
+func (a *Amount) UnmarshalYAML(n *yaml.Node) error {
+	return a.parse(n.Value)
+}
+
+// MarshalYAML writes the amount as a decimal string
+func (a Amount) MarshalYAML() (interface{}, error) {
+	return a.String(), nil
+}
+
+// UnmarshalJSON accepts a number or a decimal string
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var s string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return a.parse(s)
+	}
+	return a.parse(string(data))
+}
+
+// MarshalJSON writes the amount as a decimal string
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// JSONSchema accepts the integers and the decimal strings, yaml reads the
+// integers overflowing 64 bits as numbers
+func (Amount) JSONSchema() *jsonschema.Schema {
+	zero := 0.0
+	return &jsonschema.Schema{
+		Type:    jsonschema.Types{jsonschema.TypeString, jsonschema.TypeInteger, jsonschema.TypeNumber},
+		Pattern: amountPattern,
+		Minimum: &zero,
+	}
+}
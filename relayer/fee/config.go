@@ -0,0 +1,85 @@
+package fee
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultInterval   = time.Hour
+	DefaultSampleSize = 20
+)
+
+// Config is the fee manager configuration
+type Config struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// DryRun only reports the proposals without submitting them
+	DryRun     bool          `yaml:"dry-run" json:"dry-run"`
+	Interval   time.Duration `yaml:"interval" json:"interval"`
+	PriceFeed  *PriceConfig  `yaml:"price-feed" json:"price-feed"`
+	SampleSize uint          `yaml:"sample-size" json:"sample-size"`
+	// Margin is the percentage added on top of the delivery cost
+	Margin float64 `yaml:"margin" json:"margin"`
+	// Hysteresis is the minimum percentage change required to update the fee
+	Hysteresis float64        `yaml:"hysteresis" json:"hysteresis"`
+	Routes     []*RouteBounds `yaml:"routes" json:"routes"`
+}
+
+// RouteBounds are the limits of the fees for a src -> dst route
+// unset or zero value means there is no limit
+type RouteBounds struct {
+	Src       string  `yaml:"src" json:"src"`
+	Dst       string  `yaml:"dst" json:"dst"`
+	MinMsgFee *Amount `yaml:"min-msg-fee,omitempty" json:"min-msg-fee,omitempty"`
+	MaxMsgFee *Amount `yaml:"max-msg-fee,omitempty" json:"max-msg-fee,omitempty"`
+	MinResFee *Amount `yaml:"min-res-fee,omitempty" json:"min-res-fee,omitempty"`
+	MaxResFee *Amount `yaml:"max-res-fee,omitempty" json:"max-res-fee,omitempty"`
+}
+
+func (c *Config) Validate() error {
+	if c.PriceFeed == nil {
+		return fmt.Errorf("fee manager: price-feed is required")
+	}
+	if c.Margin < 0 || c.Hysteresis < 0 {
+		return fmt.Errorf("fee manager: margin and hysteresis cannot be negative")
+	}
+	for _, r := range c.Routes {
+		if r.Src == "" || r.Dst == "" {
+			return fmt.Errorf("fee manager: route src and dst are required")
+		}
+		if exceeds(r.MinMsgFee, r.MaxMsgFee) {
+			return fmt.Errorf("fee manager: min-msg-fee is greater than max-msg-fee for %s -> %s", r.Src, r.Dst)
+		}
+		if exceeds(r.MinResFee, r.MaxResFee) {
+			return fmt.Errorf("fee manager: min-res-fee is greater than max-res-fee for %s -> %s", r.Src, r.Dst)
+		}
+	}
+	return c.PriceFeed.Validate()
+}
+
+// exceeds reports whether the min bound is greater than the set max bound
+func exceeds(min, max *Amount) bool {
+	if min == nil || max == nil || max.Sign() == 0 {
+		return false
+	}
+	return min.Cmp(&max.Int) > 0
+}
+
+func (c *Config) sanitize() {
+	if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	}
+	if c.SampleSize == 0 {
+		c.SampleSize = DefaultSampleSize
+	}
+}
+
+// GetRouteBounds returns the bounds of the route, nil if not configured
+func (c *Config) GetRouteBounds(src, dst string) *RouteBounds {
+	for _, r := range c.Routes {
+		if r.Src == src && r.Dst == dst {
+			return r
+		}
+	}
+	return nil
+}
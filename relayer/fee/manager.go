@@ -0,0 +1,200 @@
+package fee
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// CostReader reads the recorded delivery costs of a route
+type CostReader interface {
+	GetRecentCosts(src, dst string, limit uint) ([]*types.DeliveryCost, error)
+}
+
+// Proposal is the fee update suggested for the connection on src for the dst network
+type Proposal struct {
+	Src        string   `json:"src"`
+	Dst        string   `json:"dst"`
+	CurrentFee *big.Int `json:"currentFee"`
+	MsgFee     *big.Int `json:"msgFee"`
+	ResFee     *big.Int `json:"resFee"`
+	MsgSamples int      `json:"msgSamples"`
+	ResSamples int      `json:"resSamples"`
+	Applied    bool     `json:"applied"`
+	Reason     string   `json:"reason,omitempty"`
+}
+
+// Total returns the sum of the proposed message and response fee
+func (p *Proposal) Total() *big.Int {
+	return new(big.Int).Add(p.MsgFee, p.ResFee)
+}
+
+// Skipped returns true if the proposal should not be applied
+func (p *Proposal) Skipped() bool {
+	return p.Reason != ""
+}
+
+type Manager struct {
+	cfg   *Config
+	feed  PriceFeed
+	costs CostReader
+}
+
+func NewManager(cfg *Config, costs CostReader) (*Manager, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.sanitize()
+	feed, err := NewPriceFeed(cfg.PriceFeed)
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{cfg: cfg, feed: feed, costs: costs}, nil
+}
+
+func (m *Manager) Config() *Config {
+	return m.cfg
+}
+
+func (m *Manager) PriceFeed() PriceFeed {
+	return m.feed
+}
+
+// Propose computes the fee of the connection on src for the dst network.
+//
+// Message fee covers the delivery src -> dst paid in dst coin, so it is converted
+// to the src coin using the price feed. Response fee covers the delivery dst -> src
+// which is already paid in src coin.
+func (m *Manager) Propose(ctx context.Context, src, dst string, currentFee *big.Int) (*Proposal, error) {
+	proposal := &Proposal{
+		Src:        src,
+		Dst:        dst,
+		CurrentFee: currentFee,
+		MsgFee:     new(big.Int),
+		ResFee:     new(big.Int),
+	}
+
+	msgCosts, err := m.costs.GetRecentCosts(src, dst, m.cfg.SampleSize)
+	if err != nil {
+		return nil, err
+	}
+	resCosts, err := m.costs.GetRecentCosts(dst, src, m.cfg.SampleSize)
+	if err != nil {
+		return nil, err
+	}
+	proposal.MsgSamples = len(msgCosts)
+	proposal.ResSamples = len(resCosts)
+
+	if len(msgCosts) == 0 {
+		proposal.Reason = "no delivery cost recorded for the route"
+		return proposal, nil
+	}
+
+	srcPrice, err := m.feed.Price(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	dstPrice, err := m.feed.Price(ctx, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal.MsgFee = addMargin(Convert(AverageCost(msgCosts, events.EmitMessage), dstPrice, srcPrice), m.cfg.Margin)
+	proposal.ResFee = addMargin(AverageCost(resCosts, events.EmitMessage), m.cfg.Margin)
+
+	if bounds := m.cfg.GetRouteBounds(src, dst); bounds != nil {
+		proposal.MsgFee = clamp(proposal.MsgFee, bounds.MinMsgFee, bounds.MaxMsgFee)
+		proposal.ResFee = clamp(proposal.ResFee, bounds.MinResFee, bounds.MaxResFee)
+	}
+
+	if currentFee != nil && currentFee.Sign() > 0 {
+		change := ChangePercent(currentFee, proposal.Total())
+		if change < m.cfg.Hysteresis {
+			proposal.Reason = fmt.Sprintf("change %.2f%% is within hysteresis %.2f%%", change, m.cfg.Hysteresis)
+		}
+	}
+	return proposal, nil
+}
+
+// AverageCost returns the average fee of the successful deliveries of the
+// event type with known fee, the fees of the connection only pay the delivery
+// of the messages, not the execution of the calls
+func AverageCost(costs []*types.DeliveryCost, eventType string) *big.Int {
+	sum := new(big.Int)
+	var count int64
+	for _, c := range costs {
+		if c.TxFee == nil || c.Failed || c.EventType != eventType {
+			continue
+		}
+		sum.Add(sum, c.TxFee)
+		count++
+	}
+	if count == 0 {
+		return sum
+	}
+	return sum.Quo(sum, big.NewInt(count))
+}
+
+// Convert converts the amount denominated in the from coin to the to coin
+func Convert(amount *big.Int, from, to *Price) *big.Int {
+	value := new(big.Rat).SetInt(amount)
+	value.Mul(value, decimalRat(from.Value))
+	value.Quo(value, decimalRat(to.Value))
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(to.Decimals-from.Decimals))), nil))
+	if to.Decimals >= from.Decimals {
+		value.Mul(value, scale)
+	} else {
+		value.Quo(value, scale)
+	}
+	return ratToInt(value)
+}
+
+// ChangePercent returns the absolute change from current to proposed in percentage
+func ChangePercent(current, proposed *big.Int) float64 {
+	diff := new(big.Float).SetInt(new(big.Int).Sub(proposed, current))
+	diff.Quo(diff, new(big.Float).SetInt(current))
+	change, _ := diff.Float64()
+	return math.Abs(change) * 100
+}
+
+func addMargin(amount *big.Int, margin float64) *big.Int {
+	value := new(big.Rat).SetInt(amount)
+	value.Mul(value, new(big.Rat).Add(big.NewRat(1, 1), new(big.Rat).Quo(decimalRat(margin), big.NewRat(100, 1))))
+	return ratToInt(value)
+}
+
+// decimalRat converts the float to rational using its shortest decimal form
+// so that configured values like 1.1 are exact
+func decimalRat(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+	return r
+}
+
+func ratToInt(r *big.Rat) *big.Int {
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func clamp(amount *big.Int, min, max *Amount) *big.Int {
+	if min != nil && min.Sign() > 0 && amount.Cmp(&min.Int) < 0 {
+		return new(big.Int).Set(&min.Int)
+	}
+	if max != nil && max.Sign() > 0 && amount.Cmp(&max.Int) > 0 {
+		return new(big.Int).Set(&max.Int)
+	}
+	return amount
+}
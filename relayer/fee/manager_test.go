@@ -0,0 +1,133 @@
+package fee
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/jsonschema"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type mockCosts map[string][]*types.DeliveryCost
+
+func (m mockCosts) GetRecentCosts(src, dst string, limit uint) ([]*types.DeliveryCost, error) {
+	return m[src+"-"+dst], nil
+}
+
+func newTestManager(cfg *Config, costs mockCosts) *Manager {
+	cfg.sanitize()
+	return &Manager{
+		cfg: cfg,
+		feed: StaticPriceFeed{
+			"src": {Value: 2, Decimals: 18},
+			"dst": {Value: 1, Decimals: 6},
+		},
+		costs: costs,
+	}
+}
+
+func TestManagerPropose(t *testing.T) {
+	ctx := context.Background()
+	costs := mockCosts{
+		"src-dst": {
+			{EventType: events.EmitMessage, TxFee: big.NewInt(1_000_000)},
+			{EventType: events.EmitMessage, TxFee: big.NewInt(3_000_000)},
+			// the executions of the calls are not paid by the connection fee
+			{EventType: events.CallMessage, TxFee: big.NewInt(90_000_000)},
+		},
+		"dst-src": {
+			{EventType: events.EmitMessage, TxFee: big.NewInt(500)},
+		},
+	}
+
+	t.Run("convert and add margin", func(t *testing.T) {
+		m := newTestManager(&Config{Margin: 10}, costs)
+		p, err := m.Propose(ctx, "src", "dst", nil)
+		assert.NoError(t, err)
+		assert.False(t, p.Skipped())
+		// 2 dst coin at price 1 is 1 src coin at price 2, plus 10%
+		assert.Equal(t, big.NewInt(1_100_000_000_000_000_000), p.MsgFee)
+		assert.Equal(t, big.NewInt(550), p.ResFee)
+		assert.Equal(t, 3, p.MsgSamples)
+		assert.Equal(t, 1, p.ResSamples)
+	})
+
+	t.Run("clamp to bounds", func(t *testing.T) {
+		m := newTestManager(&Config{
+			Routes: []*RouteBounds{{Src: "src", Dst: "dst", MaxMsgFee: NewAmount(1000), MinResFee: NewAmount(700)}},
+		}, costs)
+		p, err := m.Propose(ctx, "src", "dst", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(1000), p.MsgFee)
+		assert.Equal(t, big.NewInt(700), p.ResFee)
+	})
+
+	t.Run("within hysteresis", func(t *testing.T) {
+		m := newTestManager(&Config{Hysteresis: 5}, costs)
+		current, _ := new(big.Int).SetString("1020000000000000000", 10)
+		p, err := m.Propose(ctx, "src", "dst", current)
+		assert.NoError(t, err)
+		assert.True(t, p.Skipped())
+	})
+
+	t.Run("no samples", func(t *testing.T) {
+		m := newTestManager(&Config{}, costs)
+		p, err := m.Propose(ctx, "dst", "other", nil)
+		assert.NoError(t, err)
+		assert.True(t, p.Skipped())
+	})
+}
+
+func TestRouteBoundsAmounts(t *testing.T) {
+	// the example of the docs overflows 64 bits
+	data := []byte(`
+price-feed:
+  type: static
+  file: prices.yaml
+routes:
+  - src: 0x2.icon
+    dst: 0xa869.fuji
+    min-msg-fee: 1000000000000000
+    max-msg-fee: 50000000000000000000
+    max-res-fee: "60000000000000000000"
+`)
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(data, &node))
+	assert.Empty(t, jsonschema.Reflect(Config{}).Validate(&node))
+
+	var cfg Config
+	require.NoError(t, node.Decode(&cfg))
+	require.NoError(t, cfg.Validate())
+	bounds := cfg.GetRouteBounds("0x2.icon", "0xa869.fuji")
+	require.NotNil(t, bounds)
+	assert.Equal(t, "1000000000000000", bounds.MinMsgFee.String())
+	assert.Equal(t, "50000000000000000000", bounds.MaxMsgFee.String())
+	assert.Equal(t, "60000000000000000000", bounds.MaxResFee.String())
+	assert.Nil(t, bounds.MinResFee)
+
+	// the amounts are written as strings and read back from json
+	out, err := json.Marshal(bounds)
+	require.NoError(t, err)
+	var decoded RouteBounds
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, bounds, &decoded)
+	require.NoError(t, json.Unmarshal([]byte(`{"max-msg-fee":50000000000000000000}`), &decoded))
+	assert.Equal(t, "50000000000000000000", decoded.MaxMsgFee.String())
+
+	fee, _ := new(big.Int).SetString("70000000000000000000", 10)
+	assert.Equal(t, "50000000000000000000", clamp(fee, bounds.MinMsgFee, bounds.MaxMsgFee).String())
+
+	var invalid Amount
+	assert.Error(t, yaml.Unmarshal([]byte(`-1`), &invalid))
+	assert.Error(t, yaml.Unmarshal([]byte(`1.5`), &invalid))
+	assert.NotEmpty(t, jsonschema.Reflect(RouteBounds{}).Validate(&yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: "max-msg-fee"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "fifty"},
+	}}))
+}
@@ -0,0 +1,81 @@
+package fee
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+const PriceFeedStatic = "static"
+
+// Price of the native coin of a chain in a common quote currency (e.g. USD)
+type Price struct {
+	Value    float64 `yaml:"price" json:"price"`
+	Decimals int     `yaml:"decimals" json:"decimals"`
+}
+
+// PriceFeed provides the native coin prices of the chains by nid
+type PriceFeed interface {
+	Price(ctx context.Context, nid string) (*Price, error)
+}
+
+type PriceConfig struct {
+	Type string `yaml:"type" json:"type"`
+	File string `yaml:"file" json:"file"`
+}
+
+func (c *PriceConfig) Validate() error {
+	switch c.Type {
+	case PriceFeedStatic:
+		if c.File == "" {
+			return fmt.Errorf("fee manager: static price feed requires a file")
+		}
+	default:
+		return fmt.Errorf("fee manager: unknown price feed type %q", c.Type)
+	}
+	return nil
+}
+
+// NewPriceFeed creates the price feed described by the config
+func NewPriceFeed(c *PriceConfig) (PriceFeed, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	switch c.Type {
+	case PriceFeedStatic:
+		return NewStaticPriceFeed(c.File)
+	}
+	return nil, fmt.Errorf("fee manager: unknown price feed type %q", c.Type)
+}
+
+// StaticPriceFeed serves prices from a table file, useful for offline usage
+//
+//	0x2.icon:
+//	  price: 0.16
+//	  decimals: 18
+type StaticPriceFeed map[string]*Price
+
+func NewStaticPriceFeed(file string) (StaticPriceFeed, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading price file: %w", err)
+	}
+	feed := make(StaticPriceFeed)
+	if err := yaml.Unmarshal(data, &feed); err != nil {
+		return nil, fmt.Errorf("error parsing price file: %w", err)
+	}
+	return feed, nil
+}
+
+func (s StaticPriceFeed) Price(_ context.Context, nid string) (*Price, error) {
+	price, ok := s[nid]
+	if !ok || price == nil {
+		return nil, fmt.Errorf("price not found for %s", nid)
+	}
+	if price.Value <= 0 {
+		return nil, fmt.Errorf("invalid price for %s: %f", nid, price.Value)
+	}
+	return price, nil
+}
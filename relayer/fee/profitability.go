@@ -0,0 +1,185 @@
+package fee
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+const (
+	// ActionDeliver routes the message regardless of the cost
+	ActionDeliver = "deliver"
+	// ActionDefer retries the check after the defer interval
+	ActionDefer = "defer"
+	// ActionPark moves the message out of the routing queue
+	ActionPark = "park"
+
+	DefaultDeferInterval = 10 * time.Minute
+	// DefaultMaxDefers parks the messages still unprofitable after an hour
+	// with the default interval
+	DefaultMaxDefers = 6
+)
+
+// ProfitabilityConfig is the configuration of the pre-route profitability check
+type ProfitabilityConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Action applied to the unprofitable messages
+	Action        string        `yaml:"action" json:"action"`
+	DeferInterval time.Duration `yaml:"defer-interval" json:"defer-interval"`
+	// MaxDefers is the number of checks of a deferred message before it is parked
+	MaxDefers uint8 `yaml:"max-defers" json:"max-defers"`
+	// MinMargin is the percentage the fee paid must exceed the estimated cost
+	MinMargin float64        `yaml:"min-margin" json:"min-margin"`
+	PriceFeed *PriceConfig   `yaml:"price-feed" json:"price-feed"`
+	Routes    []*RoutePolicy `yaml:"routes" json:"routes"`
+}
+
+// RoutePolicy overrides the action and margin for a src -> dst route
+type RoutePolicy struct {
+	Src       string  `yaml:"src" json:"src"`
+	Dst       string  `yaml:"dst" json:"dst"`
+	Action    string  `yaml:"action" json:"action"`
+	MinMargin float64 `yaml:"min-margin" json:"min-margin"`
+}
+
+func (c *ProfitabilityConfig) Validate() error {
+	if c.PriceFeed == nil {
+		return fmt.Errorf("profitability: price-feed is required")
+	}
+	if c.MinMargin < 0 {
+		return fmt.Errorf("profitability: min-margin cannot be negative")
+	}
+	if err := validateAction(c.Action); err != nil {
+		return err
+	}
+	for _, r := range c.Routes {
+		if r.Src == "" || r.Dst == "" {
+			return fmt.Errorf("profitability: route src and dst are required")
+		}
+		if r.MinMargin < 0 {
+			return fmt.Errorf("profitability: min-margin cannot be negative for %s -> %s", r.Src, r.Dst)
+		}
+		if err := validateAction(r.Action); err != nil {
+			return err
+		}
+	}
+	return c.PriceFeed.Validate()
+}
+
+func (c *ProfitabilityConfig) sanitize() {
+	if c.Action == "" {
+		c.Action = ActionDefer
+	}
+	if c.DeferInterval == 0 {
+		c.DeferInterval = DefaultDeferInterval
+	}
+	if c.MaxDefers == 0 {
+		c.MaxDefers = DefaultMaxDefers
+	}
+	for _, r := range c.Routes {
+		if r.Action == "" {
+			r.Action = c.Action
+		}
+	}
+}
+
+// GetRoutePolicy returns the policy of the route falling back to the defaults
+func (c *ProfitabilityConfig) GetRoutePolicy(src, dst string) *RoutePolicy {
+	for _, r := range c.Routes {
+		if r.Src == src && r.Dst == dst {
+			return r
+		}
+	}
+	return &RoutePolicy{Src: src, Dst: dst, Action: c.Action, MinMargin: c.MinMargin}
+}
+
+func validateAction(action string) error {
+	switch action {
+	case "", ActionDeliver, ActionDefer, ActionPark:
+		return nil
+	}
+	return fmt.Errorf("profitability: unknown action %q", action)
+}
+
+// Decision is the outcome of the profitability check of a message
+type Decision struct {
+	Src       string   `json:"src"`
+	Dst       string   `json:"dst"`
+	Sn        *big.Int `json:"sn"`
+	EventType string   `json:"eventType"`
+	// FeePaid is denominated in the src coin
+	FeePaid *big.Int `json:"feePaid"`
+	// Cost is the estimated delivery cost converted to the src coin
+	Cost      *big.Int `json:"cost"`
+	Action    string   `json:"action"`
+	Reason    string   `json:"reason,omitempty"`
+	Timestamp int64    `json:"timestamp"`
+}
+
+// Profitable returns true if the message should be delivered
+func (d *Decision) Profitable() bool {
+	return d.Action == ActionDeliver
+}
+
+type ProfitabilityChecker struct {
+	cfg  *ProfitabilityConfig
+	feed PriceFeed
+}
+
+func NewProfitabilityChecker(cfg *ProfitabilityConfig) (*ProfitabilityChecker, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.sanitize()
+	feed, err := NewPriceFeed(cfg.PriceFeed)
+	if err != nil {
+		return nil, err
+	}
+	return &ProfitabilityChecker{cfg: cfg, feed: feed}, nil
+}
+
+func (c *ProfitabilityChecker) Config() *ProfitabilityConfig {
+	return c.cfg
+}
+
+func (c *ProfitabilityChecker) PriceFeed() PriceFeed {
+	return c.feed
+}
+
+// Check compares the fee paid on the src chain with the estimated cost
+// of the delivery on the dst chain, cost is denominated in the dst coin
+func (c *ProfitabilityChecker) Check(ctx context.Context, msg *types.Message, feePaid, cost *big.Int) (*Decision, error) {
+	decision := &Decision{
+		Src:       msg.Src,
+		Dst:       msg.Dst,
+		Sn:        msg.Sn,
+		EventType: msg.EventType,
+		FeePaid:   feePaid,
+		Action:    ActionDeliver,
+		Timestamp: time.Now().Unix(),
+	}
+	if decision.FeePaid == nil {
+		decision.FeePaid = new(big.Int)
+	}
+
+	srcPrice, err := c.feed.Price(ctx, msg.Src)
+	if err != nil {
+		return nil, err
+	}
+	dstPrice, err := c.feed.Price(ctx, msg.Dst)
+	if err != nil {
+		return nil, err
+	}
+	decision.Cost = Convert(cost, dstPrice, srcPrice)
+
+	policy := c.cfg.GetRoutePolicy(msg.Src, msg.Dst)
+	required := addMargin(decision.Cost, policy.MinMargin)
+	if decision.FeePaid.Cmp(required) < 0 {
+		decision.Action = policy.Action
+		decision.Reason = fmt.Sprintf("fee paid %s is below the required %s", decision.FeePaid, required)
+	}
+	return decision, nil
+}
@@ -0,0 +1,63 @@
+package fee
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestChecker(cfg *ProfitabilityConfig) *ProfitabilityChecker {
+	cfg.sanitize()
+	return &ProfitabilityChecker{
+		cfg: cfg,
+		feed: StaticPriceFeed{
+			"src": {Value: 2, Decimals: 18},
+			"dst": {Value: 1, Decimals: 6},
+		},
+	}
+}
+
+func TestProfitabilityCheck(t *testing.T) {
+	ctx := context.Background()
+	msg := &types.Message{Src: "src", Dst: "dst", Sn: big.NewInt(1)}
+	// 2 dst coin at price 1 is 1 src coin at price 2
+	cost := big.NewInt(2_000_000)
+	srcCoin := big.NewInt(1_000_000_000_000_000_000)
+
+	t.Run("fee covers the cost", func(t *testing.T) {
+		c := newTestChecker(&ProfitabilityConfig{})
+		d, err := c.Check(ctx, msg, srcCoin, cost)
+		assert.NoError(t, err)
+		assert.True(t, d.Profitable())
+		assert.Equal(t, srcCoin, d.Cost)
+		assert.Empty(t, d.Reason)
+	})
+
+	t.Run("fee below the margin uses default action", func(t *testing.T) {
+		c := newTestChecker(&ProfitabilityConfig{MinMargin: 10})
+		d, err := c.Check(ctx, msg, srcCoin, cost)
+		assert.NoError(t, err)
+		assert.False(t, d.Profitable())
+		assert.Equal(t, ActionDefer, d.Action)
+		assert.NotEmpty(t, d.Reason)
+	})
+
+	t.Run("route policy overrides the default", func(t *testing.T) {
+		c := newTestChecker(&ProfitabilityConfig{
+			Action: ActionDefer,
+			Routes: []*RoutePolicy{{Src: "src", Dst: "dst", Action: ActionPark}},
+		})
+		d, err := c.Check(ctx, msg, nil, cost)
+		assert.NoError(t, err)
+		assert.Equal(t, ActionPark, d.Action)
+		assert.Equal(t, big.NewInt(0), d.FeePaid)
+	})
+
+	t.Run("unknown action is rejected", func(t *testing.T) {
+		cfg := &ProfitabilityConfig{Action: "drop", PriceFeed: &PriceConfig{Type: PriceFeedStatic, File: "prices.yaml"}}
+		assert.Error(t, cfg.Validate())
+	})
+}
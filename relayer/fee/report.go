@@ -0,0 +1,177 @@
+package fee
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// RouteReport summarises the deliveries of an event type on a src -> dst route
+type RouteReport struct {
+	Src        string `json:"src"`
+	Dst        string `json:"dst"`
+	EventType  string `json:"eventType"`
+	Deliveries int    `json:"deliveries"`
+	Failed     int    `json:"failed"`
+	GasUsed    uint64 `json:"gasUsed"`
+	// Spent is denominated in the dst coin
+	Spent *big.Int `json:"spent"`
+	// FeePaid is denominated in the src coin, only the deliveries with known fee are counted
+	FeePaid *big.Int `json:"feePaid"`
+	// Margin is FeePaid less Spent converted to the src coin, nil without prices
+	Margin *big.Int `json:"margin,omitempty"`
+}
+
+// ChainReport summarises the spend and the claimed fees of a chain in its coin
+type ChainReport struct {
+	Chain      string   `json:"chain"`
+	Deliveries int      `json:"deliveries"`
+	Failed     int      `json:"failed"`
+	Spent      *big.Int `json:"spent"`
+	Earned     *big.Int `json:"earned"`
+	Net        *big.Int `json:"net"`
+}
+
+// Report is the cost accounting of the relayer between From and To unix time
+type Report struct {
+	From   int64          `json:"from"`
+	To     int64          `json:"to"`
+	Routes []*RouteReport `json:"routes"`
+	Chains []*ChainReport `json:"chains"`
+}
+
+// NewReport aggregates the delivery costs per route and per chain,
+// the fees earned are taken from the claim history
+func NewReport(from, to int64, costs []*types.DeliveryCost, claims []*types.FeeClaim) *Report {
+	routes := make(map[string]*RouteReport)
+	chains := make(map[string]*ChainReport)
+
+	chain := func(nid string) *ChainReport {
+		c, ok := chains[nid]
+		if !ok {
+			c = &ChainReport{Chain: nid, Spent: new(big.Int), Earned: new(big.Int), Net: new(big.Int)}
+			chains[nid] = c
+		}
+		return c
+	}
+
+	for _, cost := range costs {
+		key := cost.Src + "-" + cost.Dst + "-" + cost.EventType
+		route, ok := routes[key]
+		if !ok {
+			route = &RouteReport{
+				Src:       cost.Src,
+				Dst:       cost.Dst,
+				EventType: cost.EventType,
+				Spent:     new(big.Int),
+				FeePaid:   new(big.Int),
+			}
+			routes[key] = route
+		}
+		dst := chain(cost.Dst)
+		if cost.Failed {
+			route.Failed++
+			dst.Failed++
+		} else {
+			route.Deliveries++
+			dst.Deliveries++
+		}
+		route.GasUsed += cost.GasUsed
+		if cost.TxFee != nil {
+			route.Spent.Add(route.Spent, cost.TxFee)
+			dst.Spent.Add(dst.Spent, cost.TxFee)
+		}
+		if cost.FeePaid != nil {
+			route.FeePaid.Add(route.FeePaid, cost.FeePaid)
+		}
+	}
+
+	for _, claim := range claims {
+		if claim.Amount == nil {
+			continue
+		}
+		c := chain(claim.Chain)
+		c.Earned.Add(c.Earned, claim.Amount)
+	}
+
+	report := &Report{From: from, To: to}
+	for _, route := range routes {
+		report.Routes = append(report.Routes, route)
+	}
+	for _, c := range chains {
+		c.Net.Sub(c.Earned, c.Spent)
+		report.Chains = append(report.Chains, c)
+	}
+	sort.Slice(report.Routes, func(i, j int) bool {
+		a, b := report.Routes[i], report.Routes[j]
+		if a.Src != b.Src {
+			return a.Src < b.Src
+		}
+		if a.Dst != b.Dst {
+			return a.Dst < b.Dst
+		}
+		return a.EventType < b.EventType
+	})
+	sort.Slice(report.Chains, func(i, j int) bool {
+		return report.Chains[i].Chain < report.Chains[j].Chain
+	})
+	return report
+}
+
+// ApplyPrices computes the margin of the routes, routes with unknown prices are left without margin
+func (r *Report) ApplyPrices(ctx context.Context, feed PriceFeed) {
+	for _, route := range r.Routes {
+		srcPrice, err := feed.Price(ctx, route.Src)
+		if err != nil {
+			continue
+		}
+		dstPrice, err := feed.Price(ctx, route.Dst)
+		if err != nil {
+			continue
+		}
+		route.Margin = new(big.Int).Sub(route.FeePaid, Convert(route.Spent, dstPrice, srcPrice))
+	}
+}
+
+// WriteCSV writes the routes followed by the chains, the kind column tells them apart
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"kind", "src", "dst", "event_type", "deliveries", "failed", "gas_used", "spent", "fee_paid", "earned", "net"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, route := range r.Routes {
+		record := []string{
+			"route", route.Src, route.Dst, route.EventType,
+			strconv.Itoa(route.Deliveries), strconv.Itoa(route.Failed), strconv.FormatUint(route.GasUsed, 10),
+			route.Spent.String(), route.FeePaid.String(), "", intString(route.Margin),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	for _, c := range r.Chains {
+		record := []string{
+			"chain", c.Chain, "", "",
+			strconv.Itoa(c.Deliveries), strconv.Itoa(c.Failed), "",
+			c.Spent.String(), "", c.Earned.String(), c.Net.String(),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func intString(i *big.Int) string {
+	if i == nil {
+		return ""
+	}
+	return i.String()
+}
@@ -0,0 +1,62 @@
+package fee
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	costs := []*types.DeliveryCost{
+		{Src: "src", Dst: "dst", EventType: "emitMessage", GasUsed: 10, TxFee: big.NewInt(1_000_000), FeePaid: big.NewInt(1_000_000_000_000_000_000)},
+		{Src: "src", Dst: "dst", EventType: "emitMessage", GasUsed: 5, TxFee: big.NewInt(1_000_000), Failed: true},
+		{Src: "dst", Dst: "src", EventType: "emitMessage", GasUsed: 7, TxFee: big.NewInt(300)},
+	}
+	claims := []*types.FeeClaim{
+		{Chain: "src", Amount: big.NewInt(1000)},
+		{Chain: "src", Amount: big.NewInt(500)},
+	}
+	report := NewReport(0, 100, costs, claims)
+
+	t.Run("routes", func(t *testing.T) {
+		assert.Len(t, report.Routes, 2)
+		route := report.Routes[1]
+		assert.Equal(t, "src", route.Src)
+		assert.Equal(t, 1, route.Deliveries)
+		assert.Equal(t, 1, route.Failed)
+		assert.Equal(t, uint64(15), route.GasUsed)
+		assert.Equal(t, big.NewInt(2_000_000), route.Spent)
+		assert.Nil(t, route.Margin)
+	})
+
+	t.Run("chains", func(t *testing.T) {
+		assert.Len(t, report.Chains, 2)
+		src := report.Chains[1]
+		assert.Equal(t, "src", src.Chain)
+		assert.Equal(t, big.NewInt(300), src.Spent)
+		assert.Equal(t, big.NewInt(1500), src.Earned)
+		assert.Equal(t, big.NewInt(1200), src.Net)
+	})
+
+	t.Run("margin with prices", func(t *testing.T) {
+		report.ApplyPrices(context.Background(), StaticPriceFeed{
+			"src": {Value: 2, Decimals: 18},
+			"dst": {Value: 1, Decimals: 6},
+		})
+		// 2 dst coin at price 1 is 1 src coin at price 2
+		assert.Zero(t, report.Routes[1].Margin.Sign())
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, report.WriteCSV(&buf))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 5)
+		assert.Equal(t, "chain,src,,,1,0,,300,,1500,1200", lines[4])
+	})
+}
@@ -0,0 +1,96 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/fee"
+	"go.uber.org/zap"
+)
+
+// SetFeeManager enables the fee manager which keeps the connection fees
+// in line with the delivery cost of the routes
+func (r *Relayer) SetFeeManager(cfg *fee.Config) error {
+	manager, err := fee.NewManager(cfg, r.costStore)
+	if err != nil {
+		return err
+	}
+	r.feeManager = manager
+	return nil
+}
+
+func (r *Relayer) StartFeeManager(ctx context.Context) {
+	if r.feeManager == nil || !r.feeManager.Config().Enabled {
+		return
+	}
+	ticker := time.NewTicker(r.feeManager.Config().Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.isLeader() {
+				continue
+			}
+			if _, err := r.UpdateFees(ctx, !r.feeManager.Config().DryRun); err != nil {
+				r.log.Error("fee manager: failed to update fees", zap.Error(err))
+			}
+		}
+	}
+}
+
+// UpdateFees computes the fee proposals of every route and submits
+// the ones outside the hysteresis when apply is set
+func (r *Relayer) UpdateFees(ctx context.Context, apply bool) ([]*fee.Proposal, error) {
+	if r.feeManager == nil {
+		return nil, fmt.Errorf("fee manager is not configured")
+	}
+	var proposals []*fee.Proposal
+	for _, src := range r.GetAllChainsRuntime() {
+		if !src.Provider.Config().Enabled() || src.Provider.Config().GetConnContract() == "" {
+			continue
+		}
+		for _, dst := range r.GetAllChainsRuntime() {
+			if src == dst || !dst.Provider.Config().Enabled() {
+				continue
+			}
+			srcNID, dstNID := src.Provider.NID(), dst.Provider.NID()
+			currentFee, err := src.Provider.GetFee(ctx, dstNID, true)
+			if err != nil {
+				src.log.Warn("fee manager: failed to get current fee", zap.String("dst", dstNID), zap.Error(err))
+				continue
+			}
+			proposal, err := r.feeManager.Propose(ctx, srcNID, dstNID, new(big.Int).SetUint64(currentFee))
+			if err != nil {
+				src.log.Warn("fee manager: failed to propose fee", zap.String("dst", dstNID), zap.Error(err))
+				continue
+			}
+			proposals = append(proposals, proposal)
+			if proposal.Skipped() {
+				src.log.Debug("fee manager: skipping fee update", zap.String("dst", dstNID), zap.String("reason", proposal.Reason))
+				continue
+			}
+			src.log.Info("fee manager: fee proposal",
+				zap.String("dst", dstNID),
+				zap.Stringer("current_fee", proposal.CurrentFee),
+				zap.Stringer("msg_fee", proposal.MsgFee),
+				zap.Stringer("res_fee", proposal.ResFee),
+				zap.Bool("apply", apply),
+			)
+			if !apply {
+				continue
+			}
+			if err := src.Provider.SetFee(ctx, dstNID, proposal.MsgFee, proposal.ResFee); err != nil {
+				src.log.Error("fee manager: failed to set fee", zap.String("dst", dstNID), zap.Error(err))
+				proposal.Reason = err.Error()
+				continue
+			}
+			proposal.Applied = true
+		}
+	}
+	return proposals, nil
+}
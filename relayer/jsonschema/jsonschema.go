@@ -0,0 +1,191 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Draft is the dialect of the generated schemas
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern matches the durations parsed by time.ParseDuration
+const durationPattern = `^-?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$|^0$`
+
+// Schema is the subset of json schema describing the yaml encoding of the configs
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Const                string             `json:"const,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	If                   *Schema            `json:"if,omitempty"`
+	Then                 *Schema            `json:"then,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+
+	// never is the false schema, no value is valid
+	never bool
+	// order is the declaration order of the reflected properties
+	order []string
+	// optional is set for the pointer fields, which are commented out in the templates
+	optional bool
+	// hidden is set for the embedded structs, which are left out of the templates
+	hidden bool
+}
+
+// False is the schema of the additional properties of the structs
+var False = &Schema{never: true}
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.never {
+		return []byte("false"), nil
+	}
+	type schema Schema
+	return json.Marshal((*schema)(s))
+}
+
+// PropertyNames returns the properties in the order of the fields of the
+// reflected struct, the other properties are sorted
+func (s *Schema) PropertyNames() []string {
+	names := slices.Clone(s.order)
+	var rest []string
+	for name := range s.Properties {
+		if !slices.Contains(names, name) {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// Types is the type keyword, a single type is written as a string
+type Types []string
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+)
+
+// Schemer is implemented by the types with a custom yaml encoding
+type Schemer interface {
+	JSONSchema() *Schema
+}
+
+var (
+	schemerType  = reflect.TypeOf((*Schemer)(nil)).Elem()
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Reflect returns the schema of the yaml encoding of v, the fields are named
+// by their yaml tags and the fields tagged `jsonschema:"required"` are required
+func Reflect(v any) *Schema {
+	return reflectType(reflect.TypeOf(v))
+}
+
+func reflectType(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		return reflectType(t.Elem())
+	}
+	switch {
+	case t.Implements(schemerType):
+		return reflect.Zero(t).Interface().(Schemer).JSONSchema()
+	case reflect.PointerTo(t).Implements(schemerType):
+		return reflect.New(t).Interface().(Schemer).JSONSchema()
+	case t == durationType:
+		return &Schema{Type: Types{TypeString, TypeInteger}, Pattern: durationPattern}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Types{TypeBoolean}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: Types{TypeInteger}}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0.0
+		return &Schema{Type: Types{TypeInteger}, Minimum: &zero}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{TypeNumber}}
+	case reflect.String:
+		return &Schema{Type: Types{TypeString}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{TypeString}}
+		}
+		return &Schema{Type: Types{TypeArray}, Items: reflectType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: Types{TypeObject}, AdditionalProperties: reflectType(t.Elem())}
+	case reflect.Struct:
+		s := &Schema{Type: Types{TypeObject}, Properties: make(map[string]*Schema), AdditionalProperties: False}
+		reflectFields(s, t)
+		return s
+	default:
+		// interfaces accept any value
+		return &Schema{}
+	}
+}
+
+func reflectFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			inline := f.Type
+			if inline.Kind() == reflect.Pointer {
+				inline = inline.Elem()
+			}
+			if inline.Kind() == reflect.Map {
+				s.AdditionalProperties = reflectType(inline.Elem())
+			} else {
+				reflectFields(s, inline)
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		if _, ok := s.Properties[name]; !ok {
+			s.order = append(s.order, name)
+		}
+		s.Properties[name] = reflectType(f.Type)
+		if f.Type.Kind() == reflect.Pointer {
+			s.Properties[name].optional = true
+		}
+		// yaml encodes the embedded structs which are not inlined under their
+		// type name, the configs embedding them declare their own fields
+		if f.Anonymous {
+			s.Properties[name].hidden = true
+		}
+		if f.Tag.Get("jsonschema") == "required" {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
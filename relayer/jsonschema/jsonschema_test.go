@@ -0,0 +1,176 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type testLevel string
+
+func (testLevel) JSONSchema() *Schema {
+	return &Schema{Type: Types{TypeString}, Enum: []string{"low", "high"}}
+}
+
+type testCommon struct {
+	URL string `yaml:"url"`
+	NID string `yaml:"nid" jsonschema:"required"`
+}
+
+type testConfig struct {
+	testCommon `yaml:",inline"`
+	Height     uint64            `yaml:"height"`
+	Timeout    time.Duration     `yaml:"timeout"`
+	Level      testLevel         `yaml:"level"`
+	Urls       []string          `yaml:"urls"`
+	Contracts  map[string]string `yaml:"contracts"`
+	Nested     *struct {
+		Enabled bool `yaml:"enabled"`
+	} `yaml:"nested"`
+	Ignored string `yaml:"-"`
+	hidden  string
+}
+
+func validate(t *testing.T, s *Schema, doc string) []*Error {
+	var n yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(doc), &n))
+	return s.Validate(&n)
+}
+
+func TestReflect(t *testing.T) {
+	s := Reflect(testConfig{})
+	assert.Equal(t, Types{TypeObject}, s.Type)
+	assert.Equal(t, []string{"nid"}, s.Required)
+	assert.Equal(t, []string{"url", "nid", "height", "timeout", "level", "urls", "contracts", "nested"}, s.PropertyNames())
+	assert.Equal(t, []string{"low", "high"}, s.Properties["level"].Enum)
+	assert.Equal(t, Types{TypeString}, s.Properties["urls"].Items.Type)
+	assert.Equal(t, Types{TypeString}, s.Properties["contracts"].AdditionalProperties.Type)
+	assert.Contains(t, s.Properties["nested"].Properties, "enabled")
+
+	out, err := json.Marshal(s.Properties["nested"])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","properties":{"enabled":{"type":"boolean"}},"additionalProperties":false}`, string(out))
+}
+
+func TestValidate(t *testing.T) {
+	s := Reflect(testConfig{})
+
+	assert.Empty(t, validate(t, s, `
+nid: 0x1.icon
+url: http://localhost
+height: 10
+timeout: 1m30s
+level: high
+urls: [a, b]
+contracts:
+  xcall: cx1
+nested:
+  enabled: true
+`))
+
+	errs := validate(t, s, `
+url: http://localhost
+height: -1
+timeout: soon
+level: medium
+urls: [a, [b]]
+unknown: 1
+nested:
+  enabled: maybe
+`)
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+	assert.Equal(t, []string{
+		"line 2: nid: is required",
+		"line 3: height: -1 is less than 0",
+		`line 4: timeout: "soon" does not match ` + durationPattern,
+		`line 5: level: "medium" must be one of low, high`,
+		"line 6: urls[1]: expected string, got array",
+		"line 7: unknown: unknown field",
+		`line 9: nested.enabled: expected boolean, got string "maybe"`,
+	}, messages)
+	assert.True(t, errs[5].Unknown)
+	assert.False(t, errs[0].Unknown)
+}
+
+func TestValidateAnyOfAndIf(t *testing.T) {
+	s := &Schema{
+		Type: Types{TypeObject},
+		Properties: map[string]*Schema{
+			"kind": {Type: Types{TypeString}},
+			"key": {AnyOf: []*Schema{
+				{Type: Types{TypeString}},
+				{Type: Types{TypeObject}, Properties: map[string]*Schema{"env": {Type: Types{TypeString}}}, AdditionalProperties: False},
+			}},
+		},
+		AllOf: []*Schema{{
+			If:   &Schema{Required: []string{"kind"}, Properties: map[string]*Schema{"kind": {Const: "a"}}},
+			Then: &Schema{Required: []string{"key"}},
+		}},
+	}
+
+	assert.Empty(t, validate(t, s, "kind: a\nkey: value\n"))
+	assert.Empty(t, validate(t, s, "kind: a\nkey:\n  env: KEY\n"))
+	assert.Empty(t, validate(t, s, "kind: b\n"))
+
+	errs := validate(t, s, "kind: a\n")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "line 1: key: is required", errs[0].Error())
+
+	errs = validate(t, s, "kind: a\nkey:\n  file: /key\n")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "line 3: key: object does not match any of the allowed forms", errs[0].Error())
+}
+
+func TestTemplate(t *testing.T) {
+	type item struct {
+		Name string   `yaml:"name"`
+		Tags []string `yaml:"tags"`
+	}
+	type auth struct {
+		Token string `yaml:"token"`
+	}
+	type Base struct {
+		URL string `yaml:"url"`
+	}
+	type config struct {
+		Base
+		NID     string        `yaml:"nid" jsonschema:"required"`
+		Height  uint64        `yaml:"height"`
+		Timeout time.Duration `yaml:"timeout"`
+		Enabled bool          `yaml:"enabled"`
+		Auth    *auth         `yaml:"auth"`
+		Items   []item        `yaml:"items"`
+	}
+	s := Reflect(config{})
+	s.Describe(func(path string) string {
+		if path == "timeout" {
+			return "Timeout of the requests, a long description which is wrapped at the width of the template."
+		}
+		return ""
+	})
+	assert.Equal(t, `# Required.
+nid: ""
+height: 0
+# Timeout of the requests, a long description which is wrapped at the width of
+# the template.
+timeout: 0s
+enabled: false
+# auth:
+  # token: ""
+items:
+# - name: ""
+  # tags: []
+`, string(s.Template()))
+
+	var n yaml.Node
+	assert.NoError(t, yaml.Unmarshal(s.Template(), &n))
+	assert.Len(t, s.Validate(&n), 0)
+	// the embedded structs are decoded under their type name
+	assert.Len(t, validate(t, s, "nid: 0x1.icon\nbase:\n  url: http://localhost\n"), 0)
+}
@@ -0,0 +1,138 @@
+package jsonschema
+
+import (
+	"slices"
+	"strings"
+)
+
+// templateWidth is the width the descriptions are wrapped at
+const templateWidth = 80
+
+// Describe sets the descriptions of the properties returned by doc for their
+// path, the path is dotted and the items of the arrays are named path[]
+func (s *Schema) Describe(doc func(path string) string) {
+	s.describe(doc, "")
+}
+
+func (s *Schema) describe(doc func(path string) string, path string) {
+	for name, prop := range s.Properties {
+		child := name
+		if path != "" {
+			child = path + "." + name
+		}
+		if d := doc(child); d != "" {
+			prop.Description = d
+		}
+		prop.describe(doc, child)
+	}
+	if s.Items != nil {
+		s.Items.describe(doc, path+"[]")
+	}
+}
+
+type templateLine struct {
+	indent int
+	// comment lines describe the fields, the other lines are yaml
+	comment  bool
+	disabled bool
+	text     string
+}
+
+// Template returns a yaml document with the properties of the object schema
+// set to their zero value and commented with their description, the embedded
+// structs are left out. The optional
+// properties and the items of the arrays of objects are written commented
+// out, uncommenting them gives valid yaml.
+func (s *Schema) Template() []byte {
+	var b strings.Builder
+	for _, l := range templateFields(s, 0, false) {
+		b.WriteString(strings.Repeat(" ", l.indent))
+		if l.comment || l.disabled {
+			b.WriteString("# ")
+		}
+		b.WriteString(l.text)
+		b.WriteByte('\n')
+	}
+	return []byte(b.String())
+}
+
+func templateFields(s *Schema, indent int, disabled bool) []templateLine {
+	var lines []templateLine
+	for _, name := range s.PropertyNames() {
+		prop := s.Properties[name]
+		if prop.hidden {
+			continue
+		}
+		doc := prop.Description
+		if slices.Contains(s.Required, name) {
+			doc = strings.TrimSpace(doc + " Required.")
+		}
+		for _, text := range wrap(doc, templateWidth-indent-2) {
+			lines = append(lines, templateLine{indent: indent, comment: true, text: text})
+		}
+		off := disabled || prop.optional
+		switch {
+		case len(prop.Properties) > 0:
+			lines = append(lines, templateLine{indent: indent, disabled: off, text: name + ":"})
+			lines = append(lines, templateFields(prop, indent+2, off)...)
+		case prop.Items != nil && len(prop.Items.Properties) > 0:
+			// the null value decodes into an empty array and the items can be uncommented under it
+			lines = append(lines, templateLine{indent: indent, disabled: off, text: name + ":"})
+			item := templateFields(prop.Items, indent+2, true)
+			// the first field of the item starts the sequence entry
+			for i, l := range item {
+				item[i].indent = indent
+				if !l.comment {
+					item[i].text = "- " + l.text
+					break
+				}
+			}
+			lines = append(lines, item...)
+		default:
+			lines = append(lines, templateLine{indent: indent, disabled: off, text: name + ": " + prop.zeroValue()})
+		}
+	}
+	return lines
+}
+
+// zeroValue returns the yaml of the zero value of the schema
+func (s *Schema) zeroValue() string {
+	switch {
+	case len(s.Type) == 0:
+		return `""`
+	case slices.Contains(s.Type, TypeObject):
+		return "{}"
+	case slices.Contains(s.Type, TypeArray):
+		return "[]"
+	case slices.Contains(s.Type, TypeString):
+		// durations are written as strings
+		if slices.Contains(s.Type, TypeInteger) {
+			return "0s"
+		}
+		return `""`
+	case slices.Contains(s.Type, TypeBoolean):
+		return "false"
+	default:
+		return "0"
+	}
+}
+
+// wrap splits the text into lines of at most width characters, longer words are kept whole
+func wrap(text string, width int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
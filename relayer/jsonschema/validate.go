@@ -0,0 +1,212 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Error is a value of a yaml document which does not match the schema
+type Error struct {
+	// Path is the dotted path of the value, the items are indexed as items[0]
+	Path    string
+	Line    int
+	Column  int
+	Message string
+	// Unknown is set for the fields which are not declared by the schema
+	Unknown bool
+}
+
+func (e *Error) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "(root)"
+	}
+	return fmt.Sprintf("line %d: %s: %s", e.Line, path, e.Message)
+}
+
+// Validate returns the values of the yaml document which do not match the
+// schema ordered by line, the yaml values are checked as they are decoded
+// into the configs
+func (s *Schema) Validate(n *yaml.Node) []*Error {
+	v := &validator{root: s, patterns: make(map[string]*regexp.Regexp)}
+	v.validate(s, n, "")
+	sort.SliceStable(v.errs, func(i, j int) bool { return v.errs[i].Line < v.errs[j].Line })
+	return v.errs
+}
+
+type validator struct {
+	root     *Schema
+	patterns map[string]*regexp.Regexp
+	errs     []*Error
+}
+
+func (v *validator) fail(n *yaml.Node, path, format string, args ...any) {
+	v.errs = append(v.errs, &Error{Path: path, Line: n.Line, Column: n.Column, Message: fmt.Sprintf(format, args...)})
+}
+
+// matches reports whether n is valid without recording the errors
+func (v *validator) matches(s *Schema, n *yaml.Node, path string) bool {
+	sub := &validator{root: v.root, patterns: v.patterns}
+	sub.validate(s, n, path)
+	return len(sub.errs) == 0
+}
+
+func (v *validator) validate(s *Schema, n *yaml.Node, path string) {
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	if s.never {
+		v.fail(n, path, "is not allowed")
+		return
+	}
+	if s.Ref != "" {
+		ref, ok := v.root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if !ok {
+			v.fail(n, path, "unknown schema %s", s.Ref)
+			return
+		}
+		v.validate(ref, n, path)
+	}
+
+	kind := nodeType(n)
+	// yaml decodes the null values into the zero values
+	if kind == "null" {
+		return
+	}
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return typeMatches(t, kind) }) {
+		v.fail(n, path, "expected %s, got %s", strings.Join(s.Type, " or "), describe(n, kind))
+		return
+	}
+	if n.Kind == yaml.ScalarNode {
+		v.validateScalar(s, n, path, kind)
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		v.validateObject(s, n, path)
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range n.Content {
+				v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	}
+
+	if len(s.AnyOf) > 0 && !slices.ContainsFunc(s.AnyOf, func(sub *Schema) bool { return v.matches(sub, n, path) }) {
+		v.fail(n, path, "%s does not match any of the allowed forms", describe(n, kind))
+	}
+	for _, sub := range s.AllOf {
+		v.validate(sub, n, path)
+	}
+	if s.If != nil && s.Then != nil && v.matches(s.If, n, path) {
+		v.validate(s.Then, n, path)
+	}
+}
+
+func (v *validator) validateScalar(s *Schema, n *yaml.Node, path, kind string) {
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, n.Value) {
+		v.fail(n, path, "%q must be one of %s", n.Value, strings.Join(s.Enum, ", "))
+	}
+	if s.Const != "" && n.Value != s.Const {
+		v.fail(n, path, "%q must be %q", n.Value, s.Const)
+	}
+	if s.Pattern != "" && kind == TypeString {
+		re, ok := v.patterns[s.Pattern]
+		if !ok {
+			re = regexp.MustCompile(s.Pattern)
+			v.patterns[s.Pattern] = re
+		}
+		if !re.MatchString(n.Value) {
+			v.fail(n, path, "%q does not match %s", n.Value, s.Pattern)
+		}
+	}
+	if s.Minimum != nil && (kind == TypeInteger || kind == TypeNumber) {
+		if f, err := strconv.ParseFloat(n.Value, 64); err == nil && f < *s.Minimum {
+			v.fail(n, path, "%s is less than %v", n.Value, *s.Minimum)
+		}
+	}
+}
+
+func (v *validator) validateObject(s *Schema, n *yaml.Node, path string) {
+	present := make(map[string]bool, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		present[key.Value] = true
+		child := key.Value
+		if path != "" {
+			child = path + "." + key.Value
+		}
+		if prop, ok := s.Properties[key.Value]; ok {
+			v.validate(prop, value, child)
+			continue
+		}
+		if s.AdditionalProperties == nil {
+			continue
+		}
+		if s.AdditionalProperties.never {
+			v.errs = append(v.errs, &Error{Path: child, Line: key.Line, Column: key.Column, Message: "unknown field", Unknown: true})
+			continue
+		}
+		v.validate(s.AdditionalProperties, value, child)
+	}
+	for _, name := range s.Required {
+		if !present[name] {
+			child := name
+			if path != "" {
+				child = path + "." + name
+			}
+			v.fail(n, child, "is required")
+		}
+	}
+}
+
+// nodeType returns the json type of the yaml value
+func nodeType(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return TypeObject
+	case yaml.SequenceNode:
+		return TypeArray
+	}
+	switch n.ShortTag() {
+	case "!!null":
+		return "null"
+	case "!!bool":
+		return TypeBoolean
+	case "!!int":
+		return TypeInteger
+	case "!!float":
+		return TypeNumber
+	default:
+		return TypeString
+	}
+}
+
+// typeMatches reports whether a yaml value of the kind decodes into the type,
+// every scalar decodes into a string
+func typeMatches(t, kind string) bool {
+	switch t {
+	case TypeString:
+		return kind != TypeObject && kind != TypeArray
+	case TypeNumber:
+		return kind == TypeNumber || kind == TypeInteger
+	default:
+		return t == kind
+	}
+}
+
+func describe(n *yaml.Node, kind string) string {
+	if n.Kind == yaml.ScalarNode {
+		return fmt.Sprintf("%s %q", kind, n.Value)
+	}
+	return kind
+}
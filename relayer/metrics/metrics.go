@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "centralized_relay"
+
+var (
+	registry = prometheus.NewRegistry()
+
+	// CircuitState is the circuit breaker state of the destination chains
+	CircuitState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "circuit_state",
+		Help:      "Circuit breaker state of the destination chain: 0 closed, 1 half-open, 2 open.",
+	}, []string{"nid"})
+
+	// CircuitTrips counts the transitions of the circuit breakers to open
+	CircuitTrips = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "circuit_trips_total",
+		Help:      "Number of times the circuit breaker of the destination chain opened.",
+	}, []string{"nid"})
+
+	// QueueDepth is the number of queued messages of the scheduler priority classes
+	QueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "queue_depth",
+		Help:      "Number of messages waiting to be routed per priority class.",
+	}, []string{"class"})
+
+	// Leader is set while the relayer is the leader of the active/passive mode
+	Leader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "leader",
+		Help:      "1 if the relayer holds the leader lease, 0 on standby.",
+	})
+
+	// RPCRequests counts the requests sent to the rpc endpoints of the chains
+	RPCRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_requests_total",
+		Help:      "Number of requests sent to the rpc endpoint.",
+	}, []string{"chain", "pool", "endpoint"})
+
+	// RPCErrors counts the failed requests of the rpc endpoints
+	RPCErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_errors_total",
+		Help:      "Number of failed requests of the rpc endpoint.",
+	}, []string{"chain", "pool", "endpoint"})
+
+	// RPCHealthy is set while the rpc endpoint passes the health checks
+	RPCHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rpc_endpoint_healthy",
+		Help:      "1 if the rpc endpoint passes the health checks, 0 otherwise.",
+	}, []string{"chain", "pool", "endpoint"})
+
+	// RPCHeight is the latest height reported by the rpc endpoint
+	RPCHeight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rpc_endpoint_height",
+		Help:      "Latest height reported by the rpc endpoint on the last health check.",
+	}, []string{"chain", "pool", "endpoint"})
+
+	// RPCActive is set for the rpc endpoint the requests are sent to
+	RPCActive = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rpc_endpoint_active",
+		Help:      "1 if the requests of the pool are sent to the rpc endpoint, 0 otherwise.",
+	}, []string{"chain", "pool", "endpoint"})
+
+	// RPCRateLimit is the configured request rate of the rpc endpoint
+	RPCRateLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rpc_rate_limit",
+		Help:      "Configured requests per second of the rpc endpoint, 0 when unlimited.",
+	}, []string{"chain", "pool", "endpoint"})
+
+	// RPCRateAllowed is the request rate of the rpc endpoint after the backoff
+	RPCRateAllowed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rpc_rate_allowed",
+		Help:      "Requests per second allowed to the rpc endpoint, lowered while it is rate limited.",
+	}, []string{"chain", "pool", "endpoint"})
+
+	// RPCRateObserved is the request rate of the rpc endpoint between the health checks
+	RPCRateObserved = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rpc_rate_observed",
+		Help:      "Requests per second sent to the rpc endpoint since the previous health check.",
+	}, []string{"chain", "pool", "endpoint"})
+
+	// RPCThrottled counts the rate limited responses of the rpc endpoints
+	RPCThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_throttled_total",
+		Help:      "Number of rate limited responses of the rpc endpoint.",
+	}, []string{"chain", "pool", "endpoint"})
+
+	// QuorumReads counts the quorum reads by result, agreed or failed
+	QuorumReads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "quorum_reads_total",
+		Help:      "Number of reads confirmed on the endpoints of the chain by result.",
+	}, []string{"chain", "check", "result"})
+
+	// QuorumDisagreements counts the answers of the endpoints differing from the quorum
+	QuorumDisagreements = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "quorum_disagreements_total",
+		Help:      "Number of quorum reads in which the endpoint failed or disagreed with the most common answer.",
+	}, []string{"chain", "check", "endpoint"})
+)
+
+func init() {
+	registry.MustRegister(
+		CircuitState,
+		CircuitTrips,
+		QueueDepth,
+		Leader,
+		RPCRequests,
+		RPCErrors,
+		RPCHealthy,
+		RPCHeight,
+		RPCActive,
+		RPCRateLimit,
+		RPCRateAllowed,
+		RPCRateObserved,
+		RPCThrottled,
+		QuorumReads,
+		QuorumDisagreements,
+	)
+}
+
+// Handler serves the relayer metrics in the prometheus format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// NewServer returns the metrics http server listening on addr
+func NewServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return &http.Server{Addr: addr, Handler: mux}
+}
@@ -0,0 +1,146 @@
+package migration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileSuffix marks the keys whose value is read from the file they name, the
+// keys are renamed and removed along with the key they set
+const fileSuffix = "_file"
+
+type migrationState struct {
+	version int
+	changes []Change
+}
+
+// Mapping is a yaml mapping modified by the migration steps, the changes are
+// recorded with the path of the mapping
+type Mapping struct {
+	node  *yaml.Node
+	path  string
+	state *migrationState
+}
+
+// Path returns the dotted path of the mapping in the document
+func (m *Mapping) Path() string {
+	return m.path
+}
+
+// Get returns the value of the key, nil when it is not set
+func (m *Mapping) Get(key string) *yaml.Node {
+	if i := m.index(key); i >= 0 {
+		return m.node.Content[i+1]
+	}
+	return nil
+}
+
+// Has reports whether the key or its _file key is set
+func (m *Mapping) Has(key string) bool {
+	return m.index(key) >= 0 || m.index(key+fileSuffix) >= 0
+}
+
+// Mapping returns the mapping value of the key, nil when the key is not set
+// or its value is not a mapping
+func (m *Mapping) Mapping(key string) *Mapping {
+	n := m.Get(key)
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	return &Mapping{node: n, path: m.child(key), state: m.state}
+}
+
+// Each calls fn for the keys with a mapping value, in the order of the document
+func (m *Mapping) Each(fn func(key string, value *Mapping) error) error {
+	for i := 0; i+1 < len(m.node.Content); i += 2 {
+		key, value := m.node.Content[i].Value, m.node.Content[i+1]
+		if value.Kind != yaml.MappingNode {
+			continue
+		}
+		if err := fn(key, &Mapping{node: value, path: m.child(key), state: m.state}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rename renames the key and its _file key, the key is removed when the new
+// key is already set
+func (m *Mapping) Rename(from, to string) {
+	if !m.Has(from) {
+		return
+	}
+	if m.Has(to) {
+		m.Remove(from, to+" is set")
+		return
+	}
+	for _, suffix := range []string{"", fileSuffix} {
+		if i := m.index(from + suffix); i >= 0 {
+			m.node.Content[i].Value = to + suffix
+			m.record("renamed %s to %s", from+suffix, to+suffix)
+		}
+	}
+}
+
+// Remove removes the key and its _file key with their comments, the reason is
+// recorded with the change
+func (m *Mapping) Remove(key, reason string) {
+	for _, suffix := range []string{"", fileSuffix} {
+		if i := m.index(key + suffix); i >= 0 {
+			m.node.Content = append(m.node.Content[:i], m.node.Content[i+2:]...)
+			m.record("removed %s, %s", key+suffix, reason)
+		}
+	}
+}
+
+// Version returns the version set in the mapping, 0 when it is not set
+func (m *Mapping) Version() (int, error) {
+	n := m.Get(VersionKey)
+	if n == nil {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(n.Value)
+	if err != nil || n.Kind != yaml.ScalarNode || v < 0 {
+		return 0, fmt.Errorf("line %d: invalid %s %q", n.Line, VersionKey, n.Value)
+	}
+	return v, nil
+}
+
+// setVersion sets the version, the key is added as the first one of the document
+func (m *Mapping) setVersion(v int) {
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(v)}
+	if n := m.Get(VersionKey); n != nil {
+		// the comments of the key are kept
+		n.Kind, n.Tag, n.Value, n.Style = value.Kind, value.Tag, value.Value, 0
+		return
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: VersionKey}
+	m.node.Content = append([]*yaml.Node{key, value}, m.node.Content...)
+}
+
+func (m *Mapping) index(key string) int {
+	for i := 0; i+1 < len(m.node.Content); i += 2 {
+		if m.node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *Mapping) child(key string) string {
+	if m.path == "" {
+		return key
+	}
+	return m.path + "." + key
+}
+
+func (m *Mapping) record(format string, args ...any) {
+	m.state.changes = append(m.state.changes, Change{
+		Version: m.state.version,
+		Path:    m.path,
+		Message: strings.TrimSpace(fmt.Sprintf(format, args...)),
+	})
+}
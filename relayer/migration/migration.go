@@ -0,0 +1,146 @@
+package migration
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VersionKey is the top level key holding the version of a document
+const VersionKey = "version"
+
+// Change is a modification made by a migration step
+type Change struct {
+	// Version is the version the step migrates to
+	Version int
+	// Path is the dotted path of the mapping which was changed
+	Path    string
+	Message string
+}
+
+func (c Change) String() string {
+	if c.Path == "" {
+		return fmt.Sprintf("v%d: %s", c.Version, c.Message)
+	}
+	return fmt.Sprintf("v%d: %s: %s", c.Version, c.Path, c.Message)
+}
+
+// Step upgrades a document from the version From to From+1
+type Step struct {
+	From        int
+	Description string
+	// Migrate modifies the root mapping of the document in place
+	Migrate func(root *Mapping) error
+}
+
+// Registry holds the steps upgrading a document to its latest version
+type Registry struct {
+	steps map[int]Step
+}
+
+func NewRegistry(steps ...Step) *Registry {
+	r := &Registry{steps: make(map[int]Step)}
+	for _, s := range steps {
+		r.Register(s)
+	}
+	return r
+}
+
+// Register adds the step, a version can only be migrated by a single step
+func (r *Registry) Register(s Step) {
+	if _, ok := r.steps[s.From]; ok {
+		panic(fmt.Sprintf("migration from version %d already registered", s.From))
+	}
+	r.steps[s.From] = s
+}
+
+// Latest returns the version the documents are migrated to
+func (r *Registry) Latest() int {
+	var latest int
+	for from := range r.steps {
+		if from+1 > latest {
+			latest = from + 1
+		}
+	}
+	return latest
+}
+
+// Steps returns the steps in the order they are applied
+func (r *Registry) Steps() []Step {
+	steps := make([]Step, 0, len(r.steps))
+	for _, s := range r.steps {
+		steps = append(steps, s)
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i].From < steps[j].From })
+	return steps
+}
+
+// Migrate upgrades the document to the latest version and sets its version,
+// it returns the version of the document before the migration and the changes
+// made. A document without version is at version 0.
+func (r *Registry) Migrate(doc *yaml.Node) (int, []Change, error) {
+	root, err := rootMapping(doc)
+	if err != nil {
+		return 0, nil, err
+	}
+	from, err := root.Version()
+	if err != nil {
+		return 0, nil, err
+	}
+	latest := r.Latest()
+	if from > latest {
+		return from, nil, &NewerVersionError{Version: from, Latest: latest}
+	}
+	for v := from; v < latest; v++ {
+		step, ok := r.steps[v]
+		if !ok {
+			return from, nil, fmt.Errorf("no migration from version %d", v)
+		}
+		root.state.version = v + 1
+		if err := step.Migrate(root); err != nil {
+			return from, nil, fmt.Errorf("migration to version %d: %w", v+1, err)
+		}
+	}
+	if from < latest {
+		root.setVersion(latest)
+	}
+	return from, root.state.changes, nil
+}
+
+// NewerVersionError is returned for the documents written for a newer version
+type NewerVersionError struct {
+	Version int
+	Latest  int
+}
+
+func (e *NewerVersionError) Error() string {
+	return fmt.Sprintf("version %d is newer than the supported version %d", e.Version, e.Latest)
+}
+
+// Version returns the version of the document, 0 when it is not set
+func Version(doc *yaml.Node) (int, error) {
+	root, err := rootMapping(doc)
+	if err != nil {
+		return 0, err
+	}
+	return root.Version()
+}
+
+func rootMapping(doc *yaml.Node) (*Mapping, error) {
+	n := doc
+	if n.Kind == 0 {
+		// the empty documents are not decoded into a document node
+		n.Kind = yaml.DocumentNode
+	}
+	if n.Kind == yaml.DocumentNode {
+		if len(n.Content) == 0 {
+			n.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+		}
+		n = n.Content[0]
+	}
+	if n.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping", n.Line)
+	}
+	return &Mapping{node: n, state: new(migrationState)}, nil
+}
@@ -0,0 +1,123 @@
+package migration
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func testRegistry() *Registry {
+	return NewRegistry(
+		Step{From: 0, Description: "rename the endpoints", Migrate: func(root *Mapping) error {
+			root.Remove("memo", "not used")
+			return root.Mapping("chains").Each(func(_ string, chain *Mapping) error {
+				chain.Rename("url", "rpc-url")
+				chain.Rename("key", "api-key")
+				return nil
+			})
+		}},
+		Step{From: 1, Description: "drop the intervals", Migrate: func(root *Mapping) error {
+			return root.Mapping("chains").Each(func(_ string, chain *Mapping) error {
+				chain.Remove("interval", "the blocks are polled")
+				return nil
+			})
+		}},
+	)
+}
+
+func migrate(t *testing.T, r *Registry, doc string) (string, int, []string, error) {
+	var n yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(doc), &n))
+	from, changes, err := r.Migrate(&n)
+	var messages []string
+	for _, c := range changes {
+		messages = append(messages, c.String())
+	}
+	out, marshalErr := yaml.Marshal(&n)
+	assert.NoError(t, marshalErr)
+	return string(out), from, messages, err
+}
+
+func TestMigrate(t *testing.T) {
+	r := testRegistry()
+	assert.Equal(t, 2, r.Latest())
+	assert.Len(t, r.Steps(), 2)
+
+	out, from, changes, err := migrate(t, r, `# relayer config
+
+memo: ""
+chains:
+  icon:
+    # primary endpoint
+    url: http://localhost
+    key_file: /run/secrets/key
+    interval: 2s
+  evm:
+    url: http://old
+    rpc-url: http://new
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, from)
+	assert.Equal(t, `# relayer config
+
+version: 2
+chains:
+    icon:
+        # primary endpoint
+        rpc-url: http://localhost
+        api-key_file: /run/secrets/key
+    evm:
+        rpc-url: http://new
+`, out)
+	assert.Equal(t, []string{
+		"v1: removed memo, not used",
+		"v1: chains.icon: renamed url to rpc-url",
+		"v1: chains.icon: renamed key_file to api-key_file",
+		"v1: chains.evm: removed url, rpc-url is set",
+		"v2: chains.icon: removed interval, the blocks are polled",
+	}, changes)
+
+	// the migrated documents are left unchanged
+	again, from, changes, err := migrate(t, r, out)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, from)
+	assert.Empty(t, changes)
+	assert.Equal(t, out, again)
+
+	// only the steps after the version are applied
+	out, from, changes, err = migrate(t, r, "version: 1\nmemo: kept\nchains:\n  icon:\n    url: a\n    interval: 2s\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, from)
+	assert.Equal(t, "version: 2\nmemo: kept\nchains:\n    icon:\n        url: a\n", out)
+	assert.Equal(t, []string{"v2: chains.icon: removed interval, the blocks are polled"}, changes)
+}
+
+func TestMigrateErrors(t *testing.T) {
+	r := testRegistry()
+
+	_, _, _, err := migrate(t, r, "version: 3\n")
+	var newer *NewerVersionError
+	assert.True(t, errors.As(err, &newer))
+	assert.Equal(t, 3, newer.Version)
+	assert.EqualError(t, err, "version 3 is newer than the supported version 2")
+
+	_, _, _, err = migrate(t, r, "version: two\n")
+	assert.EqualError(t, err, `line 1: invalid version "two"`)
+
+	_, _, _, err = migrate(t, r, "- a\n")
+	assert.EqualError(t, err, "line 1: expected a mapping")
+
+	_, _, _, err = migrate(t, NewRegistry(Step{From: 1, Migrate: func(*Mapping) error { return nil }}), "{}\n")
+	assert.EqualError(t, err, "no migration from version 0")
+
+	assert.Panics(t, func() {
+		r.Register(Step{From: 1})
+	})
+
+	var empty yaml.Node
+	v, err := Version(&empty)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, v)
+}
@@ -0,0 +1,234 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+var (
+	prefixPauseStore = "pause"
+	// PauseCheckInterval is the interval of the maintenance windows check
+	PauseCheckInterval = 10 * time.Second
+)
+
+// pauses keeps the stored pauses by id
+type pauses struct {
+	items map[string]*types.Pause
+	*sync.RWMutex
+}
+
+func newPauses(items []*types.Pause) *pauses {
+	p := &pauses{
+		items:   make(map[string]*types.Pause, len(items)),
+		RWMutex: new(sync.RWMutex),
+	}
+	for _, item := range items {
+		p.items[item.ID()] = item
+	}
+	return p
+}
+
+// Pause stops listening to a chain or routing on a route, the pause is kept in the store
+func (r *Relayer) Pause(ctx context.Context, pause *types.Pause) error {
+	if err := r.validatePause(pause); err != nil {
+		return err
+	}
+	pause.CreatedAt = time.Now().Unix()
+	if err := r.pauseStore.StorePause(pause); err != nil {
+		return err
+	}
+	r.pauses.Lock()
+	r.pauses.items[pause.ID()] = pause
+	r.pauses.Unlock()
+	r.log.Info("paused",
+		zap.String("target", pause.ID()),
+		zap.String("reason", pause.Reason),
+		zap.Int64("from", pause.From),
+		zap.Int64("until", pause.Until),
+	)
+	r.applyPauses(ctx)
+	return nil
+}
+
+func (r *Relayer) validatePause(pause *types.Pause) error {
+	switch pause.Kind {
+	case types.PauseListen:
+		if pause.Dst != "" {
+			return fmt.Errorf("listening is paused per chain, dst is not allowed")
+		}
+	case types.PauseRoute:
+	default:
+		return fmt.Errorf("unknown pause kind %q", pause.Kind)
+	}
+	if _, err := r.FindChainRuntime(pause.Src); err != nil {
+		return err
+	}
+	if pause.Dst != "" {
+		if _, err := r.FindChainRuntime(pause.Dst); err != nil {
+			return err
+		}
+	}
+	if pause.Until != 0 {
+		if pause.Until <= pause.From {
+			return fmt.Errorf("pause must end after it starts")
+		}
+		if pause.IsExpired(time.Now().Unix()) {
+			return fmt.Errorf("pause ends in the past")
+		}
+	}
+	return nil
+}
+
+// Resume removes the pause of the target
+func (r *Relayer) Resume(ctx context.Context, kind, src, dst string) error {
+	id := (&types.Pause{Kind: kind, Src: src, Dst: dst}).ID()
+	r.pauses.Lock()
+	_, ok := r.pauses.items[id]
+	delete(r.pauses.items, id)
+	r.pauses.Unlock()
+	if !ok {
+		return fmt.Errorf("%s is not paused", id)
+	}
+	if err := r.pauseStore.DeletePause(id); err != nil {
+		return err
+	}
+	r.log.Info("resumed", zap.String("target", id))
+	r.applyPauses(ctx)
+	return nil
+}
+
+// GetPauses returns the pauses, including the scheduled ones
+func (r *Relayer) GetPauses() []*types.Pause {
+	r.pauses.RLock()
+	defer r.pauses.RUnlock()
+	pauses := make([]*types.Pause, 0, len(r.pauses.items))
+	for _, pause := range r.pauses.items {
+		pauses = append(pauses, pause)
+	}
+	sort.Slice(pauses, func(i, j int) bool {
+		return pauses[i].ID() < pauses[j].ID()
+	})
+	return pauses
+}
+
+// routePaused returns true if routing on the src -> dst route is paused
+func (r *Relayer) routePaused(src, dst string) bool {
+	now := time.Now().Unix()
+	r.pauses.RLock()
+	defer r.pauses.RUnlock()
+	for _, pause := range r.pauses.items {
+		if pause.MatchRoute(src, dst) && pause.IsActive(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// listenPaused returns true if listening to the chain is paused
+func (r *Relayer) listenPaused(nid string) bool {
+	r.pauses.RLock()
+	defer r.pauses.RUnlock()
+	pause, ok := r.pauses.items[(&types.Pause{Kind: types.PauseListen, Src: nid}).ID()]
+	return ok && pause.IsActive(time.Now().Unix())
+}
+
+// StartPauseManager starts and ends the maintenance windows
+func (r *Relayer) StartPauseManager(ctx context.Context) {
+	ticker := time.NewTicker(PauseCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.applyPauses(ctx)
+		}
+	}
+}
+
+// applyPauses removes the expired pauses and stops or restarts
+// the listeners according to the listen pauses
+func (r *Relayer) applyPauses(ctx context.Context) {
+	now := time.Now().Unix()
+	r.pauses.Lock()
+	for id, pause := range r.pauses.items {
+		if !pause.IsExpired(now) {
+			continue
+		}
+		delete(r.pauses.items, id)
+		if err := r.pauseStore.DeletePause(id); err != nil {
+			r.log.Error("error occured when deleting expired pause", zap.String("target", id), zap.Error(err))
+		}
+		r.log.Info("maintenance window ended, resumed", zap.String("target", id))
+	}
+	r.pauses.Unlock()
+
+	// the listeners are only cancelled under the lock, waiting for them
+	// would block the reloads and the other pauses
+	var stopping []*ChainRuntime
+	r.lifecycleMu.Lock()
+	for _, chainRuntime := range r.GetAllChainsRuntime() {
+		if !chainRuntime.Provider.Config().Enabled() {
+			continue
+		}
+		paused := r.listenPaused(chainRuntime.Provider.NID())
+		switch {
+		case paused && !chainRuntime.isStopped():
+			chainRuntime.log.Info("pausing chain listener")
+			chainRuntime.draining.Store(true)
+			chainRuntime.stop()
+			stopping = append(stopping, chainRuntime)
+		case !paused && chainRuntime.isStopped():
+			// resumed by the next check once the listener returned
+			if chainRuntime.draining.Load() {
+				continue
+			}
+			chainRuntime.log.Info("resuming chain listener")
+			if err := r.resumeChain(ctx, chainRuntime); err != nil {
+				chainRuntime.log.Error("failed to resume chain listener", zap.Error(err))
+			}
+		}
+	}
+	r.lifecycleMu.Unlock()
+
+	for _, chainRuntime := range stopping {
+		r.waitChain(ctx, chainRuntime)
+		chainRuntime.draining.Store(false)
+	}
+}
+
+// resumeChain restarts the listener of a paused chain. The provider of the
+// stopped listener may hold cancelled clients, so a new one is built from the
+// config when it is known
+func (r *Relayer) resumeChain(ctx context.Context, chainRuntime *ChainRuntime) error {
+	nid := chainRuntime.Provider.NID()
+	chain := NewChain(r.log, chainRuntime.Provider, false)
+	rebuilt := false
+	if r.loadedConfig != nil {
+		if cfg, ok := r.loadedConfig.Chains[nid]; ok && cfg.Build != nil {
+			next, err := cfg.Build(r.buildContext(ctx))
+			if err != nil {
+				return fmt.Errorf("failed to build chain %s: %w", nid, err)
+			}
+			chain, rebuilt = next, true
+		}
+	}
+	restarted, err := r.replaceChainRuntime(chainRuntime, chain)
+	if err != nil {
+		if rebuilt {
+			closeProvider(chainRuntime.log, chain.ChainProvider)
+		}
+		return err
+	}
+	if rebuilt {
+		closeProvider(chainRuntime.log, chainRuntime.Provider)
+	}
+	r.startChain(restarted)
+	return nil
+}
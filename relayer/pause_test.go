@@ -0,0 +1,84 @@
+package relayer
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/chains/mockchain"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// slowProvider is a mock chain whose listener returns once released
+type slowProvider struct {
+	*costProvider
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *slowProvider) Listener(context.Context, types.LastProcessedTx, chan *types.BlockInfo) error {
+	close(p.started)
+	<-p.release
+	return nil
+}
+
+func TestPauseListener(t *testing.T) {
+	src := &slowProvider{costProvider: newCostProvider(t, "mock-1", 0, 0), started: make(chan struct{}), release: make(chan struct{})}
+	dst := newCostProvider(t, "mock-2", 0, 0)
+	rly := newTestRelayer(t, filepath.Join(t.TempDir(), "db"), src.costProvider, dst)
+	rly.chains["mock-1"].Provider = src
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rly.runCtx = ctx
+
+	var builds int
+	rebuilt := newCostProvider(t, "mock-1", 0, 0)
+	rly.SetConfigLoader(nil, &LoadedConfig{Chains: map[string]*ChainConfig{
+		"mock-1": {
+			Type:   src.Type(),
+			Config: &mockchain.MockProviderConfig{NId: "mock-1"},
+			Build: func(context.Context) (*Chain, error) {
+				builds++
+				return NewChain(zap.NewNop(), rebuilt, false), nil
+			},
+		},
+	}})
+	srcRuntime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	rly.startChain(srcRuntime)
+	<-src.started
+
+	paused := make(chan error, 1)
+	go func() {
+		paused <- rly.Pause(ctx, &types.Pause{Kind: types.PauseListen, Src: "mock-1"})
+	}()
+
+	// the lifecycle is not locked while waiting for the listener
+	assert.Eventually(t, srcRuntime.isStopped, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		if !rly.lifecycleMu.TryLock() {
+			return false
+		}
+		rly.lifecycleMu.Unlock()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the chain is not resumed before its listener returned
+	require.NoError(t, rly.Resume(ctx, types.PauseListen, "mock-1", ""))
+	assert.Zero(t, builds)
+
+	close(src.release)
+	require.NoError(t, <-paused)
+
+	// the resumed chain listens with a new provider
+	rly.applyPauses(ctx)
+	assert.Equal(t, 1, builds)
+	resumed, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	assert.Same(t, rebuilt, resumed.Provider)
+	assert.False(t, resumed.isStopped())
+}
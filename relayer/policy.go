@@ -0,0 +1,62 @@
+package relayer
+
+import (
+	"context"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/policy"
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+var prefixDeniedMessageStore = "denied"
+
+// SetPolicy enables the routing policy rules
+func (r *Relayer) SetPolicy(cfg *policy.Config) error {
+	engine, err := policy.NewEngine(r.log.With(zap.String("component", "policy")), cfg)
+	if err != nil {
+		return err
+	}
+	r.policy = engine
+	r.deniedStore = store.NewMessageStore(r.db, prefixDeniedMessageStore)
+	return nil
+}
+
+// StartPolicyWatcher reloads the policy rules when the file changes
+func (r *Relayer) StartPolicyWatcher(ctx context.Context) {
+	if r.policy == nil {
+		return
+	}
+	r.policy.Watch(ctx)
+}
+
+// applyPolicy evaluates the message once per rules version and returns
+// false when the message must not be routed now
+func (r *Relayer) applyPolicy(message *types.RouteMessage, src *ChainRuntime) bool {
+	if message.PolicyVersion == r.policy.Version() {
+		return true
+	}
+	decision := r.policy.Evaluate(message.Message)
+	message.PolicyVersion = decision.Version
+	message.Priority = decision.Priority
+	r.log.Info("policy decision",
+		zap.String("src", message.Src),
+		zap.String("dst", message.Dst),
+		zap.Any("sn", message.Sn),
+		zap.String("event_type", message.EventType),
+		zap.String("rule", decision.Rule),
+		zap.String("action", decision.Action),
+		zap.Duration("delay", decision.Delay),
+		zap.Int("priority", decision.Priority),
+	)
+	switch decision.Action {
+	case policy.ActionDeny:
+		r.moveMessage(message, src, r.deniedStore)
+		return false
+	case policy.ActionDelay:
+		message.LastTry = time.Now().Add(decision.Delay)
+		return false
+	}
+	return true
+}
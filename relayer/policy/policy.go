@@ -0,0 +1,320 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ActionAllow routes the message
+	ActionAllow = "allow"
+	// ActionDeny moves the message out of the routing queue
+	ActionDeny = "deny"
+	// ActionDelay postpones the first routing attempt by the rule delay
+	ActionDelay = "delay"
+	// ActionPrioritise routes the message ahead of the lower priority messages
+	ActionPrioritise = "prioritise"
+
+	DefaultRule           = "default"
+	DefaultReloadInterval = 10 * time.Second
+)
+
+// Config of the routing policy, the rules are read from File and reloaded on change
+type Config struct {
+	File           string        `yaml:"file" json:"file"`
+	ReloadInterval time.Duration `yaml:"reload-interval" json:"reload-interval"`
+}
+
+func (c *Config) Validate() error {
+	if c.File == "" {
+		return fmt.Errorf("policy: file is required")
+	}
+	return nil
+}
+
+func (c *Config) sanitize() {
+	if c.ReloadInterval == 0 {
+		c.ReloadInterval = DefaultReloadInterval
+	}
+}
+
+// Rules is the content of the policy file, the first matching rule wins
+type Rules struct {
+	// Default is the action of the messages matching no rule
+	Default string  `yaml:"default" json:"default"`
+	Rules   []*Rule `yaml:"rules" json:"rules"`
+}
+
+// Rule applies the action to the messages for which Expr evaluates to true
+type Rule struct {
+	Name     string        `yaml:"name" json:"name"`
+	Expr     string        `yaml:"expr" json:"expr"`
+	Action   string        `yaml:"action" json:"action"`
+	Delay    time.Duration `yaml:"delay" json:"delay"`
+	Priority int           `yaml:"priority" json:"priority"`
+}
+
+func (r *Rule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("policy: rule name is required")
+	}
+	if r.Expr == "" {
+		return fmt.Errorf("policy: expr is required for rule %s", r.Name)
+	}
+	if err := validateAction(r.Action); err != nil {
+		return fmt.Errorf("%w for rule %s", err, r.Name)
+	}
+	if r.Action == ActionDelay && r.Delay <= 0 {
+		return fmt.Errorf("policy: delay is required for rule %s", r.Name)
+	}
+	return nil
+}
+
+func validateAction(action string) error {
+	switch action {
+	case ActionAllow, ActionDeny, ActionDelay, ActionPrioritise:
+		return nil
+	}
+	return fmt.Errorf("policy: unknown action %q", action)
+}
+
+// Decision is the outcome of the policy evaluation of a message
+type Decision struct {
+	Rule     string        `json:"rule"`
+	Action   string        `json:"action"`
+	Delay    time.Duration `json:"delay,omitempty"`
+	Priority int           `json:"priority,omitempty"`
+	// Version of the rules which took the decision
+	Version uint64 `json:"version"`
+}
+
+type compiledRule struct {
+	*Rule
+	program cel.Program
+}
+
+type Engine struct {
+	cfg *Config
+	log *zap.Logger
+	env *cel.Env
+
+	mu      sync.RWMutex
+	rules   []*compiledRule
+	def     string
+	version uint64
+	modTime time.Time
+}
+
+// NewEngine compiles the rules of the policy file
+func NewEngine(log *zap.Logger, cfg *Config) (*Engine, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.sanitize()
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+	e := &Engine{cfg: cfg, log: log, env: env}
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("src", cel.StringType),
+		cel.Variable("dst", cel.StringType),
+		cel.Variable("event_type", cel.StringType),
+		cel.Variable("sn", cel.UintType),
+		cel.Variable("req_id", cel.UintType),
+		cel.Variable("data", cel.BytesType),
+		cel.Variable("data_size", cel.IntType),
+		cel.Variable("from", cel.StringType),
+		cel.Variable("to", cel.StringType),
+		cel.Variable("msg_type", cel.IntType),
+		// sn and req_id are compared with the int literals of the rules
+		cel.CrossTypeNumericComparisons(true),
+	)
+}
+
+// Reload reads and compiles the policy file, the current rules
+// are kept when the file is invalid
+func (e *Engine) Reload() error {
+	info, err := os.Stat(e.cfg.File)
+	if err != nil {
+		return fmt.Errorf("policy: %w", err)
+	}
+	file, err := os.ReadFile(e.cfg.File)
+	if err != nil {
+		return fmt.Errorf("policy: %w", err)
+	}
+	rules := new(Rules)
+	if err := yaml.Unmarshal(file, rules); err != nil {
+		return fmt.Errorf("policy: failed to parse %s: %w", e.cfg.File, err)
+	}
+	return e.load(rules, info.ModTime())
+}
+
+// Load replaces the rules
+func (e *Engine) Load(rules *Rules) error {
+	return e.load(rules, time.Time{})
+}
+
+func (e *Engine) load(rules *Rules, modTime time.Time) error {
+	def := rules.Default
+	if def == "" {
+		def = ActionAllow
+	}
+	if def != ActionAllow && def != ActionDeny {
+		return fmt.Errorf("policy: default must be %s or %s", ActionAllow, ActionDeny)
+	}
+	names := make(map[string]bool, len(rules.Rules))
+	compiled := make([]*compiledRule, 0, len(rules.Rules))
+	for _, rule := range rules.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("policy: duplicate rule %s", rule.Name)
+		}
+		names[rule.Name] = true
+		ast, issues := e.env.Compile(rule.Expr)
+		if issues != nil && issues.Err() != nil {
+			return fmt.Errorf("policy: rule %s: %w", rule.Name, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType {
+			return fmt.Errorf("policy: rule %s: expr must evaluate to bool", rule.Name)
+		}
+		program, err := e.env.Program(ast)
+		if err != nil {
+			return fmt.Errorf("policy: rule %s: %w", rule.Name, err)
+		}
+		compiled = append(compiled, &compiledRule{Rule: rule, program: program})
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules = compiled
+	e.def = def
+	e.modTime = modTime
+	e.version++
+	return nil
+}
+
+// Version is incremented on every successful reload
+func (e *Engine) Version() uint64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.version
+}
+
+// Watch reloads the rules when the policy file changes
+func (e *Engine) Watch(ctx context.Context) {
+	ticker := time.NewTicker(e.cfg.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(e.cfg.File)
+			if err != nil {
+				e.log.Warn("failed to stat policy file", zap.String("file", e.cfg.File), zap.Error(err))
+				continue
+			}
+			e.mu.RLock()
+			changed := !info.ModTime().Equal(e.modTime)
+			e.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := e.Reload(); err != nil {
+				e.log.Error("failed to reload policy, keeping the current rules", zap.Error(err))
+				continue
+			}
+			e.log.Info("policy reloaded", zap.String("file", e.cfg.File), zap.Uint64("version", e.Version()))
+		}
+	}
+}
+
+// Evaluate returns the decision of the first rule matching the message
+func (e *Engine) Evaluate(msg *types.Message) *Decision {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	vars := activation(msg)
+	for _, rule := range e.rules {
+		out, _, err := rule.program.Eval(vars)
+		if err != nil {
+			e.log.Debug("policy rule evaluation failed", zap.String("rule", rule.Name), zap.Error(err))
+			continue
+		}
+		if matched, ok := out.Value().(bool); !ok || !matched {
+			continue
+		}
+		return &Decision{
+			Rule:     rule.Name,
+			Action:   rule.Action,
+			Delay:    rule.Delay,
+			Priority: rule.Priority,
+			Version:  e.version,
+		}
+	}
+	return &Decision{Rule: DefaultRule, Action: e.def, Version: e.version}
+}
+
+// activation maps the message and its xCall payload to the rule variables, data
+// is the payload of the call request when decoded and the raw message otherwise
+func activation(msg *types.Message) map[string]any {
+	data := msg.Data
+	if data == nil {
+		data = []byte{}
+	}
+	vars := map[string]any{
+		"src":        msg.Src,
+		"dst":        msg.Dst,
+		"event_type": msg.EventType,
+		"sn":         uint64(0),
+		"req_id":     uint64(0),
+		"data":       data,
+		"data_size":  int64(len(data)),
+		"from":       "",
+		"to":         "",
+		"msg_type":   int64(0),
+	}
+	// the numbers overflowing 64 bits are left unbound, the rules reading
+	// them fail to evaluate instead of matching a truncated value
+	for name, value := range map[string]*big.Int{"sn": msg.Sn, "req_id": msg.ReqID} {
+		switch {
+		case value == nil:
+		case value.IsUint64():
+			vars[name] = value.Uint64()
+		default:
+			delete(vars, name)
+		}
+	}
+	if msg.EventType == events.EmitMessage {
+		if xcall, err := DecodeXcallMessage(msg.Data); err == nil {
+			vars["from"] = xcall.From
+			vars["to"] = xcall.To
+			vars["msg_type"] = int64(xcall.MsgType)
+			if xcall.Type == CSMessageRequest {
+				vars["data"] = xcall.Data
+				vars["data_size"] = int64(len(xcall.Data))
+			}
+		}
+	}
+	return vars
+}
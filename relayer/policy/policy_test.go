@@ -0,0 +1,134 @@
+package policy
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func xcallRequest(t *testing.T, from, to string, data []byte) []byte {
+	payload, err := rlp.EncodeToBytes([]any{from, to, big.NewInt(1), uint64(0), data, []string{}})
+	assert.NoError(t, err)
+	msg, err := rlp.EncodeToBytes([]any{CSMessageRequest, payload})
+	assert.NoError(t, err)
+	return msg
+}
+
+func TestDecodeXcallMessage(t *testing.T) {
+	xcall, err := DecodeXcallMessage(xcallRequest(t, "0x2.icon/hxabc", "0xa4b1.arbitrum/0xdef", []byte("hello")))
+	assert.NoError(t, err)
+	assert.Equal(t, CSMessageRequest, xcall.Type)
+	assert.Equal(t, "0x2.icon/hxabc", xcall.From)
+	assert.Equal(t, "0xa4b1.arbitrum/0xdef", xcall.To)
+	assert.Equal(t, []byte("hello"), xcall.Data)
+
+	_, err = DecodeXcallMessage([]byte("not rlp"))
+	assert.Error(t, err)
+}
+
+func TestEngine(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	rules := `
+default: allow
+rules:
+  - name: large-payload
+    expr: data_size > 16
+    action: deny
+  - name: slow-archway
+    expr: dst == "archway" && event_type == "emitMessage"
+    action: delay
+    delay: 5m
+  - name: vip
+    expr: from.startsWith("0x2.icon/hxabc")
+    action: prioritise
+    priority: 10
+`
+	assert.NoError(t, os.WriteFile(file, []byte(rules), 0o644))
+	engine, err := NewEngine(zap.NewNop(), &Config{File: file})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), engine.Version())
+
+	msg := &types.Message{Src: "0x2.icon", Dst: "avalanche", Sn: big.NewInt(1), EventType: events.EmitMessage}
+
+	t.Run("default", func(t *testing.T) {
+		msg.Data = xcallRequest(t, "0x2.icon/hxother", "avalanche/0xdef", nil)
+		d := engine.Evaluate(msg)
+		assert.Equal(t, DefaultRule, d.Rule)
+		assert.Equal(t, ActionAllow, d.Action)
+	})
+
+	t.Run("prioritise", func(t *testing.T) {
+		msg.Data = xcallRequest(t, "0x2.icon/hxabc", "avalanche/0xdef", nil)
+		d := engine.Evaluate(msg)
+		assert.Equal(t, "vip", d.Rule)
+		assert.Equal(t, 10, d.Priority)
+	})
+
+	t.Run("first match wins", func(t *testing.T) {
+		msg.Data = xcallRequest(t, "0x2.icon/hxabc", "avalanche/0xdef", make([]byte, 32))
+		d := engine.Evaluate(msg)
+		assert.Equal(t, "large-payload", d.Rule)
+		assert.Equal(t, ActionDeny, d.Action)
+	})
+
+	t.Run("delay", func(t *testing.T) {
+		d := engine.Evaluate(&types.Message{Src: "0x2.icon", Dst: "archway", EventType: events.EmitMessage})
+		assert.Equal(t, ActionDelay, d.Action)
+		assert.Equal(t, 5*time.Minute, d.Delay)
+	})
+
+	t.Run("invalid rules are not loaded", func(t *testing.T) {
+		err := engine.Load(&Rules{Rules: []*Rule{{Name: "bad", Expr: "data_size", Action: ActionDeny}}})
+		assert.Error(t, err)
+		err = engine.Load(&Rules{Rules: []*Rule{{Name: "bad", Expr: "dst == 1", Action: ActionDeny}}})
+		assert.Error(t, err)
+		assert.Equal(t, uint64(1), engine.Version())
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(file, []byte("default: deny\n"), 0o644))
+		assert.NoError(t, engine.Reload())
+		assert.Equal(t, uint64(2), engine.Version())
+		d := engine.Evaluate(msg)
+		assert.Equal(t, ActionDeny, d.Action)
+		assert.Equal(t, uint64(2), d.Version)
+	})
+}
+
+func TestEngineLargeNumbers(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	rules := `
+default: allow
+rules:
+  - name: high-sn
+    expr: sn > 9223372036854775807
+    action: deny
+  - name: old-req
+    expr: req_id < 100
+    action: delay
+    delay: 1m
+`
+	assert.NoError(t, os.WriteFile(file, []byte(rules), 0o644))
+	engine, err := NewEngine(zap.NewNop(), &Config{File: file})
+	assert.NoError(t, err)
+
+	sn, _ := new(big.Int).SetString("18446744073709551615", 10)
+	d := engine.Evaluate(&types.Message{Src: "0x2.icon", Dst: "avalanche", Sn: sn, ReqID: big.NewInt(5)})
+	assert.Equal(t, "high-sn", d.Rule)
+
+	d = engine.Evaluate(&types.Message{Src: "0x2.icon", Dst: "avalanche", Sn: big.NewInt(1), ReqID: big.NewInt(5)})
+	assert.Equal(t, "old-req", d.Rule)
+
+	// the numbers overflowing 64 bits do not match a truncated value
+	huge, _ := new(big.Int).SetString("18446744073709551621", 10)
+	d = engine.Evaluate(&types.Message{Src: "0x2.icon", Dst: "avalanche", Sn: huge, ReqID: huge})
+	assert.Equal(t, DefaultRule, d.Rule)
+}
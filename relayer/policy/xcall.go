@@ -0,0 +1,74 @@
+package policy
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	CSMessageRequest uint64 = 1
+	CSMessageResult  uint64 = 2
+)
+
+// XcallMessage is the decoded xCall payload carried by the emitted messages
+type XcallMessage struct {
+	Type    uint64
+	From    string
+	To      string
+	Sn      *big.Int
+	MsgType uint64
+	Data    []byte
+}
+
+type csMessage struct {
+	Type    uint64
+	Payload []byte
+}
+
+type csMessageRequest struct {
+	From        string
+	To          string
+	Sn          *big.Int
+	MessageType rlp.RawValue
+	Data        []byte
+	Rest        []rlp.RawValue `rlp:"tail"`
+}
+
+type csMessageResult struct {
+	Sn   *big.Int
+	Rest []rlp.RawValue `rlp:"tail"`
+}
+
+// DecodeXcallMessage decodes the rlp encoded CSMessage, the request fields
+// are only set for the call requests
+func DecodeXcallMessage(data []byte) (*XcallMessage, error) {
+	msg := new(csMessage)
+	if err := rlp.DecodeBytes(data, msg); err != nil {
+		return nil, err
+	}
+	xcall := &XcallMessage{Type: msg.Type}
+	switch msg.Type {
+	case CSMessageRequest:
+		req := new(csMessageRequest)
+		if err := rlp.DecodeBytes(msg.Payload, req); err != nil {
+			return nil, err
+		}
+		xcall.From = req.From
+		xcall.To = req.To
+		xcall.Sn = req.Sn
+		xcall.Data = req.Data
+		// older versions encode the rollback flag in place of the message type
+		var msgType uint64
+		if err := rlp.DecodeBytes(req.MessageType, &msgType); err == nil {
+			xcall.MsgType = msgType
+		}
+	case CSMessageResult:
+		res := new(csMessageResult)
+		if err := rlp.DecodeBytes(msg.Payload, res); err != nil {
+			return nil, err
+		}
+		xcall.Sn = res.Sn
+	}
+	return xcall, nil
+}
@@ -0,0 +1,285 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/fee"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+var (
+	prefixParkedMessageStore = "parked"
+	prefixDecisionStore      = "decision"
+)
+
+// routeFeeTTL is how long the fee of a route is reused for the new messages
+const routeFeeTTL = time.Minute
+
+// routeFees caches the fee of the connection per route, so the listener does
+// not query the fee of every message it receives
+type routeFees struct {
+	fees map[string]*routeFee
+	*sync.Mutex
+}
+
+type routeFee struct {
+	fee        *big.Int
+	updated    time.Time
+	refreshing bool
+}
+
+func newRouteFees() *routeFees {
+	return &routeFees{
+		fees:  make(map[string]*routeFee),
+		Mutex: new(sync.Mutex),
+	}
+}
+
+// get returns the cached fee of the route, refresh is set when the fee is
+// missing or expired and no refresh is running
+func (f *routeFees) get(src, dst string) (fee *big.Int, refresh bool) {
+	f.Lock()
+	defer f.Unlock()
+	entry, ok := f.fees[src+"-"+dst]
+	if !ok {
+		entry = new(routeFee)
+		f.fees[src+"-"+dst] = entry
+	}
+	if entry.fee == nil || time.Since(entry.updated) > routeFeeTTL {
+		refresh = !entry.refreshing
+		entry.refreshing = true
+	}
+	return entry.fee, refresh
+}
+
+// set stores the fee of the route, a nil fee only ends the refresh
+func (f *routeFees) set(src, dst string, fee *big.Int) {
+	f.Lock()
+	defer f.Unlock()
+	entry, ok := f.fees[src+"-"+dst]
+	if !ok {
+		entry = new(routeFee)
+		f.fees[src+"-"+dst] = entry
+	}
+	entry.refreshing = false
+	if fee != nil {
+		entry.fee = fee
+		entry.updated = time.Now()
+	}
+}
+
+// SetProfitabilityCheck enables the check of the fee paid against the
+// estimated delivery cost before routing the messages
+func (r *Relayer) SetProfitabilityCheck(cfg *fee.ProfitabilityConfig) error {
+	checker, err := fee.NewProfitabilityChecker(cfg)
+	if err != nil {
+		return err
+	}
+	r.profitability = checker
+	r.parkedStore = store.NewMessageStore(r.db, prefixParkedMessageStore)
+	r.decisionStore = store.NewDecisionStore(r.db, prefixDecisionStore)
+	return nil
+}
+
+// shouldCheckProfitability returns true if the message is subject to the profitability check,
+// only the messages paid by the connection fee are checked, the unparked
+// messages were released by the operator
+func (r *Relayer) shouldCheckProfitability(message *types.RouteMessage) bool {
+	return r.profitability != nil && r.profitability.Config().Enabled &&
+		message.EventType == events.EmitMessage && !message.Unparked
+}
+
+// cachedMessageFee sets the fee of the route to the message when it is cached,
+// an expired fee is refreshed in the background so the listener never waits
+func (r *Relayer) cachedMessageFee(ctx context.Context, src *ChainRuntime, message *types.Message) {
+	fee, refresh := r.routeFees.get(src.Provider.NID(), message.Dst)
+	if fee != nil {
+		message.Fee = fee
+	}
+	if refresh {
+		go func() {
+			if _, err := r.routeFee(ctx, src, message.Dst); err != nil {
+				r.log.Warn("failed to get route fee", zap.String("dst", message.Dst), zap.Error(err))
+			}
+		}()
+	}
+}
+
+// routeFee queries the fee of the route from the connection contract on src
+func (r *Relayer) routeFee(ctx context.Context, src *ChainRuntime, dst string) (*big.Int, error) {
+	paid, err := src.Provider.GetFee(ctx, dst, false)
+	if err != nil {
+		r.routeFees.set(src.Provider.NID(), dst, nil)
+		return nil, err
+	}
+	fee := new(big.Int).SetUint64(paid)
+	r.routeFees.set(src.Provider.NID(), dst, fee)
+	return fee, nil
+}
+
+// messageFee returns the fee paid for the message on src, it is queried
+// from the connection contract when not already known
+func (r *Relayer) messageFee(ctx context.Context, src *ChainRuntime, message *types.Message) (*big.Int, error) {
+	if message.Fee != nil {
+		return message.Fee, nil
+	}
+	fee, err := r.routeFee(ctx, src, message.Dst)
+	if err != nil {
+		return nil, err
+	}
+	message.Fee = fee
+	return message.Fee, nil
+}
+
+// routeIfProfitable routes the message if the fee paid covers the delivery cost,
+// otherwise the message is deferred or parked according to the route policy
+func (r *Relayer) routeIfProfitable(ctx context.Context, message *types.RouteMessage, dst, src *ChainRuntime) {
+	decision, err := r.checkProfitability(ctx, message, dst, src)
+	if err != nil {
+		dst.log.Warn("profitability check failed, routing message",
+			zap.String("src", message.Src),
+			zap.Any("sn", message.Sn),
+			zap.Error(err),
+		)
+		r.RouteMessage(ctx, message, dst, src)
+		return
+	}
+	if decision == nil || decision.Profitable() {
+		if decision != nil && decision.Reason != "" {
+			dst.log.Info("routing unprofitable message", zap.Any("decision", decision))
+		}
+		r.removeDecision(message.MessageKey())
+		r.RouteMessage(ctx, message, dst, src)
+		return
+	}
+
+	cfg := r.profitability.Config()
+	if decision.Action == fee.ActionDefer {
+		message.Defers++
+		if message.Defers > cfg.MaxDefers {
+			decision.Action = fee.ActionPark
+			decision.Reason = fmt.Sprintf("%s after %d checks", decision.Reason, cfg.MaxDefers)
+		}
+	}
+	if err := r.decisionStore.StoreDecision(decision); err != nil {
+		r.log.Error("error occured when storing profitability decision", zap.Error(err))
+	}
+	dst.log.Info("skipping unprofitable message",
+		zap.String("src", message.Src),
+		zap.Any("sn", message.Sn),
+		zap.Stringer("fee_paid", decision.FeePaid),
+		zap.Stringer("cost", decision.Cost),
+		zap.String("action", decision.Action),
+		zap.Uint8("defers", message.Defers),
+	)
+	switch decision.Action {
+	case fee.ActionPark:
+		r.parkMessage(ctx, message, src)
+	default:
+		message.LastTry = time.Now().Add(cfg.DeferInterval)
+		message.ToggleProcessing()
+		// the deferral is kept across restarts
+		if err := r.messageStore.StoreMessage(message); err != nil {
+			r.log.Error("error occured when storing deferred message", zap.Error(err))
+		}
+	}
+}
+
+// checkProfitability returns nil decision when the destination cannot estimate the cost
+func (r *Relayer) checkProfitability(ctx context.Context, message *types.RouteMessage, dst, src *ChainRuntime) (*fee.Decision, error) {
+	estimator, ok := dst.Provider.(provider.CostEstimator)
+	if !ok {
+		return nil, nil
+	}
+	feePaid, err := r.messageFee(ctx, src, message.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee paid: %w", err)
+	}
+	cost, err := estimator.EstimateCost(ctx, message.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate cost: %w", err)
+	}
+	return r.profitability.Check(ctx, message.Message, feePaid, cost)
+}
+
+// parkMessage moves the message from the routing queue to the parked store
+func (r *Relayer) parkMessage(ctx context.Context, message *types.RouteMessage, src *ChainRuntime) {
+	r.moveMessage(message, src, r.parkedStore)
+}
+
+// moveMessage removes the message from the routing queue and keeps it in the given store
+func (r *Relayer) moveMessage(message *types.RouteMessage, src *ChainRuntime, to *store.MessageStore) {
+	message.Processing = false
+	if err := to.StoreMessage(message); err != nil {
+		r.log.Error("error occured when moving message out of the routing queue", zap.Error(err))
+		return
+	}
+	src.MessageCache.Remove(message.MessageKey())
+	if err := r.messageStore.DeleteMessage(message.MessageKey()); err != nil {
+		r.log.Error("error occured when deleting moved message from db", zap.Error(err))
+	}
+}
+
+// removeDecision forgets the decision of the message once it leaves the queue
+func (r *Relayer) removeDecision(key *types.MessageKey) {
+	if r.decisionStore == nil {
+		return
+	}
+	if err := r.decisionStore.DeleteDecision(key); err != nil {
+		r.log.Error("error occured when deleting profitability decision", zap.Error(err))
+	}
+}
+
+// GetSkippedMessages returns the messages skipped by the profitability check,
+// filtered by the src chain when provided
+func (r *Relayer) GetSkippedMessages(src string) ([]*fee.Decision, error) {
+	if r.profitability == nil {
+		return nil, fmt.Errorf("profitability check is not configured")
+	}
+	decisions, err := r.decisionStore.GetDecisions(src)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(decisions, func(i, j int) bool {
+		return decisions[i].Timestamp < decisions[j].Timestamp
+	})
+	return decisions, nil
+}
+
+// UnparkMessage moves the parked message back to the routing queue, it is
+// routed without the profitability check
+func (r *Relayer) UnparkMessage(key *types.MessageKey) (*types.RouteMessage, error) {
+	if r.profitability == nil {
+		return nil, fmt.Errorf("profitability check is not configured")
+	}
+	src, err := r.FindChainRuntime(key.Src)
+	if err != nil {
+		return nil, err
+	}
+	message, err := r.parkedStore.GetMessage(key)
+	if err != nil {
+		return nil, fmt.Errorf("parked message not found: %w", err)
+	}
+	message.Unparked = true
+	message.Processing = false
+	message.Defers = 0
+	message.LastTry = time.Now()
+	if err := r.messageStore.StoreMessage(message); err != nil {
+		return nil, err
+	}
+	if err := r.parkedStore.DeleteMessage(key); err != nil {
+		return nil, err
+	}
+	r.removeDecision(key)
+	src.MessageCache.Add(message)
+	return message, nil
+}
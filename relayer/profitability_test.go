@@ -0,0 +1,163 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/chains/mockchain"
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/fee"
+	"github.com/icon-project/centralized-relay/relayer/lvldb"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// costProvider is a mock chain with a connection fee and a delivery cost
+type costProvider struct {
+	*mockchain.MockProvider
+	fee      uint64
+	cost     *big.Int
+	feeCalls atomic.Int32
+	// release blocks the fee queries until it is closed
+	release chan struct{}
+}
+
+func (p *costProvider) GetFee(context.Context, string, bool) (uint64, error) {
+	p.feeCalls.Add(1)
+	if p.release != nil {
+		<-p.release
+	}
+	return p.fee, nil
+}
+
+func (p *costProvider) EstimateCost(context.Context, *types.Message) (*big.Int, error) {
+	return p.cost, nil
+}
+
+func newCostProvider(t *testing.T, nid string, fee uint64, cost int64) *costProvider {
+	cfg := &mockchain.MockProviderConfig{NId: nid}
+	p, err := cfg.NewProvider(context.Background(), zap.NewNop(), "", false, nid)
+	require.NoError(t, err)
+	return &costProvider{MockProvider: p.(*mockchain.MockProvider), fee: fee, cost: big.NewInt(cost)}
+}
+
+func newProfitabilityRelayer(t *testing.T, dbPath string, src, dst *costProvider) *Relayer {
+	rly := newTestRelayer(t, dbPath, src, dst)
+	prices := filepath.Join(t.TempDir(), "prices.yaml")
+	require.NoError(t, os.WriteFile(prices, []byte("mock-1: {price: 1, decimals: 18}\nmock-2: {price: 1, decimals: 18}\n"), 0o600))
+	require.NoError(t, rly.SetProfitabilityCheck(&fee.ProfitabilityConfig{
+		Enabled:   true,
+		MaxDefers: 2,
+		PriceFeed: &fee.PriceConfig{Type: "static", File: prices},
+	}))
+	return rly
+}
+
+func emitMessage(sn int64) *types.Message {
+	return &types.Message{Src: "mock-1", Dst: "mock-2", Sn: big.NewInt(sn), EventType: events.EmitMessage, MessageHeight: 10}
+}
+
+func TestProcessBlockInfoRouteFee(t *testing.T) {
+	src := newCostProvider(t, "mock-1", 100, 10)
+	src.release = make(chan struct{})
+	rly := newProfitabilityRelayer(t, filepath.Join(t.TempDir(), "db"), src, newCostProvider(t, "mock-2", 0, 10))
+	srcRuntime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// the listener does not wait for the fee of the route
+	done := make(chan struct{})
+	go func() {
+		rly.processBlockInfo(ctx, srcRuntime, &types.BlockInfo{Height: 10, Messages: []*types.Message{emitMessage(1), emitMessage(2)}})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener blocked by the fee query")
+	}
+	msg, ok := srcRuntime.MessageCache.Get(emitMessage(1).MessageKey())
+	require.True(t, ok)
+	assert.Nil(t, msg.Fee)
+
+	// a single query refreshes the fee of the route
+	close(src.release)
+	assert.Eventually(t, func() bool {
+		fee, _ := rly.routeFees.get("mock-1", "mock-2")
+		return fee != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	rly.processBlockInfo(ctx, srcRuntime, &types.BlockInfo{Height: 11, Messages: []*types.Message{emitMessage(3)}})
+	msg, ok = srcRuntime.MessageCache.Get(emitMessage(3).MessageKey())
+	require.True(t, ok)
+	assert.Equal(t, big.NewInt(100), msg.Fee)
+	assert.Equal(t, int32(1), src.feeCalls.Load())
+}
+
+func TestUnprofitableMessageParked(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "db")
+	src, dst := newCostProvider(t, "mock-1", 100, 0), newCostProvider(t, "mock-2", 0, 1000)
+	rly := newProfitabilityRelayer(t, dbPath, src, dst)
+	srcRuntime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	dstRuntime, err := rly.FindChainRuntime("mock-2")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	message := types.NewRouteMessage(emitMessage(1))
+	srcRuntime.MessageCache.Add(message)
+	require.NoError(t, rly.messageStore.StoreMessage(message))
+
+	// the deferrals are stored with the message
+	for i := 1; i <= 2; i++ {
+		message.ToggleProcessing()
+		rly.routeIfProfitable(ctx, message, dstRuntime, srcRuntime)
+		stored, err := rly.messageStore.GetMessage(message.MessageKey())
+		require.NoError(t, err)
+		assert.Equal(t, uint8(i), stored.Defers)
+		assert.True(t, stored.LastTry.After(time.Now()))
+	}
+
+	// the message is parked after max-defers checks
+	message.ToggleProcessing()
+	rly.routeIfProfitable(ctx, message, dstRuntime, srcRuntime)
+	_, err = rly.messageStore.GetMessage(message.MessageKey())
+	assert.Error(t, err)
+	_, err = rly.parkedStore.GetMessage(message.MessageKey())
+	require.NoError(t, err)
+	_, ok := srcRuntime.MessageCache.Get(message.MessageKey())
+	assert.False(t, ok)
+
+	// the decisions are kept across restarts
+	require.NoError(t, rly.db.(*lvldb.LVLDB).Close())
+	rly = newProfitabilityRelayer(t, dbPath, src, dst)
+	decisions, err := rly.GetSkippedMessages("mock-1")
+	require.NoError(t, err)
+	require.Len(t, decisions, 1)
+	assert.Equal(t, fee.ActionPark, decisions[0].Action)
+
+	// the unparked message is routed without the check
+	unparked, err := rly.UnparkMessage(&types.MessageKey{Src: "mock-1", Sn: big.NewInt(1)})
+	require.NoError(t, err)
+	assert.True(t, unparked.Unparked)
+	assert.False(t, rly.shouldCheckProfitability(unparked))
+	stored, err := rly.messageStore.GetMessage(message.MessageKey())
+	require.NoError(t, err)
+	assert.True(t, stored.Unparked)
+	srcRuntime, err = rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	_, ok = srcRuntime.MessageCache.Get(message.MessageKey())
+	assert.True(t, ok)
+	decisions, err = rly.GetSkippedMessages("")
+	require.NoError(t, err)
+	assert.Empty(t, decisions)
+
+	_, err = rly.UnparkMessage(&types.MessageKey{Src: "mock-1", Sn: big.NewInt(1)})
+	assert.ErrorContains(t, err, "parked message not found")
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/icon-project/centralized-relay/relayer/kms"
+	"github.com/icon-project/centralized-relay/relayer/rpcauth"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
 	"github.com/icon-project/centralized-relay/relayer/types"
 	"go.uber.org/zap"
 )
@@ -40,6 +43,84 @@ type ClusterChainVerifier interface {
 	VerifyMessage(ctx context.Context, messageKey *types.MessageKeyWithMessageHeight) ([]*types.Message, error)
 }
 
+// CostEstimator is implemented by the providers which can estimate the fee
+// of delivering a message before submitting it, the cost is denominated
+// in the smallest unit of the native coin
+type CostEstimator interface {
+	EstimateCost(ctx context.Context, message *types.Message) (*big.Int, error)
+}
+
+// TxWaiter is implemented by the providers which bound the wait for the
+// result of a transaction, counted from the first one sent for a message
+type TxWaiter interface {
+	MaxTxWait() time.Duration
+}
+
+// PendingTxChecker is implemented by the providers whose wallet sends its
+// transactions in order, a new leader sharing the wallet waits for them
+type PendingTxChecker interface {
+	// PendingTx returns true while a transaction of the wallet is not mined
+	PendingTx(ctx context.Context) (bool, error)
+}
+
+// FeeClaimer is implemented by the providers which read the amount of fees
+// claimed from the result of the claim transaction, in the smallest unit of
+// the native coin
+type FeeClaimer interface {
+	ClaimFeeAmount(ctx context.Context) (*big.Int, error)
+}
+
+// ConfigUpdater is implemented by the providers which apply the changes of
+// some config fields without a restart
+type ConfigUpdater interface {
+	// UpdatableFields returns the names of the fields applied by UpdateConfig
+	UpdatableFields() []string
+	// UpdateConfig applies the updatable fields of the config
+	UpdateConfig(cfg Config) error
+}
+
+// EndpointReader is a provider reading from a single rpc endpoint of the chain
+type EndpointReader struct {
+	// Endpoint names the endpoint in the logs and metrics
+	Endpoint string
+	Provider ChainProvider
+}
+
+// QuorumReader is implemented by the providers which can read from each of
+// their rpc endpoints, the readers confirm the reads of the provider and are
+// only used for queries
+type QuorumReader interface {
+	EndpointReaders(ctx context.Context) ([]*EndpointReader, error)
+}
+
+// ConfigChecker is implemented by the configs which check their fields
+// without connecting to the chain, every problem found is returned
+type ConfigChecker interface {
+	CheckConfig() []error
+}
+
+// NetworkChecker is implemented by the providers which can verify that their
+// rpc endpoints serve the network of the nid
+type NetworkChecker interface {
+	CheckNetwork(ctx context.Context) error
+}
+
+// NetworkDetector is implemented by the configs which can read the network of
+// their rpc endpoints before the provider is built. The network is the value
+// compared by NetworkChecker: the 0x prefixed chain id of the nid for evm and
+// icon, the chain id for cosmos, the network passphrase for stellar and the
+// chain identifier for sui
+type NetworkDetector interface {
+	DetectNetwork(ctx context.Context) (string, error)
+}
+
+// ContractChecker is implemented by the providers which can verify that their
+// contracts are deployed, the errors are keyed by the contract names and are
+// nil for the deployed contracts
+type ContractChecker interface {
+	CheckContracts(ctx context.Context) map[string]error
+}
+
 type ChainProvider interface {
 	ChainQuery
 	NID() string
@@ -69,15 +150,30 @@ type ChainProvider interface {
 	ClaimFee(context.Context) error
 }
 
+// RPCAuthConfig is the auth and http options of the rpc endpoints, shared by
+// the configs of all chain providers
+type RPCAuthConfig struct {
+	RPCAuth     *rpcauth.Auth        `json:"rpc-auth,omitempty" yaml:"rpc-auth,omitempty"`
+	HTTPOptions *rpcauth.HTTPOptions `json:"http-options,omitempty" yaml:"http-options,omitempty"`
+}
+
+// RPCAuthClient resolves the rpc-auth and http-options of the endpoints
+func (c *RPCAuthConfig) RPCAuthClient() (*rpcauth.Client, error) {
+	return rpcauth.New(c.RPCAuth, c.HTTPOptions)
+}
+
 // CommonConfig is the common configuration for all chain providers
 type CommonConfig struct {
+	RPCAuthConfig `json:",inline" yaml:",inline"`
 	ChainName     string                  `json:"-" yaml:"-"`
 	RPCUrl        string                  `json:"rpc-url" yaml:"rpc-url"`
+	RPCUrls       []string                `json:"rpc-urls,omitempty" yaml:"rpc-urls,omitempty"`
+	RPCPool       rpcpool.Config          `json:"rpc-pool,omitempty" yaml:"rpc-pool,omitempty"`
 	StartHeight   uint64                  `json:"start-height" yaml:"start-height"`
 	Address       string                  `json:"address" yaml:"address"`
 	Contracts     types.ContractConfigMap `json:"contracts" yaml:"contracts"`
 	FinalityBlock uint64                  `json:"finality-block" yaml:"finality-block"`
-	NID           string                  `json:"nid" yaml:"nid"`
+	NID           string                  `json:"nid" yaml:"nid" jsonschema:"required"`
 	Decimals      int                     `json:"decimals" yaml:"decimals"`
 	HomeDir       string                  `json:"-" yaml:"-"`
 	Disabled      bool                    `json:"disabled" yaml:"disabled"`
@@ -89,6 +185,11 @@ func (c *CommonConfig) Enabled() bool {
 	return !c.Disabled
 }
 
+// RPCEndpoints returns the rpc-url followed by the fallback rpc-urls
+func (pc *CommonConfig) RPCEndpoints() []string {
+	return rpcpool.Merge(pc.RPCUrl, pc.RPCUrls)
+}
+
 func (pc *CommonConfig) SetWallet(addr string) {
 	pc.Address = addr
 }
@@ -0,0 +1,212 @@
+package relayer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/quorum"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+type quorumEntry struct {
+	provider provider.ChainProvider
+	readers  []*provider.EndpointReader
+	checker  *quorum.Checker
+}
+
+// close releases the clients and pools of the readers of the entry
+func (e *quorumEntry) close(log *zap.Logger) {
+	for _, reader := range e.readers {
+		closeProvider(log, reader.Provider)
+	}
+}
+
+// SetQuorum confirms the messages detected by the listeners, the message
+// received checks and the finality receipts on several endpoints of the chains
+func (r *Relayer) SetQuorum(cfg *quorum.Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if !cfg.Enabled {
+		return nil
+	}
+	r.quorum = cfg
+	r.quorumCheckers = make(map[string]*quorumEntry)
+	return nil
+}
+
+// quorumCovers returns true if the reads of the chain are confirmed by a quorum
+func (r *Relayer) quorumCovers(chain *ChainRuntime) bool {
+	return r.quorum != nil && r.quorum.Covers(chain.Provider.NID())
+}
+
+// quorumChecker returns the checker of the chain, nil when its reads are not
+// confirmed, the readers are connected on the first use and again when the
+// provider is replaced by a config reload
+func (r *Relayer) quorumChecker(ctx context.Context, chain *ChainRuntime) (*quorum.Checker, error) {
+	if !r.quorumCovers(chain) {
+		return nil, nil
+	}
+	nid := chain.Provider.NID()
+	r.quorumMu.Lock()
+	defer r.quorumMu.Unlock()
+	if entry, ok := r.quorumCheckers[nid]; ok {
+		if entry.provider == chain.Provider {
+			return entry.checker, nil
+		}
+		// the provider was replaced, its readers are connected again
+		entry.close(chain.log)
+	}
+	entry := &quorumEntry{provider: chain.Provider}
+	r.quorumCheckers[nid] = entry
+
+	quorumReader, ok := chain.Provider.(provider.QuorumReader)
+	if !ok {
+		chain.log.Warn("quorum reads are not supported by the chain, reading from a single endpoint")
+		return nil, nil
+	}
+	readers, err := quorumReader.EndpointReaders(ctx)
+	if err != nil {
+		delete(r.quorumCheckers, nid)
+		return nil, fmt.Errorf("failed to connect the quorum endpoints: %w", err)
+	}
+	entry.readers = readers
+	if len(readers) < 2 {
+		chain.log.Warn("quorum reads need several endpoints, reading from a single endpoint")
+		return nil, nil
+	}
+	entry.checker = quorum.NewChecker(nid, r.quorum.Threshold, readers, chain.log)
+	chain.log.Info("quorum reads enabled",
+		zap.Int("endpoints", entry.checker.Endpoints()),
+		zap.Int("threshold", entry.checker.Threshold()),
+	)
+	return entry.checker, nil
+}
+
+// dropQuorumChecker closes the readers of a removed chain
+func (r *Relayer) dropQuorumChecker(log *zap.Logger, nid string) {
+	if r.quorum == nil {
+		return
+	}
+	r.quorumMu.Lock()
+	defer r.quorumMu.Unlock()
+	if entry, ok := r.quorumCheckers[nid]; ok {
+		entry.close(log)
+		delete(r.quorumCheckers, nid)
+	}
+}
+
+// confirmMessage routes the message once a quorum of the endpoints of the src
+// chain emitted it, the message is dropped when the quorum does not find it.
+// The endpoints behind the height of the message do not vote, so a quorum of
+// lagging endpoints retries the confirmation rather than dropping the message
+func (r *Relayer) confirmMessage(ctx context.Context, message *types.RouteMessage, src *ChainRuntime) {
+	defer message.ToggleProcessing()
+	checker, err := r.quorumChecker(ctx, src)
+	if err != nil {
+		src.log.Error("failed to confirm message", zap.Any("sn", message.Sn), zap.Error(err))
+		message.LastTry = time.Now().Add(types.RetryInterval)
+		return
+	}
+	if checker == nil {
+		message.Confirmed = true
+		return
+	}
+	found, err := quorum.Vote(ctx, checker, quorum.CheckMessage, func(ctx context.Context, p provider.ChainProvider) (bool, error) {
+		height, err := p.QueryLatestHeight(ctx)
+		if err != nil {
+			return false, err
+		}
+		if height < message.MessageHeight {
+			return false, fmt.Errorf("endpoint at height %d is behind the message height %d", height, message.MessageHeight)
+		}
+		messages, err := p.GenerateMessages(ctx, message.MessageHeight, message.MessageHeight)
+		if err != nil {
+			return false, err
+		}
+		for _, m := range messages {
+			if sameMessage(m, message.Message) {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		src.log.Warn("message not confirmed by a quorum of the endpoints, retrying",
+			zap.String("dst", message.Dst),
+			zap.Any("sn", message.Sn),
+			zap.String("event_type", message.EventType),
+			zap.Uint64("height", message.MessageHeight),
+			zap.Error(err),
+		)
+		message.LastTry = time.Now().Add(types.RetryInterval)
+		return
+	}
+	if !found {
+		src.log.Error("message not found by a quorum of the endpoints, dropped",
+			zap.String("dst", message.Dst),
+			zap.Any("sn", message.Sn),
+			zap.String("event_type", message.EventType),
+			zap.Uint64("height", message.MessageHeight),
+		)
+		r.ClearMessages(ctx, []*types.MessageKey{message.MessageKey()}, src)
+		return
+	}
+	message.Confirmed = true
+}
+
+// messageReceived checks if the dst chain received the message, on a quorum
+// of its endpoints when enabled
+func (r *Relayer) messageReceived(ctx context.Context, dst *ChainRuntime, message *types.Message) (bool, error) {
+	checker, err := r.quorumChecker(ctx, dst)
+	if err != nil {
+		return false, err
+	}
+	if checker == nil {
+		return dst.Provider.MessageReceived(ctx, message)
+	}
+	return quorum.Vote(ctx, checker, quorum.CheckMessageReceived, func(ctx context.Context, p provider.ChainProvider) (bool, error) {
+		return p.MessageReceived(ctx, message)
+	})
+}
+
+// transactionReceipt returns the receipt of the transaction, on a quorum of
+// the endpoints of the chain when enabled
+func (r *Relayer) transactionReceipt(ctx context.Context, chain *ChainRuntime, txHash string) (*types.Receipt, error) {
+	checker, err := r.quorumChecker(ctx, chain)
+	if err != nil {
+		return nil, err
+	}
+	if checker == nil {
+		return chain.Provider.QueryTransactionReceipt(ctx, txHash)
+	}
+	receipt, err := quorum.Vote(ctx, checker, quorum.CheckReceipt, func(ctx context.Context, p provider.ChainProvider) (types.Receipt, error) {
+		receipt, err := p.QueryTransactionReceipt(ctx, txHash)
+		if err != nil {
+			return types.Receipt{}, err
+		}
+		return *receipt, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &receipt, nil
+}
+
+// sameMessage returns true if the messages have the same key, request id and data
+func sameMessage(a, b *types.Message) bool {
+	return a.Src == b.Src && a.Dst == b.Dst && a.EventType == b.EventType &&
+		equalInt(a.Sn, b.Sn) && equalInt(a.ReqID, b.ReqID) && bytes.Equal(a.Data, b.Data)
+}
+
+func equalInt(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
@@ -0,0 +1,145 @@
+package quorum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/icon-project/centralized-relay/relayer/metrics"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"go.uber.org/zap"
+)
+
+const (
+	// CheckMessage confirms a message detected by the listener of the src chain
+	CheckMessage = "message"
+	// CheckMessageReceived confirms a message is received by the dst chain
+	CheckMessageReceived = "message-received"
+	// CheckReceipt confirms the receipt of a transaction after the finality
+	CheckReceipt = "receipt"
+)
+
+// ErrNoQuorum is returned when not enough endpoints returned the same value
+var ErrNoQuorum = errors.New("no quorum")
+
+// Config of the quorum reads
+type Config struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Threshold is the number of endpoints which must agree, a majority of the endpoints of the chain by default
+	Threshold int `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+	// Chains are the nids of the chains whose reads are confirmed, all when empty
+	Chains []string `yaml:"chains,omitempty" json:"chains,omitempty"`
+}
+
+// Validate checks the config values
+func (c *Config) Validate() error {
+	if c.Threshold < 0 {
+		return fmt.Errorf("quorum threshold cannot be negative")
+	}
+	return nil
+}
+
+// Covers returns true if the reads of the chain are confirmed
+func (c *Config) Covers(nid string) bool {
+	return c.Enabled && (len(c.Chains) == 0 || slices.Contains(c.Chains, nid))
+}
+
+// Checker confirms the reads of a chain on its endpoints
+type Checker struct {
+	chain     string
+	threshold int
+	readers   []*provider.EndpointReader
+	log       *zap.Logger
+}
+
+// NewChecker returns the checker of the readers of the chain, a threshold of 0
+// or above the number of readers is replaced by a majority of them
+func NewChecker(chain string, threshold int, readers []*provider.EndpointReader, log *zap.Logger) *Checker {
+	if threshold <= 0 || threshold > len(readers) {
+		threshold = len(readers)/2 + 1
+	}
+	return &Checker{
+		chain:     chain,
+		threshold: threshold,
+		readers:   readers,
+		log:       log,
+	}
+}
+
+// Threshold returns the number of endpoints which must agree
+func (c *Checker) Threshold() int {
+	return c.threshold
+}
+
+// Endpoints returns the number of endpoints read
+func (c *Checker) Endpoints() int {
+	return len(c.readers)
+}
+
+// Vote reads the value from every endpoint and returns the one returned by at
+// least the threshold of them, the endpoints which failed or returned another
+// value are logged and counted as disagreements
+func Vote[T comparable](ctx context.Context, c *Checker, check string, read func(ctx context.Context, p provider.ChainProvider) (T, error)) (T, error) {
+	type answer struct {
+		value T
+		err   error
+	}
+	answers := make([]answer, len(c.readers))
+	var wg sync.WaitGroup
+	for i, reader := range c.readers {
+		wg.Add(1)
+		go func(i int, reader *provider.EndpointReader) {
+			defer wg.Done()
+			answers[i].value, answers[i].err = read(ctx, reader.Provider)
+		}(i, reader)
+	}
+	wg.Wait()
+
+	var (
+		winner T
+		votes  int
+		counts = make(map[T]int)
+	)
+	for _, a := range answers {
+		if a.err != nil {
+			continue
+		}
+		counts[a.value]++
+		if counts[a.value] > votes {
+			winner, votes = a.value, counts[a.value]
+		}
+	}
+	agreed := votes >= c.threshold
+
+	if votes < len(c.readers) {
+		fields := []zap.Field{
+			zap.String("check", check),
+			zap.Int("votes", votes),
+			zap.Int("threshold", c.threshold),
+			zap.Any("agreed", winner),
+		}
+		for i, a := range answers {
+			if a.err == nil && a.value == winner {
+				continue
+			}
+			endpoint := c.readers[i].Endpoint
+			if a.err != nil {
+				fields = append(fields, zap.String(endpoint, a.err.Error()))
+			} else {
+				fields = append(fields, zap.Any(endpoint, a.value))
+			}
+			metrics.QuorumDisagreements.WithLabelValues(c.chain, check, endpoint).Inc()
+		}
+		c.log.Warn("quorum read disagreement", fields...)
+	}
+
+	if !agreed {
+		metrics.QuorumReads.WithLabelValues(c.chain, check, "failed").Inc()
+		var zero T
+		return zero, fmt.Errorf("%w: %d of %d endpoints agree, %d required", ErrNoQuorum, votes, len(c.readers), c.threshold)
+	}
+	metrics.QuorumReads.WithLabelValues(c.chain, check, "agreed").Inc()
+	return winner, nil
+}
@@ -0,0 +1,78 @@
+package quorum
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type endpoint struct {
+	provider.ChainProvider
+	received bool
+	err      error
+}
+
+func (e *endpoint) MessageReceived(ctx context.Context, msg *types.Message) (bool, error) {
+	return e.received, e.err
+}
+
+func newChecker(threshold int, endpoints ...*endpoint) *Checker {
+	readers := make([]*provider.EndpointReader, len(endpoints))
+	for i, e := range endpoints {
+		readers[i] = &provider.EndpointReader{Endpoint: string(rune('a' + i)), Provider: e}
+	}
+	return NewChecker("test", threshold, readers, zap.NewNop())
+}
+
+func messageReceived(ctx context.Context, p provider.ChainProvider) (bool, error) {
+	return p.MessageReceived(ctx, &types.Message{})
+}
+
+func TestVote(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("majority by default", func(t *testing.T) {
+		c := newChecker(0, &endpoint{}, &endpoint{}, &endpoint{})
+		assert.Equal(t, 2, c.Threshold())
+		c = newChecker(5, &endpoint{}, &endpoint{})
+		assert.Equal(t, 2, c.Threshold())
+	})
+
+	t.Run("agreement", func(t *testing.T) {
+		c := newChecker(0, &endpoint{received: true}, &endpoint{received: true}, &endpoint{received: true})
+		received, err := Vote(ctx, c, CheckMessageReceived, messageReceived)
+		assert.NoError(t, err)
+		assert.True(t, received)
+	})
+
+	t.Run("disagreement below the threshold", func(t *testing.T) {
+		c := newChecker(2, &endpoint{received: true}, &endpoint{received: false}, &endpoint{received: true})
+		received, err := Vote(ctx, c, CheckMessageReceived, messageReceived)
+		assert.NoError(t, err)
+		assert.True(t, received)
+	})
+
+	t.Run("failed endpoints do not vote", func(t *testing.T) {
+		c := newChecker(2, &endpoint{err: errors.New("down")}, &endpoint{received: false}, &endpoint{err: errors.New("down")})
+		_, err := Vote(ctx, c, CheckMessageReceived, messageReceived)
+		assert.ErrorIs(t, err, ErrNoQuorum)
+	})
+
+	t.Run("no quorum", func(t *testing.T) {
+		c := newChecker(3, &endpoint{received: true}, &endpoint{received: false}, &endpoint{received: true})
+		_, err := Vote(ctx, c, CheckMessageReceived, messageReceived)
+		assert.ErrorIs(t, err, ErrNoQuorum)
+	})
+}
+
+func TestCovers(t *testing.T) {
+	assert.False(t, (&Config{}).Covers("0x2.icon"))
+	assert.True(t, (&Config{Enabled: true}).Covers("0x2.icon"))
+	assert.False(t, (&Config{Enabled: true, Chains: []string{"sui"}}).Covers("0x2.icon"))
+	assert.Error(t, (&Config{Threshold: -1}).Validate())
+}
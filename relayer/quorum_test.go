@@ -0,0 +1,119 @@
+package relayer
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/quorum"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// endpointProvider is an endpoint of a mock chain at a height, emitting the messages
+type endpointProvider struct {
+	*costProvider
+	height   uint64
+	messages []*types.Message
+	closed   int
+}
+
+func (p *endpointProvider) Close() error {
+	p.closed++
+	return nil
+}
+
+func (p *endpointProvider) QueryLatestHeight(context.Context) (uint64, error) {
+	return p.height, nil
+}
+
+func (p *endpointProvider) GenerateMessages(context.Context, uint64, uint64) ([]*types.Message, error) {
+	return p.messages, nil
+}
+
+// quorumProvider is a mock chain read from several endpoints
+type quorumProvider struct {
+	*costProvider
+	endpoints []*endpointProvider
+}
+
+func (p *quorumProvider) EndpointReaders(context.Context) ([]*provider.EndpointReader, error) {
+	var readers []*provider.EndpointReader
+	for i, endpoint := range p.endpoints {
+		readers = append(readers, &provider.EndpointReader{Endpoint: string(rune('a' + i)), Provider: endpoint})
+	}
+	return readers, nil
+}
+
+func TestConfirmMessageLaggingEndpoints(t *testing.T) {
+	src := &quorumProvider{costProvider: newCostProvider(t, "mock-1", 0, 0)}
+	rly := newTestRelayer(t, filepath.Join(t.TempDir(), "db"), src, newCostProvider(t, "mock-2", 0, 0))
+	require.NoError(t, rly.SetQuorum(&quorum.Config{Enabled: true}))
+	srcRuntime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	message := types.NewRouteMessage(emitMessage(1))
+	srcRuntime.MessageCache.Add(message)
+	require.NoError(t, rly.messageStore.StoreMessage(message))
+	endpoint := func(height uint64, messages ...*types.Message) *endpointProvider {
+		return &endpointProvider{costProvider: newCostProvider(t, "mock-1", 0, 0), height: height, messages: messages}
+	}
+
+	// two of the three endpoints did not reach the height of the message yet
+	src.endpoints = []*endpointProvider{endpoint(10, emitMessage(1)), endpoint(9), endpoint(8)}
+	message.ToggleProcessing()
+	rly.confirmMessage(ctx, message, srcRuntime)
+	assert.False(t, message.Confirmed)
+	_, err = rly.messageStore.GetMessage(message.MessageKey())
+	assert.NoError(t, err)
+	_, ok := srcRuntime.MessageCache.Get(message.MessageKey())
+	assert.True(t, ok)
+
+	// once synced, the endpoints confirm the message
+	for _, e := range src.endpoints {
+		e.height, e.messages = 12, []*types.Message{emitMessage(1)}
+	}
+	message.ToggleProcessing()
+	rly.confirmMessage(ctx, message, srcRuntime)
+	assert.True(t, message.Confirmed)
+}
+
+func TestQuorumCheckerClosesReaders(t *testing.T) {
+	endpoints := func() []*endpointProvider {
+		return []*endpointProvider{
+			{costProvider: newCostProvider(t, "mock-1", 0, 0)},
+			{costProvider: newCostProvider(t, "mock-1", 0, 0)},
+		}
+	}
+	src := &quorumProvider{costProvider: newCostProvider(t, "mock-1", 0, 0), endpoints: endpoints()}
+	rly := newTestRelayer(t, filepath.Join(t.TempDir(), "db"), src, newCostProvider(t, "mock-2", 0, 0))
+	require.NoError(t, rly.SetQuorum(&quorum.Config{Enabled: true}))
+	srcRuntime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	checker, err := rly.quorumChecker(ctx, srcRuntime)
+	require.NoError(t, err)
+	require.NotNil(t, checker)
+
+	// the readers of a replaced provider are closed before connecting the new ones
+	replaced := &quorumProvider{costProvider: src.costProvider, endpoints: endpoints()}
+	srcRuntime.Provider = replaced
+	_, err = rly.quorumChecker(ctx, srcRuntime)
+	require.NoError(t, err)
+	for _, e := range src.endpoints {
+		assert.Equal(t, 1, e.closed)
+	}
+	for _, e := range replaced.endpoints {
+		assert.Equal(t, 0, e.closed)
+	}
+
+	// and the ones of a removed chain
+	rly.dropQuorumChecker(srcRuntime.log, "mock-1")
+	for _, e := range replaced.endpoints {
+		assert.Equal(t, 1, e.closed)
+	}
+}
@@ -3,12 +3,22 @@ package relayer
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/icon-project/centralized-relay/relayer/circuit"
+	"github.com/icon-project/centralized-relay/relayer/election"
 	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/fee"
+	"github.com/icon-project/centralized-relay/relayer/policy"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/quorum"
+	"github.com/icon-project/centralized-relay/relayer/rpcpool"
+	"github.com/icon-project/centralized-relay/relayer/scheduler"
+	"github.com/icon-project/centralized-relay/relayer/shard"
 	"github.com/icon-project/centralized-relay/relayer/store"
 	"github.com/icon-project/centralized-relay/relayer/types"
 	"go.uber.org/zap"
@@ -29,6 +39,8 @@ var (
 	prefixMessageStore  = "message"
 	prefixBlockStore    = "block"
 	prefixFinalityStore = "finality"
+	prefixCostStore     = "cost"
+	prefixClaimStore    = "claim"
 
 	prefixLastProcessedTx = "lastProcessedTx"
 )
@@ -36,6 +48,10 @@ var (
 // main start loop
 func (r *Relayer) Start(ctx context.Context, flushInterval time.Duration, fresh bool) (chan error, error) {
 	errorChan := make(chan error, 1)
+	// chains added by a config reload are started with this context and
+	// report their errors on this channel
+	r.runCtx = ctx
+	r.errCh = errorChan
 	// once flush completes then only start processing
 	if fresh {
 		// flush all the packet and then continue
@@ -55,6 +71,21 @@ func (r *Relayer) Start(ctx context.Context, flushInterval time.Duration, fresh
 	// responsible for checking finality
 	go r.StartFinalityProcessor(ctx)
 
+	// responsible for keeping the connection fees up to date
+	go r.StartFeeManager(ctx)
+
+	// responsible for reloading the routing policy
+	go r.StartPolicyWatcher(ctx)
+
+	// responsible for the maintenance windows of the paused chains
+	go r.StartPauseManager(ctx)
+
+	// responsible for the leadership of the active/passive mode
+	go r.StartElection(ctx)
+
+	// responsible for the registration of the shard in the shared manifest
+	go r.StartShardManifest(ctx)
+
 	return errorChan, nil
 }
 
@@ -72,7 +103,42 @@ type Relayer struct {
 	blockStore           *store.BlockStore
 	finalityStore        *store.FinalityStore
 	lastProcessedTxStore *store.LastProcessedTxStore
+	costStore            *store.CostStore
+	claimStore           *store.ClaimStore
 	clusterMode          ClusterMode
+	feeManager           *fee.Manager
+	profitability        *fee.ProfitabilityChecker
+	parkedStore          *store.MessageStore
+	decisionStore        *store.DecisionStore
+	routeFees            *routeFees
+	policy               *policy.Engine
+	deniedStore          *store.MessageStore
+	scheduler            *scheduler.Scheduler
+	circuitConfig        *circuit.Config
+	chainsMu             sync.RWMutex
+	runCtx               context.Context
+	errCh                chan error
+	configLoader         ConfigLoader
+	loadedConfig         *LoadedConfig
+	// removedChains are the chains removed by a config reload, the messages
+	// to them are kept until they are added back
+	removedChains map[string]bool
+	// lifecycleMu serialises the start and stop of the chains
+	lifecycleMu sync.Mutex
+	pauseStore  *store.PauseStore
+	pauses      *pauses
+	elector     *election.Elector
+	takeover    *takeover
+	// shard limits the routing to the owned routes
+	shard         *shard.Config
+	shardManifest *shard.Manifest
+	// quorum confirms the reads on several endpoints of the chains
+	quorum         *quorum.Config
+	quorumMu       sync.Mutex
+	quorumCheckers map[string]*quorumEntry
+	// startedAt bounds the queued time of the stored messages, the wait
+	// before a restart does not count towards the starvation of a message
+	startedAt time.Time
 }
 
 func NewRelayer(log *zap.Logger, db store.Store, chains map[string]*Chain, fresh bool, clusterMode ClusterMode) (*Relayer, error) {
@@ -95,22 +161,26 @@ func NewRelayer(log *zap.Logger, db store.Store, chains map[string]*Chain, fresh
 	// last processed tx store
 	lastProcessedTxStore := store.NewLastProcessedTxStore(db, prefixLastProcessedTx)
 
+	// delivery cost store
+	costStore := store.NewCostStore(db, prefixCostStore)
+
+	// fee claim history store
+	claimStore := store.NewClaimStore(db, prefixClaimStore)
+
+	// paused chains and routes
+	pauseStore := store.NewPauseStore(db, prefixPauseStore)
+	storedPauses, err := pauseStore.GetPauses()
+	if err != nil {
+		return nil, err
+	}
+
 	chainRuntimes := make(map[string]*ChainRuntime, len(chains))
 	for _, chain := range chains {
-		chainRuntime, err := NewChainRuntime(log, chain)
+		chainRuntime, err := newChainRuntime(log, chain, blockStore)
 		if err != nil {
 			return nil, err
 		}
-
-		lastSavedHeight, err := blockStore.GetLastStoredBlock(chain.NID())
-		if err == nil {
-			// successfully fetched last savedBlock
-			chainRuntime.LastSavedHeight = lastSavedHeight
-		}
 		chainRuntimes[chain.NID()] = chainRuntime
-		chainRuntime.Provider.SetLastSavedHeightFunc(func() uint64 {
-			return chainRuntime.LastSavedHeight
-		})
 	}
 
 	return &Relayer{
@@ -121,10 +191,36 @@ func NewRelayer(log *zap.Logger, db store.Store, chains map[string]*Chain, fresh
 		blockStore:           blockStore,
 		finalityStore:        finalityStore,
 		lastProcessedTxStore: lastProcessedTxStore,
+		costStore:            costStore,
+		claimStore:           claimStore,
 		clusterMode:          clusterMode,
+		routeFees:            newRouteFees(),
+		scheduler:            scheduler.Default(),
+		startedAt:            time.Now(),
+		removedChains:        make(map[string]bool),
+		pauseStore:           pauseStore,
+		pauses:               newPauses(storedPauses),
 	}, nil
 }
 
+// newChainRuntime builds the runtime of the chain resuming from the last saved height
+func newChainRuntime(log *zap.Logger, chain *Chain, blockStore *store.BlockStore) (*ChainRuntime, error) {
+	chainRuntime, err := NewChainRuntime(log, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	lastSavedHeight, err := blockStore.GetLastStoredBlock(chain.NID())
+	if err == nil {
+		// successfully fetched last savedBlock
+		chainRuntime.LastSavedHeight = lastSavedHeight
+	}
+	chainRuntime.Provider.SetLastSavedHeightFunc(func() uint64 {
+		return chainRuntime.LastSavedHeight
+	})
+	return chainRuntime, nil
+}
+
 // GetBlockStore returns the block store
 func (r *Relayer) GetBlockStore() *store.BlockStore {
 	return r.blockStore
@@ -135,23 +231,28 @@ func (r *Relayer) GetMessageStore() *store.MessageStore {
 	return r.messageStore
 }
 
+// SetCircuitBreaker configures the circuit breakers of all the chains
+func (r *Relayer) SetCircuitBreaker(cfg *circuit.Config) {
+	r.circuitConfig = cfg
+	for _, chain := range r.GetAllChainsRuntime() {
+		chain.Circuit.Configure(cfg)
+	}
+}
+
 func (r *Relayer) StartChainListeners(ctx context.Context, errCh chan error) {
 	var eg errgroup.Group
 
-	for _, chainRuntime := range r.chains {
+	for _, chainRuntime := range r.GetAllChainsRuntime() {
 		if !chainRuntime.Provider.Config().Enabled() {
 			continue
 		}
+		if r.listenPaused(chainRuntime.Provider.NID()) {
+			chainRuntime.stop()
+			continue
+		}
+		chainRuntime.wg.Add(1)
 		eg.Go(func() error {
-			lastProcessedTxInfo, err := r.lastProcessedTxStore.Get(chainRuntime.Provider.NID())
-			if err != nil {
-				r.log.Warn("failed to get last processed tx", zap.Error(err), zap.String("nid", chainRuntime.Provider.NID()))
-			}
-			lastProcessedTx := types.LastProcessedTx{
-				Height: chainRuntime.LastSavedHeight,
-				Info:   lastProcessedTxInfo,
-			}
-			return chainRuntime.Provider.Listener(ctx, lastProcessedTx, chainRuntime.listenerChan)
+			return r.runListener(chainRuntime.run(ctx), chainRuntime)
 		})
 	}
 	if err := eg.Wait(); err != nil {
@@ -159,25 +260,41 @@ func (r *Relayer) StartChainListeners(ctx context.Context, errCh chan error) {
 	}
 }
 
+// runListener listens to the chain until it fails or is stopped, the caller
+// adds it to the wait group of the chain before starting it
+func (r *Relayer) runListener(ctx context.Context, chainRuntime *ChainRuntime) error {
+	defer chainRuntime.wg.Done()
+	lastProcessedTxInfo, err := r.lastProcessedTxStore.Get(chainRuntime.Provider.NID())
+	if err != nil {
+		r.log.Warn("failed to get last processed tx", zap.Error(err), zap.String("nid", chainRuntime.Provider.NID()))
+	}
+	lastProcessedTx := types.LastProcessedTx{
+		Height: chainRuntime.LastSavedHeight,
+		Info:   lastProcessedTxInfo,
+	}
+	// the rate limiters of the endpoints serve the listener first
+	ctx = rpcpool.WithPriority(ctx, rpcpool.PriorityListener)
+	err = chainRuntime.Provider.Listener(ctx, lastProcessedTx, chainRuntime.listenerChan)
+	if chainRuntime.isStopped() {
+		return nil
+	}
+	return err
+}
+
 func (r *Relayer) StartBlockProcessors(ctx context.Context, errorChan chan error) {
 	var eg errgroup.Group
 
-	for _, chainRuntime := range r.chains {
+	for _, chainRuntime := range r.GetAllChainsRuntime() {
 		if !chainRuntime.Provider.Config().Enabled() {
 			continue
 		}
+		if r.listenPaused(chainRuntime.Provider.NID()) {
+			chainRuntime.stop()
+			continue
+		}
+		chainRuntime.wg.Add(1)
 		eg.Go(func() error {
-			for {
-				select {
-				case <-ctx.Done():
-					return ctx.Err()
-				case blockInfo, ok := <-chainRuntime.listenerChan:
-					if !ok {
-						return fmt.Errorf("listener channel closed")
-					}
-					r.processBlockInfo(ctx, chainRuntime, blockInfo)
-				}
-			}
+			return r.runBlockProcessor(chainRuntime.run(ctx), chainRuntime)
 		})
 	}
 
@@ -186,6 +303,26 @@ func (r *Relayer) StartBlockProcessors(ctx context.Context, errorChan chan error
 	}
 }
 
+// runBlockProcessor processes the blocks of the chain listener until it is
+// stopped, the caller adds it to the wait group of the chain before starting it
+func (r *Relayer) runBlockProcessor(ctx context.Context, chainRuntime *ChainRuntime) error {
+	defer chainRuntime.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			if chainRuntime.isStopped() {
+				return nil
+			}
+			return ctx.Err()
+		case blockInfo, ok := <-chainRuntime.listenerChan:
+			if !ok {
+				return fmt.Errorf("listener channel closed")
+			}
+			r.processBlockInfo(ctx, chainRuntime, blockInfo)
+		}
+	}
+}
+
 func (r *Relayer) StartRouter(ctx context.Context, flushInterval time.Duration) {
 	routeTimer := time.NewTicker(types.RouteDuration)
 	flushTimer := time.NewTicker(1 * time.Second)
@@ -217,7 +354,7 @@ func (r *Relayer) StartRouter(ctx context.Context, flushInterval time.Duration)
 
 func (r *Relayer) flushMessages(ctx context.Context) {
 	r.log.Debug("flushing messages from db to cache")
-	for _, chain := range r.chains {
+	for _, chain := range r.GetAllChainsRuntime() {
 		nId := chain.Provider.NID()
 		messages, err := r.getActiveMessagesFromStore(nId, maxFlushMessage)
 		if err != nil {
@@ -244,7 +381,10 @@ func (r *Relayer) getActiveMessagesFromStore(nId string, maxMessages uint) ([]*t
 		return nil, err
 	}
 	for _, m := range msgs {
-		if !m.IsStale() {
+		if !m.IsStale() && r.ownsMessage(m.Message) {
+			if m.QueuedAt.Before(r.startedAt) {
+				m.QueuedAt = r.startedAt
+			}
 			activeMessages = append(activeMessages, m)
 		}
 	}
@@ -252,51 +392,100 @@ func (r *Relayer) getActiveMessagesFromStore(nId string, maxMessages uint) ([]*t
 }
 
 func (r *Relayer) processMessages(ctx context.Context) {
-	for _, src := range r.chains {
-		for _, message := range src.MessageCache.Messages {
-			dst, err := r.FindChainRuntime(message.Dst)
-			if err != nil {
-				r.log.Error("dst chain nid not found", zap.String("nid", message.Dst))
-				r.ClearMessages(ctx, []*types.MessageKey{message.MessageKey()}, src)
-				continue
-			}
+	// the standby keeps the messages until it takes over, the routing of the
+	// leader is cancelled when it loses the lease
+	ctx, ok := r.leaderContext(ctx)
+	if !ok {
+		return
+	}
+	// messages dispatched per destination in this cycle
+	dispatched := make(map[string]int)
+	// destinations with transactions in flight in this cycle
+	unsettled := make(map[string]bool)
+	for _, queued := range r.pendingMessages() {
+		message, src := queued.RouteMessage, queued.src
+		dst, err := r.FindChainRuntime(message.Dst)
+		if err != nil && r.chainRemoved(message.Dst) {
+			continue
+		}
+		if err != nil {
+			r.log.Error("dst chain nid not found", zap.String("nid", message.Dst))
+			r.ClearMessages(ctx, []*types.MessageKey{message.MessageKey()}, src)
+			continue
+		}
+		// stored before the shard changed, the owner routes it and the
+		// message stays in the store for a shard config owning it again
+		if !r.ownsMessage(message.Message) {
+			src.log.Debug("skipping message not owned by the shard",
+				zap.String("dst", message.Dst),
+				zap.Any("sn", message.Sn),
+				zap.String("event_type", message.EventType),
+			)
+			src.clearMessageFromCache([]*types.MessageKey{message.MessageKey()})
+			continue
+		}
+		if r.routePaused(message.Src, message.Dst) || !r.scheduler.CanDispatch(dispatched[message.Dst]) {
+			continue
+		}
+		// a new leader waits for the transactions in flight of the wallet
+		if unsettled[message.Dst] || !r.txSettled(ctx, dst) {
+			unsettled[message.Dst] = true
+			continue
+		}
 
-			if ok := dst.shouldSendMessage(ctx, message, src); !ok {
-				r.log.Debug("processing", zap.Any("message", message))
-				continue
-			}
+		if ok := dst.shouldSendMessage(ctx, message, src); !ok {
+			r.log.Debug("processing", zap.Any("message", message))
+			continue
+		}
+		// the listener reads are confirmed before the message is routed
+		if !message.Confirmed && r.quorumCovers(src) {
 			message.ToggleProcessing()
+			go r.confirmMessage(ctx, message, src)
+			continue
+		}
+		// the message keeps its retry budget while the circuit is open
+		if !dst.Circuit.Allow() {
+			continue
+		}
+		message.ToggleProcessing()
+		dispatched[message.Dst]++
 
-			if !r.clusterMode.IsEnabled() ||
-				(message.EventType == events.PacketAcknowledged &&
-					dst.Provider.Config().GetConnContract() != "" &&
-					dst.Provider.Config().Enabled()) {
-				messageReceived, err := dst.Provider.MessageReceived(ctx, message.Message)
-				if err != nil {
-					dst.log.Error("error occured when checking message received", zap.String("src", message.Src), zap.Any("sn", message.Sn), zap.Error(err))
-					message.ToggleProcessing()
-					continue
-				}
-				if messageReceived {
-					dst.log.Info("message already received",
-						zap.String("src", message.Src),
-						zap.String("dst", message.Dst),
-						zap.Any("sn", message.Sn),
-						zap.Any("req_id", message.ReqID),
-						zap.Any("event_type", message.EventType),
-					)
-					r.ClearMessages(ctx, []*types.MessageKey{message.MessageKey()}, src)
-					continue
+		if !r.clusterMode.IsEnabled() ||
+			(message.EventType == events.PacketAcknowledged &&
+				dst.Provider.Config().GetConnContract() != "" &&
+				dst.Provider.Config().Enabled()) {
+			messageReceived, err := r.messageReceived(ctx, dst, message.Message)
+			if err != nil {
+				dst.log.Error("error occured when checking message received", zap.String("src", message.Src), zap.Any("sn", message.Sn), zap.Error(err))
+				if circuit.IsTransportFailure(err) {
+					dst.Circuit.Failure()
 				}
+				message.ToggleProcessing()
+				continue
 			}
-
-			clusterEvents := []string{events.EmitMessage, events.PacketRegistered, events.PacketAcknowledged}
-			if r.clusterMode.IsEnabled() && slices.Contains(clusterEvents, message.EventType) {
-				r.processClusterEvents(ctx, message, dst, src)
-			} else {
-				go r.RouteMessage(ctx, message, dst, src)
+			if messageReceived {
+				// the chain answered, a probe of a half open circuit succeeds
+				dst.Circuit.Success()
+				dst.log.Info("message already received",
+					zap.String("src", message.Src),
+					zap.String("dst", message.Dst),
+					zap.Any("sn", message.Sn),
+					zap.Any("req_id", message.ReqID),
+					zap.Any("event_type", message.EventType),
+				)
+				r.ClearMessages(ctx, []*types.MessageKey{message.MessageKey()}, src)
+				continue
 			}
 		}
+
+		clusterEvents := []string{events.EmitMessage, events.PacketRegistered, events.PacketAcknowledged}
+		if r.clusterMode.IsEnabled() && slices.Contains(clusterEvents, message.EventType) {
+			r.processClusterEvents(ctx, message, dst, src)
+		} else if r.shouldCheckProfitability(message) {
+			go r.routeIfProfitable(ctx, message, dst, src)
+		} else {
+			go r.RouteMessage(ctx, message, dst, src)
+		}
 	}
 }
 
@@ -313,7 +502,7 @@ func (r *Relayer) processClusterEvents(ctx context.Context, message *types.Route
 		}
 		message.DstConnAddress = dst.Provider.Config().GetConnContract()
 		message.Message.SrcConnAddress = srcChainProvider.Provider.Config().GetConnContract()
-		iconChain := getIconChain(r.chains)
+		iconChain := getIconChain(r.GetAllChainsRuntime())
 		go r.processAcknowledgementMsg(ctx, message, srcChainProvider, dst, iconChain, true)
 	case events.PacketRegistered:
 		srcChainProvider, err := r.FindChainRuntime(message.Src)
@@ -322,7 +511,7 @@ func (r *Relayer) processClusterEvents(ctx context.Context, message *types.Route
 			r.ClearMessages(ctx, []*types.MessageKey{message.MessageKey()}, src)
 			return
 		}
-		iconChain := getIconChain(r.chains)
+		iconChain := getIconChain(r.GetAllChainsRuntime())
 		go r.processAcknowledgementMsg(ctx, message, srcChainProvider, dst, iconChain, false)
 	case events.PacketAcknowledged:
 		if dst.Provider.Config().Enabled() {
@@ -342,9 +531,21 @@ func (r *Relayer) processBlockInfo(ctx context.Context, src *ChainRuntime, block
 	src.LastBlockHeight = blockInfo.Height
 	for _, msg := range blockInfo.Messages {
 		msg := types.NewRouteMessage(msg)
-		src.MessageCache.Add(msg)
-		if err := r.messageStore.StoreMessage(msg); err != nil {
-			r.log.Error("failed to store a message in db", zap.Error(err))
+		if r.ownsMessage(msg.Message) {
+			// fee is recorded at emission as it may change until the message is routed
+			if r.shouldCheckProfitability(msg) {
+				r.cachedMessageFee(ctx, src, msg.Message)
+			}
+			src.MessageCache.Add(msg)
+			if err := r.messageStore.StoreMessage(msg); err != nil {
+				r.log.Error("failed to store a message in db", zap.Error(err))
+			}
+		} else {
+			src.log.Debug("skipping message not owned by the shard",
+				zap.String("dst", msg.Dst),
+				zap.Any("sn", msg.Sn),
+				zap.String("event_type", msg.EventType),
+			)
 		}
 		if err := r.lastProcessedTxStore.Set(src.Provider.NID(), msg.TxInfo); err != nil {
 			r.log.Error("failed to save last processed tx",
@@ -362,6 +563,8 @@ func (r *Relayer) SaveBlockHeight(ctx context.Context, chainRuntime *ChainRuntim
 }
 
 func (r *Relayer) FindChainRuntime(nId string) (*ChainRuntime, error) {
+	r.chainsMu.RLock()
+	defer r.chainsMu.RUnlock()
 	if chain, ok := r.chains[nId]; ok {
 		return chain, nil
 	}
@@ -369,6 +572,8 @@ func (r *Relayer) FindChainRuntime(nId string) (*ChainRuntime, error) {
 }
 
 func (r *Relayer) GetAllChainsRuntime() []*ChainRuntime {
+	r.chainsMu.RLock()
+	defer r.chainsMu.RUnlock()
 	var chains []*ChainRuntime
 	for _, chainRuntime := range r.chains {
 		chains = append(chains, chainRuntime)
@@ -407,8 +612,11 @@ func (r *Relayer) callback(ctx context.Context, src, dst *ChainRuntime) types.Tx
 				zap.String("dst", dst.Provider.NID()),
 				zap.String("event_type", key.EventType),
 				zap.String("tx_hash", response.TxHash),
+				zap.Strings("replacements", response.Replacements),
 				zap.Uint8("count", routeMessage.Retry),
 			)
+			dst.Circuit.Success()
+			r.storeDeliveryCost(key, dst, response, routeMessage.Fee)
 			if r.clusterMode.IsEnabled() && key.EventType == events.EmitMessage {
 				key.Dst = dst.Provider.NID()
 			}
@@ -430,11 +638,24 @@ func (r *Relayer) callback(ctx context.Context, src, dst *ChainRuntime) types.Tx
 				r.log.Error("error occured when clearing successful message", zap.Error(err))
 			}
 		} else {
+			r.storeDeliveryCost(key, dst, response, routeMessage.Fee)
 			r.HandleMessageFailed(routeMessage, dst, src, response.TxHash, err)
 		}
 	}
 }
 
+// storeDeliveryCost records the cost paid on dst for delivering the message
+func (r *Relayer) storeDeliveryCost(key *types.MessageKey, dst *ChainRuntime, response *types.TxResponse, feePaid *big.Int) {
+	if response == nil || response.TxFee == nil {
+		return
+	}
+	cost := types.NewDeliveryCost(key, dst.Provider.NID(), response)
+	cost.FeePaid = feePaid
+	if err := r.costStore.StoreCost(cost); err != nil {
+		r.log.Error("error occured when storing delivery cost", zap.Error(err))
+	}
+}
+
 func (r *Relayer) RouteMessage(ctx context.Context, m *types.RouteMessage, dst, src *ChainRuntime) {
 	m.IncrementRetry()
 	if err := dst.Provider.Route(ctx, m.Message, r.callback(ctx, src, dst)); err != nil {
@@ -452,6 +673,13 @@ func (r *Relayer) HandleMessageFailed(routeMessage *types.RouteMessage, dst, src
 		zap.Uint8("count", routeMessage.Retry),
 		zap.Error(err),
 	)
+	// only the failures to reach the chain count, a message reverting on
+	// every try must not stop the routing of the others
+	if circuit.IsTransportFailure(err) {
+		dst.Circuit.Failure()
+	} else {
+		dst.Circuit.Reached()
+	}
 	routeMessage.ToggleProcessing()
 	if routeMessage.Retry >= types.MaxTxRetry {
 		if err := r.messageStore.StoreMessage(routeMessage); err != nil {
@@ -473,6 +701,7 @@ func (r *Relayer) ClearMessages(ctx context.Context, msgs []*types.MessageKey, s
 	srcChain.clearMessageFromCache(msgs)
 
 	for _, m := range msgs {
+		r.removeDecision(m)
 		if err := r.messageStore.DeleteMessage(m); err != nil {
 			r.log.Error("error occured when deleting message from db ", zap.Error(err))
 			return err
@@ -493,7 +722,11 @@ func (r *Relayer) StartFinalityProcessor(ctx context.Context) {
 }
 
 func (r *Relayer) CheckFinality(ctx context.Context) {
-	for nid, c := range r.chains {
+	if !r.isLeader() {
+		return
+	}
+	for _, c := range r.GetAllChainsRuntime() {
+		nid := c.Provider.NID()
 		if !c.Provider.Config().Enabled() {
 			continue
 		}
@@ -529,7 +762,7 @@ func (r *Relayer) CheckFinality(ctx context.Context) {
 				}
 
 				// check if the txReceipt still exist
-				receipt, err := c.Provider.QueryTransactionReceipt(ctx, txObject.TxHash)
+				receipt, err := r.transactionReceipt(ctx, c, txObject.TxHash)
 				if err != nil {
 					r.log.Error("finality processor: queryTransactionReceipt ",
 						zap.Any("message key", txObject.MessageKey),
@@ -553,8 +786,8 @@ func (r *Relayer) CheckFinality(ctx context.Context) {
 					zap.String("tx hash on destination chain", txObject.TxHash))
 
 				// if receipt donot exist generate message again and send to src chain
-				srcChainRuntime, ok := r.chains[txObject.Src]
-				if !ok {
+				srcChainRuntime, err := r.FindChainRuntime(txObject.Src)
+				if err != nil {
 					r.log.Error("finality processor:  ",
 						zap.Any("message key", txObject.MessageKey),
 						zap.Error(err))
@@ -590,7 +823,8 @@ func (r *Relayer) CheckFinality(ctx context.Context) {
 func (r *Relayer) SaveChainsBlockHeight(ctx context.Context) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	for nid, chain := range r.chains {
+	for _, chain := range r.GetAllChainsRuntime() {
+		nid := chain.Provider.NID()
 		height, err := chain.Provider.QueryLatestHeight(ctx)
 		if err != nil {
 			r.log.Error("error occured when querying latest height", zap.String("nid", nid), zap.Error(err))
@@ -605,7 +839,8 @@ func (r *Relayer) SaveChainsBlockHeight(ctx context.Context) {
 
 // cleanExpiredMessages
 func (r *Relayer) cleanExpiredMessages(ctx context.Context) {
-	for nid, chain := range r.chains {
+	for _, chain := range r.GetAllChainsRuntime() {
+		nid := chain.Provider.NID()
 		p := store.NewPagination().WithLimit(maxFlushMessage)
 		messages, err := r.messageStore.GetMessages(nid, p)
 		if err != nil {
@@ -623,7 +858,7 @@ func (r *Relayer) cleanExpiredMessages(ctx context.Context) {
 	}
 }
 
-func getIconChain(chains map[string]*ChainRuntime) *ChainRuntime {
+func getIconChain(chains []*ChainRuntime) *ChainRuntime {
 	for _, v := range chains {
 		if v.Provider.Type() == "icon" && strings.Contains(v.Provider.NID(), "icon") {
 			return v
@@ -11,6 +11,7 @@ import (
 	"github.com/icon-project/centralized-relay/relayer/lvldb"
 	"github.com/icon-project/centralized-relay/relayer/provider"
 	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 )
@@ -77,6 +78,20 @@ func GetMockChainProvider(log *zap.Logger, blockDuration time.Duration, NId stri
 	return mock1ProviderConfig.NewProvider(context.Background(), log, "empty", false, NId)
 }
 
+// newTestRelayer returns a relayer of the two chains on a new database
+func newTestRelayer(t *testing.T, dbPath string, src, dst provider.ChainProvider) *Relayer {
+	db, err := lvldb.NewLvlDB(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	chains := map[string]*Chain{
+		src.NID(): NewChain(zap.NewNop(), src, false),
+		dst.NID(): NewChain(zap.NewNop(), dst, false),
+	}
+	rly, err := NewRelayer(zap.NewNop(), db, chains, false, nil)
+	require.NoError(t, err)
+	return rly
+}
+
 func (s *RelayTestSuite) SetupTest() {
 	logger, _ := zap.NewProduction()
 	db, err := lvldb.NewLvlDB(levelDbName)
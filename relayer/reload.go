@@ -0,0 +1,366 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/circuit"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+)
+
+// chainStopTimeout is the wait for the listener of a stopped chain to return
+var chainStopTimeout = 30 * time.Second
+
+// ConfigLoader reads the config file, the providers of the chains are not built
+type ConfigLoader func(ctx context.Context) (*LoadedConfig, error)
+
+// ChainConfig is the config of a chain as read from the config file
+type ChainConfig struct {
+	Type string
+	// Config is passed to the running provider when only its updatable fields changed
+	Config provider.Config
+	// Fields are the values of the config file, compared to find the changes
+	Fields map[string]any
+	// Build builds the chain, it is only called for the new and changed chains
+	Build func(ctx context.Context) (*Chain, error)
+}
+
+// LoadedConfig is the config file read by the ConfigLoader, the chains are keyed by nid
+type LoadedConfig struct {
+	Chains map[string]*ChainConfig
+	// Global are the values of the global section, only the circuit breaker
+	// is applied at runtime, the other changes need a restart
+	Global         map[string]any
+	CircuitBreaker *circuit.Config
+}
+
+// globalReloadable are the global fields applied by a reload
+var globalReloadable = map[string]bool{"circuit-breaker": true}
+
+// ChainChange lists the config fields changed on a chain
+type ChainChange struct {
+	Chain  string   `json:"chain"`
+	Fields []string `json:"fields"`
+}
+
+// ReloadReport is the outcome of a config reload, the updated chains applied
+// the changes without a restart and the changed ones were restarted
+type ReloadReport struct {
+	Added     []string       `json:"added"`
+	Removed   []string       `json:"removed"`
+	Updated   []*ChainChange `json:"updated"`
+	Changed   []*ChainChange `json:"changed"`
+	Unchanged []string       `json:"unchanged"`
+	// Global are the changed global fields applied, RestartRequired the ones
+	// which only apply after a restart
+	Global          []string `json:"global"`
+	RestartRequired []string `json:"restartRequired"`
+}
+
+// SetConfigLoader sets the loader used by ReloadConfig, current is the
+// config the relayer was started with
+func (r *Relayer) SetConfigLoader(loader ConfigLoader, current *LoadedConfig) {
+	r.configLoader = loader
+	r.loadedConfig = current
+}
+
+// ReloadConfig reads the config file and applies the changes
+func (r *Relayer) ReloadConfig(ctx context.Context) (*ReloadReport, error) {
+	if r.configLoader == nil {
+		return nil, fmt.Errorf("config reload is not supported")
+	}
+	loaded, err := r.configLoader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return r.ReloadChains(ctx, loaded)
+}
+
+// ReloadChains diffs the config against the running one. Removed chains are
+// stopped and their stored messages are kept, new chains are started, the
+// chains whose changes are all updatable fields apply them in place and the
+// other changed chains are restarted with a new provider keeping their
+// message cache, circuit breaker and heights
+func (r *Relayer) ReloadChains(ctx context.Context, loaded *LoadedConfig) (*ReloadReport, error) {
+	r.lifecycleMu.Lock()
+	defer r.lifecycleMu.Unlock()
+
+	buildCtx := r.buildContext(ctx)
+	current := r.loadedConfig
+	if current == nil {
+		current = new(LoadedConfig)
+	}
+	// the config of the chains is only replaced once applied, a chain
+	// failing to reload is compared to its running config the next time
+	applied := &LoadedConfig{
+		Chains:         make(map[string]*ChainConfig, len(loaded.Chains)),
+		Global:         loaded.Global,
+		CircuitBreaker: loaded.CircuitBreaker,
+	}
+	for nid, chain := range current.Chains {
+		applied.Chains[nid] = chain
+	}
+	defer func() {
+		r.loadedConfig = applied
+	}()
+
+	report := new(ReloadReport)
+	for _, field := range fieldChanges(current.Global, loaded.Global) {
+		if !globalReloadable[field] {
+			report.RestartRequired = append(report.RestartRequired, field)
+			continue
+		}
+		report.Global = append(report.Global, field)
+	}
+	if slices.Contains(report.Global, "circuit-breaker") {
+		r.SetCircuitBreaker(loaded.CircuitBreaker)
+	}
+	if len(report.RestartRequired) > 0 {
+		r.log.Warn("global config changed, restart the relayer to apply it", zap.Strings("fields", report.RestartRequired))
+	}
+
+	for _, running := range r.GetAllChainsRuntime() {
+		nid := running.Provider.NID()
+		chain, ok := loaded.Chains[nid]
+		if !ok {
+			r.stopChain(ctx, running)
+			closeProvider(running.log, running.Provider)
+			r.dropQuorumChecker(running.log, nid)
+			r.chainsMu.Lock()
+			delete(r.chains, nid)
+			r.removedChains[nid] = true
+			r.chainsMu.Unlock()
+			delete(applied.Chains, nid)
+			report.Removed = append(report.Removed, nid)
+			continue
+		}
+		previous, ok := current.Chains[nid]
+		if !ok {
+			previous = &ChainConfig{Type: running.Provider.Type()}
+		}
+		fields := fieldChanges(previous.Fields, chain.Fields)
+		if len(fields) == 0 && previous.Type == chain.Type {
+			applied.Chains[nid] = chain
+			report.Unchanged = append(report.Unchanged, nid)
+			continue
+		}
+		if updater, ok := running.Provider.(provider.ConfigUpdater); ok && previous.Type == chain.Type && updatable(updater, fields) {
+			if err := updater.UpdateConfig(chain.Config); err != nil {
+				return report, fmt.Errorf("failed to update config of %s: %w", nid, err)
+			}
+			applied.Chains[nid] = chain
+			report.Updated = append(report.Updated, &ChainChange{Chain: nid, Fields: fields})
+			continue
+		}
+		next, err := chain.Build(buildCtx)
+		if err != nil {
+			return report, fmt.Errorf("failed to build chain %s: %w", nid, err)
+		}
+		r.stopChain(ctx, running)
+		chainRuntime, err := r.replaceChainRuntime(running, next)
+		if err != nil {
+			closeProvider(running.log, next.ChainProvider)
+			return report, err
+		}
+		closeProvider(running.log, running.Provider)
+		r.startChain(chainRuntime)
+		applied.Chains[nid] = chain
+		report.Changed = append(report.Changed, &ChainChange{Chain: nid, Fields: fields})
+	}
+
+	for nid, chain := range loaded.Chains {
+		if _, err := r.FindChainRuntime(nid); err == nil {
+			continue
+		}
+		next, err := chain.Build(buildCtx)
+		if err != nil {
+			return report, fmt.Errorf("failed to build chain %s: %w", nid, err)
+		}
+		chainRuntime, err := newChainRuntime(r.log, next, r.blockStore)
+		if err != nil {
+			closeProvider(r.log, next.ChainProvider)
+			return report, err
+		}
+		chainRuntime.Circuit.Configure(r.circuitConfig)
+		r.chainsMu.Lock()
+		r.chains[nid] = chainRuntime
+		delete(r.removedChains, nid)
+		r.chainsMu.Unlock()
+		r.startChain(chainRuntime)
+		applied.Chains[nid] = chain
+		report.Added = append(report.Added, nid)
+	}
+
+	sort.Strings(report.Added)
+	sort.Strings(report.Removed)
+	sort.Strings(report.Unchanged)
+	sortChanges(report.Updated)
+	sortChanges(report.Changed)
+	r.log.Info("config reloaded",
+		zap.Strings("added", report.Added),
+		zap.Strings("removed", report.Removed),
+		zap.Any("updated", report.Updated),
+		zap.Any("changed", report.Changed),
+		zap.Strings("unchanged", report.Unchanged),
+		zap.Strings("global", report.Global),
+		zap.Strings("restart_required", report.RestartRequired),
+	)
+	return report, nil
+}
+
+// updatable returns true if all the fields are applied by the provider
+func updatable(updater provider.ConfigUpdater, fields []string) bool {
+	for _, field := range fields {
+		if !slices.Contains(updater.UpdatableFields(), field) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortChanges(changes []*ChainChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Chain < changes[j].Chain
+	})
+}
+
+// chainRemoved returns true if the chain was removed by a config reload
+func (r *Relayer) chainRemoved(nid string) bool {
+	r.chainsMu.RLock()
+	defer r.chainsMu.RUnlock()
+	return r.removedChains[nid]
+}
+
+// buildContext returns the context of the providers built at runtime. The
+// providers keep the context of their clients, so they are built with the
+// context of the relayer rather than the one of the request
+func (r *Relayer) buildContext(ctx context.Context) context.Context {
+	if r.runCtx != nil {
+		return r.runCtx
+	}
+	return ctx
+}
+
+// stopChain stops the listener of the chain and saves the last processed height
+func (r *Relayer) stopChain(ctx context.Context, chainRuntime *ChainRuntime) {
+	chainRuntime.stop()
+	r.waitChain(ctx, chainRuntime)
+}
+
+// waitChain waits for the stopped listener of the chain to return and saves
+// the last processed height
+func (r *Relayer) waitChain(ctx context.Context, chainRuntime *ChainRuntime) {
+	if !chainRuntime.wait(chainStopTimeout) {
+		chainRuntime.log.Warn("chain listener did not stop in time", zap.Duration("timeout", chainStopTimeout))
+	}
+	if chainRuntime.LastBlockHeight > 0 {
+		if err := r.SaveBlockHeight(ctx, chainRuntime, chainRuntime.LastBlockHeight); err != nil {
+			chainRuntime.log.Error("error occured when saving block height", zap.Error(err))
+		}
+	}
+}
+
+// closeProvider releases the resources of the provider, such as the health
+// checks and clients of its rpc pools or the process of the plugin chains
+func closeProvider(log *zap.Logger, p any) {
+	closer, ok := p.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		log.Warn("failed to close chain provider", zap.Error(err))
+	}
+}
+
+// replaceChainRuntime swaps the provider of the chain, the runtime state is carried over
+func (r *Relayer) replaceChainRuntime(old *ChainRuntime, chain *Chain) (*ChainRuntime, error) {
+	chainRuntime, err := NewChainRuntime(r.log, chain)
+	if err != nil {
+		return nil, err
+	}
+	chainRuntime.MessageCache = old.MessageCache
+	chainRuntime.Circuit = old.Circuit
+	chainRuntime.listenerChan = old.listenerChan
+	chainRuntime.LastSavedHeight = old.LastSavedHeight
+	chainRuntime.LastBlockHeight = old.LastBlockHeight
+	chainRuntime.Provider.SetLastSavedHeightFunc(func() uint64 {
+		return chainRuntime.LastSavedHeight
+	})
+
+	r.chainsMu.Lock()
+	defer r.chainsMu.Unlock()
+	r.chains[chain.NID()] = chainRuntime
+	return chainRuntime, nil
+}
+
+// startChain starts the listener and the block processor of a chain added at
+// runtime, their errors are sent to the error channel of Start like the ones
+// of the chains started with the relayer
+func (r *Relayer) startChain(chainRuntime *ChainRuntime) {
+	if r.runCtx == nil || !chainRuntime.Provider.Config().Enabled() {
+		return
+	}
+	if r.listenPaused(chainRuntime.Provider.NID()) {
+		chainRuntime.stop()
+		return
+	}
+	ctx := chainRuntime.run(r.runCtx)
+	chainRuntime.wg.Add(2)
+	go func() {
+		if err := r.runListener(ctx, chainRuntime); err != nil {
+			r.chainFailed(chainRuntime, err)
+		}
+	}()
+	go func() {
+		if err := r.runBlockProcessor(ctx, chainRuntime); err != nil {
+			r.chainFailed(chainRuntime, err)
+		}
+	}()
+}
+
+// chainFailed reports the error of a chain started at runtime
+func (r *Relayer) chainFailed(chainRuntime *ChainRuntime, err error) {
+	if r.errCh == nil {
+		chainRuntime.log.Error("chain stopped", zap.Error(err))
+		return
+	}
+	r.errCh <- err
+}
+
+// fieldChanges returns the names of the fields which differ
+func fieldChanges(a, b map[string]any) []string {
+	var fields []string
+	for name, value := range a {
+		if !reflect.DeepEqual(value, b[name]) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// ConfigFields returns the values of the config by their json names, they
+// must be read before the provider is built as it fills in the defaults
+func ConfigFields(cfg any) (map[string]any, error) {
+	out, err := jsoniter.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]any)
+	if err := jsoniter.Unmarshal(out, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
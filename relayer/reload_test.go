@@ -0,0 +1,147 @@
+package relayer
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/chains/mockchain"
+	"github.com/icon-project/centralized-relay/relayer/circuit"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// updatableProvider applies the changes of gas-limit without a restart
+type updatableProvider struct {
+	*costProvider
+	updates []provider.Config
+}
+
+func (p *updatableProvider) UpdatableFields() []string {
+	return []string{"gas-limit"}
+}
+
+func (p *updatableProvider) UpdateConfig(cfg provider.Config) error {
+	p.updates = append(p.updates, cfg)
+	return nil
+}
+
+// closingProvider counts the releases of the provider
+type closingProvider struct {
+	*costProvider
+	closed atomic.Int32
+}
+
+func (p *closingProvider) Close() error {
+	p.closed.Add(1)
+	return nil
+}
+
+// reloadChain returns the config of a chain of the file counting its builds
+func reloadChain(t *testing.T, p provider.ChainProvider, builds *int, fields map[string]any) *ChainConfig {
+	return &ChainConfig{
+		Type:   p.Type(),
+		Config: &mockchain.MockProviderConfig{NId: p.NID()},
+		Fields: fields,
+		Build: func(context.Context) (*Chain, error) {
+			*builds++
+			return NewChain(zap.NewNop(), p, false), nil
+		},
+	}
+}
+
+func TestReloadChains(t *testing.T) {
+	src := &updatableProvider{costProvider: newCostProvider(t, "mock-1", 0, 0)}
+	dst := &closingProvider{costProvider: newCostProvider(t, "mock-2", 0, 0)}
+	rly := newTestRelayer(t, filepath.Join(t.TempDir(), "db"), src.costProvider, dst)
+	rly.chains["mock-1"].Provider = src
+	stopTimeout := chainStopTimeout
+	chainStopTimeout = time.Second
+	t.Cleanup(func() { chainStopTimeout = stopTimeout })
+	ctx := context.Background()
+
+	var srcBuilds, dstBuilds int
+	current := &LoadedConfig{
+		Chains: map[string]*ChainConfig{
+			"mock-1": reloadChain(t, src, &srcBuilds, map[string]any{"nid": "mock-1", "gas-limit": 1.0}),
+			"mock-2": reloadChain(t, dst, &dstBuilds, map[string]any{"nid": "mock-2", "rpc-url": "a"}),
+		},
+		Global: map[string]any{"timeout": "10s"},
+	}
+	rly.SetConfigLoader(nil, current)
+
+	t.Run("updatable fields are applied in place", func(t *testing.T) {
+		loaded := &LoadedConfig{
+			Chains: map[string]*ChainConfig{
+				"mock-1": reloadChain(t, src, &srcBuilds, map[string]any{"nid": "mock-1", "gas-limit": 2.0}),
+				"mock-2": reloadChain(t, dst, &dstBuilds, map[string]any{"nid": "mock-2", "rpc-url": "a"}),
+			},
+			Global:         map[string]any{"timeout": "20s", "circuit-breaker": map[string]any{"threshold": 1.0}},
+			CircuitBreaker: &circuit.Config{Threshold: 1, Cooldown: time.Hour},
+		}
+		report, err := rly.ReloadChains(ctx, loaded)
+		require.NoError(t, err)
+		assert.Equal(t, []*ChainChange{{Chain: "mock-1", Fields: []string{"gas-limit"}}}, report.Updated)
+		assert.Equal(t, []string{"mock-2"}, report.Unchanged)
+		assert.Len(t, src.updates, 1)
+		// the providers of the unchanged and updated chains are not built
+		assert.Zero(t, srcBuilds)
+		assert.Zero(t, dstBuilds)
+
+		assert.Equal(t, []string{"circuit-breaker"}, report.Global)
+		assert.Equal(t, []string{"timeout"}, report.RestartRequired)
+		dstRuntime, err := rly.FindChainRuntime("mock-2")
+		require.NoError(t, err)
+		dstRuntime.Circuit.Failure()
+		assert.Equal(t, circuit.Open, dstRuntime.Circuit.State())
+	})
+
+	t.Run("changed chains are rebuilt", func(t *testing.T) {
+		loaded := &LoadedConfig{
+			Chains: map[string]*ChainConfig{
+				"mock-1": reloadChain(t, src, &srcBuilds, map[string]any{"nid": "mock-1", "gas-limit": 2.0}),
+				"mock-2": reloadChain(t, dst, &dstBuilds, map[string]any{"nid": "mock-2", "rpc-url": "b"}),
+			},
+			Global: map[string]any{"timeout": "20s", "circuit-breaker": map[string]any{"threshold": 1.0}},
+		}
+		report, err := rly.ReloadChains(ctx, loaded)
+		require.NoError(t, err)
+		assert.Equal(t, []*ChainChange{{Chain: "mock-2", Fields: []string{"rpc-url"}}}, report.Changed)
+		assert.Equal(t, []string{"mock-1"}, report.Unchanged)
+		assert.Empty(t, report.RestartRequired)
+		assert.Zero(t, srcBuilds)
+		assert.Equal(t, 1, dstBuilds)
+		// the replaced provider is released
+		assert.Equal(t, int32(1), dst.closed.Load())
+	})
+
+	t.Run("messages to removed chains are kept", func(t *testing.T) {
+		srcRuntime, err := rly.FindChainRuntime("mock-1")
+		require.NoError(t, err)
+		message := types.NewRouteMessage(emitMessage(1))
+		srcRuntime.MessageCache.Add(message)
+		require.NoError(t, rly.messageStore.StoreMessage(message))
+
+		loaded := &LoadedConfig{
+			Chains: map[string]*ChainConfig{
+				"mock-1": reloadChain(t, src, &srcBuilds, map[string]any{"nid": "mock-1", "gas-limit": 2.0}),
+			},
+			Global: map[string]any{"timeout": "20s", "circuit-breaker": map[string]any{"threshold": 1.0}},
+		}
+		report, err := rly.ReloadChains(ctx, loaded)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"mock-2"}, report.Removed)
+		assert.Equal(t, int32(2), dst.closed.Load())
+
+		rly.processMessages(ctx)
+		_, err = rly.messageStore.GetMessage(message.MessageKey())
+		assert.NoError(t, err)
+		_, ok := srcRuntime.MessageCache.Get(message.MessageKey())
+		assert.True(t, ok)
+	})
+}
@@ -0,0 +1,284 @@
+package rpcauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Auth of the requests sent to the rpc endpoints of a chain
+type Auth struct {
+	// Headers are sent with every request and websocket handshake
+	Headers map[string]Secret `yaml:"headers,omitempty" json:"headers,omitempty"`
+	// Basic sets the basic authorization
+	Basic *BasicAuth `yaml:"basic,omitempty" json:"basic,omitempty"`
+	// BearerToken sets a static bearer authorization
+	BearerToken *Secret `yaml:"bearer-token,omitempty" json:"bearer-token,omitempty"`
+	// JWT signs a new bearer token for every request
+	JWT *JWT `yaml:"jwt,omitempty" json:"jwt,omitempty"`
+}
+
+// BasicAuth is the username and password of the basic authorization
+type BasicAuth struct {
+	Username string `yaml:"username" json:"username"`
+	Password Secret `yaml:"password" json:"password"`
+}
+
+// JWT signs HS256 tokens issued at the time of the request, as required by
+// the authenticated rpc of the ethereum clients
+type JWT struct {
+	// Secret is hex encoded, the raw value is used when it is not
+	Secret Secret `yaml:"secret" json:"secret"`
+	// Claims are added to the iat claim of the tokens
+	Claims map[string]interface{} `yaml:"claims,omitempty" json:"claims,omitempty"`
+}
+
+// HTTPOptions of the connections to the rpc endpoints of a chain
+type HTTPOptions struct {
+	// Proxy is the url of the http proxy, the HTTP_PROXY and HTTPS_PROXY environment variables are used when empty
+	Proxy string `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+	// CAFile holds the pem certificates trusted in addition to the system ones
+	CAFile string `yaml:"ca-file,omitempty" json:"ca-file,omitempty"`
+	// CertFile and KeyFile hold the client certificate sent to the endpoints
+	CertFile string `yaml:"cert-file,omitempty" json:"cert-file,omitempty"`
+	KeyFile  string `yaml:"key-file,omitempty" json:"key-file,omitempty"`
+	// InsecureSkipVerify disables the verification of the certificates of the endpoints
+	InsecureSkipVerify bool `yaml:"insecure-skip-verify,omitempty" json:"insecure-skip-verify,omitempty"`
+}
+
+// Client applies the resolved auth and http options to the clients of the
+// endpoints, a nil client leaves them unchanged
+type Client struct {
+	header    http.Header
+	jwtSecret []byte
+	claims    map[string]interface{}
+	proxy     func(*http.Request) (*url.URL, error)
+	tlsConfig *tls.Config
+	// httpClient is the default client shared by the probes
+	httpClient *http.Client
+}
+
+// New resolves the secrets and loads the certificates of the config, it
+// returns nil when neither auth nor options are set
+func New(auth *Auth, opts *HTTPOptions) (*Client, error) {
+	if auth == nil && opts == nil {
+		return nil, nil
+	}
+	c := &Client{header: make(http.Header)}
+	if auth != nil {
+		if err := c.resolveAuth(auth); err != nil {
+			return nil, fmt.Errorf("rpc-auth: %w", err)
+		}
+	}
+	if opts != nil {
+		if err := c.loadOptions(opts); err != nil {
+			return nil, fmt.Errorf("http-options: %w", err)
+		}
+	}
+	c.httpClient = &http.Client{Transport: c.Wrap(nil)}
+	return c, nil
+}
+
+func (c *Client) resolveAuth(auth *Auth) error {
+	for name, secret := range auth.Headers {
+		value, err := secret.Resolve()
+		if err != nil {
+			return fmt.Errorf("header %s: %w", name, err)
+		}
+		c.header.Set(name, value)
+	}
+	if auth.Basic != nil {
+		password, err := auth.Basic.Password.Resolve()
+		if err != nil {
+			return fmt.Errorf("basic password: %w", err)
+		}
+		credentials := base64.StdEncoding.EncodeToString([]byte(auth.Basic.Username + ":" + password))
+		c.header.Set("Authorization", "Basic "+credentials)
+	}
+	if auth.BearerToken != nil {
+		token, err := auth.BearerToken.Resolve()
+		if err != nil {
+			return fmt.Errorf("bearer-token: %w", err)
+		}
+		c.header.Set("Authorization", "Bearer "+token)
+	}
+	if auth.JWT != nil {
+		secret, err := auth.JWT.Secret.Resolve()
+		if err != nil {
+			return fmt.Errorf("jwt secret: %w", err)
+		}
+		if secret == "" {
+			return fmt.Errorf("jwt secret is empty")
+		}
+		c.jwtSecret = []byte(secret)
+		if key, err := hex.DecodeString(strings.TrimPrefix(secret, "0x")); err == nil {
+			c.jwtSecret = key
+		}
+		c.claims = auth.JWT.Claims
+	}
+	return nil
+}
+
+func (c *Client) loadOptions(opts *HTTPOptions) error {
+	if opts.Proxy != "" {
+		proxy, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return fmt.Errorf("proxy: %w", err)
+		}
+		c.proxy = http.ProxyURL(proxy)
+	}
+	if opts.CAFile == "" && opts.CertFile == "" && !opts.InsecureSkipVerify {
+		return nil
+	}
+	c.tlsConfig = &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify}
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return fmt.Errorf("ca-file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("ca-file: no certificate found in %s", opts.CAFile)
+		}
+		c.tlsConfig.RootCAs = pool
+	}
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return fmt.Errorf("client certificate: %w", err)
+		}
+		c.tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return nil
+}
+
+// SetHeader sets the auth headers on h, a new token is signed for the jwt auth
+func (c *Client) SetHeader(h http.Header) error {
+	if c == nil {
+		return nil
+	}
+	for name, values := range c.header {
+		h[name] = append([]string(nil), values...)
+	}
+	if c.jwtSecret != nil {
+		token, err := c.token(time.Now())
+		if err != nil {
+			return err
+		}
+		h.Set("Authorization", "Bearer "+token)
+	}
+	return nil
+}
+
+// Header returns the auth headers of a websocket handshake
+func (c *Client) Header() (http.Header, error) {
+	h := make(http.Header)
+	return h, c.SetHeader(h)
+}
+
+func (c *Client) token(now time.Time) (string, error) {
+	claims := map[string]interface{}{"iat": now.Unix()}
+	for k, v := range c.claims {
+		claims[k] = v
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoding := base64.RawURLEncoding
+	unsigned := encoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, c.jwtSecret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + encoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (c *Client) authenticated() bool {
+	return len(c.header) > 0 || c.jwtSecret != nil
+}
+
+// Wrap returns base with the proxy and tls options, sending the requests with
+// the auth headers, base defaults to http.DefaultTransport
+func (c *Client) Wrap(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if c == nil {
+		return base
+	}
+	if t, ok := base.(*http.Transport); ok && (c.proxy != nil || c.tlsConfig != nil) {
+		t = t.Clone()
+		if c.proxy != nil {
+			t.Proxy = c.proxy
+		}
+		if c.tlsConfig != nil {
+			t.TLSClientConfig = c.tlsConfig.Clone()
+		}
+		base = t
+	}
+	if !c.authenticated() {
+		return base
+	}
+	return &transport{client: c, base: base}
+}
+
+// HTTPClient returns the http client over the default transport with the options and auth
+func (c *Client) HTTPClient() *http.Client {
+	if c == nil {
+		return http.DefaultClient
+	}
+	return c.httpClient
+}
+
+// WebsocketDialer returns the default websocket dialer with the proxy and tls options
+func (c *Client) WebsocketDialer() *websocket.Dialer {
+	dialer := *websocket.DefaultDialer
+	if c == nil {
+		return &dialer
+	}
+	if c.proxy != nil {
+		dialer.Proxy = c.proxy
+	}
+	if c.tlsConfig != nil {
+		dialer.TLSClientConfig = c.tlsConfig.Clone()
+	}
+	return &dialer
+}
+
+type transport struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if err := t.client.SetHeader(out.Header); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(out)
+}
+
+// CloseIdleConnections closes the idle connections of the base transport
+// unless it is the default one
+func (t *transport) CloseIdleConnections() {
+	if t.base == http.DefaultTransport {
+		return
+	}
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,98 @@
+package rpcauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSecret(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "key")
+	assert.NoError(t, os.WriteFile(file, []byte("from-file\n"), 0o600))
+	t.Setenv("RPC_AUTH_TEST_KEY", "from-env")
+
+	var auth Auth
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+headers:
+  x-inline: inline
+  x-env:
+    env: RPC_AUTH_TEST_KEY
+  x-file:
+    file: `+file+`
+`), &auth))
+	for name, want := range map[string]string{"x-inline": "inline", "x-env": "from-env", "x-file": "from-file"} {
+		value, err := auth.Headers[name].Resolve()
+		assert.NoError(t, err)
+		assert.Equal(t, want, value)
+	}
+
+	out, err := yaml.Marshal(auth.Headers["x-inline"])
+	assert.NoError(t, err)
+	assert.Equal(t, "inline\n", string(out))
+
+	_, err = Secret{Env: "RPC_AUTH_TEST_UNSET"}.Resolve()
+	assert.Error(t, err)
+}
+
+func TestClient(t *testing.T) {
+	var got http.Header
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caFile, cert, 0o600))
+
+	t.Run("private ca and headers", func(t *testing.T) {
+		c, err := New(&Auth{
+			Headers: map[string]Secret{"x-api-key": {Value: "key"}},
+			Basic:   &BasicAuth{Username: "relayer", Password: Secret{Value: "secret"}},
+		}, &HTTPOptions{CAFile: caFile})
+		assert.NoError(t, err)
+		resp, err := c.HTTPClient().Get(server.URL)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "key", got.Get("x-api-key"))
+		assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("relayer:secret")), got.Get("Authorization"))
+	})
+
+	t.Run("unknown ca", func(t *testing.T) {
+		_, err := http.DefaultClient.Get(server.URL)
+		assert.Error(t, err)
+	})
+
+	t.Run("jwt", func(t *testing.T) {
+		c, err := New(&Auth{JWT: &JWT{Secret: Secret{Value: "0x0102"}}}, &HTTPOptions{CAFile: caFile})
+		assert.NoError(t, err)
+		resp, err := c.HTTPClient().Get(server.URL)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		token := strings.TrimPrefix(got.Get("Authorization"), "Bearer ")
+		parts := strings.Split(token, ".")
+		assert.Len(t, parts, 3)
+		mac := hmac.New(sha256.New, []byte{1, 2})
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), parts[2])
+	})
+
+	t.Run("nil client", func(t *testing.T) {
+		c, err := New(nil, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, c)
+		assert.Equal(t, http.DefaultTransport, c.Wrap(nil))
+		assert.NoError(t, c.SetHeader(http.Header{}))
+	})
+}
@@ -0,0 +1,89 @@
+package rpcauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/icon-project/centralized-relay/relayer/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// Secret is a value set in the config, read from an environment variable or
+// from a file, so the credentials can stay out of the config file
+type Secret struct {
+	Value string `yaml:"value,omitempty" json:"value,omitempty"`
+	// Env is the environment variable holding the value
+	Env string `yaml:"env,omitempty" json:"env,omitempty"`
+	// File holds the value, surrounding whitespaces are trimmed
+	File string `yaml:"file,omitempty" json:"file,omitempty"`
+}
+
+// Resolve returns the value of the secret
+func (s Secret) Resolve() (string, error) {
+	switch {
+	case s.Env != "":
+		value, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", s.Env)
+		}
+		return value, nil
+	case s.File != "":
+		data, err := os.ReadFile(s.File)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return s.Value, nil
+}
+
+func (s Secret) inline() bool {
+	return s.Env == "" && s.File == ""
+}
+
+// UnmarshalYAML accepts a plain value or the value, env and file fields
+func (s *Secret) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		return n.Decode(&s.Value)
+	}
+	type secret Secret
+	return n.Decode((*secret)(s))
+}
+
+// JSONSchema accepts a plain value or the value, env and file fields
+func (Secret) JSONSchema() *jsonschema.Schema {
+	type secret Secret
+	return &jsonschema.Schema{AnyOf: []*jsonschema.Schema{
+		{Type: jsonschema.Types{jsonschema.TypeString}},
+		jsonschema.Reflect(secret{}),
+	}}
+}
+
+// MarshalYAML writes the inline secrets as plain values
+func (s Secret) MarshalYAML() (interface{}, error) {
+	if s.inline() {
+		return s.Value, nil
+	}
+	type secret Secret
+	return secret(s), nil
+}
+
+// UnmarshalJSON accepts a plain value or the value, env and file fields
+func (s *Secret) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &s.Value)
+	}
+	type secret Secret
+	return json.Unmarshal(data, (*secret)(s))
+}
+
+// MarshalJSON writes the inline secrets as plain values
+func (s Secret) MarshalJSON() ([]byte, error) {
+	if s.inline() {
+		return json.Marshal(s.Value)
+	}
+	type secret Secret
+	return json.Marshal(secret(s))
+}
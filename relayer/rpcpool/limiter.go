@@ -0,0 +1,179 @@
+package rpcpool
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/metrics"
+)
+
+const (
+	// DefaultRetryAfter pauses a rate limited endpoint which did not send a Retry-After header
+	DefaultRetryAfter = time.Second
+	// maxRetryAfter bounds the pause requested by an endpoint
+	maxRetryAfter = time.Minute
+	// minRateFactor is the lowest share of the rate limit the backoff goes down to
+	minRateFactor = 0.1
+	// rateRecoverInterval is the interval without rate limited responses after
+	// which the allowed rate goes up by a tenth of the rate limit
+	rateRecoverInterval = 10 * time.Second
+)
+
+// Priority of the requests for the rate limiter
+type Priority int
+
+const (
+	// PriorityNormal is used by the router, the finality checks and the socket queries
+	PriorityNormal Priority = iota
+	// PriorityListener requests are served before the waiting normal ones
+	PriorityListener
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose requests are rate limited with the priority
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+func priorityOf(ctx context.Context) Priority {
+	priority, _ := ctx.Value(priorityKey{}).(Priority)
+	return priority
+}
+
+// limiter is the token bucket of an endpoint, the rate is halved on every rate
+// limited response and goes back up to the limit while none is received
+type limiter struct {
+	chain, kind, label string
+
+	mu    sync.Mutex
+	limit float64
+	rate  float64
+	burst float64
+	// tokens available at last
+	tokens      float64
+	last        time.Time
+	adjustedAt  time.Time
+	pausedUntil time.Time
+	// listeners is the number of waiting listener requests
+	listeners int
+}
+
+func newLimiter(chain, kind, label string, limit float64, burst int) *limiter {
+	l := &limiter{
+		chain:  chain,
+		kind:   kind,
+		label:  label,
+		limit:  limit,
+		rate:   limit,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+	metrics.RPCRateLimit.WithLabelValues(chain, kind, label).Set(limit)
+	metrics.RPCRateAllowed.WithLabelValues(chain, kind, label).Set(limit)
+	return l
+}
+
+// wait blocks until a request can be sent, the listener requests are served
+// first, the other requests wait while one of them is waiting
+func (l *limiter) wait(ctx context.Context) error {
+	priority := priorityOf(ctx)
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.refill(now)
+		var delay time.Duration
+		switch {
+		case now.Before(l.pausedUntil):
+			delay = l.pausedUntil.Sub(now)
+		case l.limit == 0:
+			l.mu.Unlock()
+			return nil
+		case l.tokens >= 1 && (priority == PriorityListener || l.listeners == 0):
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		default:
+			delay = time.Duration(max(1-l.tokens, 0.1) / l.rate * float64(time.Second))
+		}
+		if priority == PriorityListener {
+			l.listeners++
+		}
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+		if priority == PriorityListener {
+			l.mu.Lock()
+			l.listeners--
+			l.mu.Unlock()
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// refill adds the tokens earned since the last call and raises the allowed rate
+// back towards the limit
+func (l *limiter) refill(now time.Time) {
+	if l.limit == 0 {
+		return
+	}
+	if l.rate < l.limit && now.Sub(l.adjustedAt) >= rateRecoverInterval {
+		l.setRate(min(l.limit, l.rate+l.limit/10), now)
+	}
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+}
+
+// throttled pauses the endpoint for the retry after duration and halves the allowed rate
+func (l *limiter) throttled(retryAfter time.Duration) {
+	metrics.RPCThrottled.WithLabelValues(l.chain, l.kind, l.label).Inc()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.pausedUntil = now.Add(retryAfter)
+	if l.limit == 0 {
+		return
+	}
+	l.refill(now)
+	l.tokens = 0
+	l.setRate(max(l.limit*minRateFactor, l.rate/2), now)
+}
+
+func (l *limiter) setRate(rate float64, now time.Time) {
+	l.rate = rate
+	l.adjustedAt = now
+	metrics.RPCRateAllowed.WithLabelValues(l.chain, l.kind, l.label).Set(rate)
+}
+
+// allowed returns the request rate allowed after the backoff
+func (l *limiter) allowed() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate
+}
+
+// retryAfter parses the Retry-After header in seconds or as a date
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return DefaultRetryAfter
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return min(max(time.Duration(seconds)*time.Second, DefaultRetryAfter), maxRetryAfter)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return min(max(time.Until(date), DefaultRetryAfter), maxRetryAfter)
+	}
+	return DefaultRetryAfter
+}
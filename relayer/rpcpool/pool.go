@@ -0,0 +1,547 @@
+package rpcpool
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/metrics"
+	"github.com/icon-project/centralized-relay/relayer/rpcauth"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultHealthInterval   = 15 * time.Second
+	DefaultMaxLag           = 10
+	DefaultMaxErrorRate     = 0.5
+	DefaultMinRequests      = 10
+	DefaultFailureThreshold = 3
+	DefaultRecoverChecks    = 2
+
+	// maxProbeTimeout bounds the probes of a health check
+	maxProbeTimeout = 10 * time.Second
+)
+
+// Config of the health checks of the endpoints of a pool
+type Config struct {
+	// HealthInterval is the interval of the health checks
+	HealthInterval time.Duration `yaml:"health-interval,omitempty" json:"health-interval,omitempty"`
+	// MaxLag is the number of blocks an endpoint may lag behind the highest one
+	MaxLag uint64 `yaml:"max-lag,omitempty" json:"max-lag,omitempty"`
+	// MaxErrorRate is the ratio of failed requests between two checks above which an endpoint is unhealthy
+	MaxErrorRate float64 `yaml:"max-error-rate,omitempty" json:"max-error-rate,omitempty"`
+	// MinRequests is the number of requests between two checks below which the error rate is ignored
+	MinRequests uint64 `yaml:"min-requests,omitempty" json:"min-requests,omitempty"`
+	// FailureThreshold is the number of consecutive failed requests which fails over without waiting for the next check
+	FailureThreshold uint64 `yaml:"failure-threshold,omitempty" json:"failure-threshold,omitempty"`
+	// RecoverChecks is the number of passing checks before an unhealthy endpoint is used again
+	RecoverChecks int `yaml:"recover-checks,omitempty" json:"recover-checks,omitempty"`
+	// RateLimit is the number of requests per second sent to each endpoint, unlimited when 0
+	RateLimit float64 `yaml:"rate-limit,omitempty" json:"rate-limit,omitempty"`
+	// Burst is the number of requests sent at once under the rate limit, the rate limit by default
+	Burst int `yaml:"burst,omitempty" json:"burst,omitempty"`
+}
+
+func (c *Config) sanitize() {
+	if c.HealthInterval == 0 {
+		c.HealthInterval = DefaultHealthInterval
+	}
+	if c.MaxLag == 0 {
+		c.MaxLag = DefaultMaxLag
+	}
+	if c.MaxErrorRate == 0 {
+		c.MaxErrorRate = DefaultMaxErrorRate
+	}
+	if c.MinRequests == 0 {
+		c.MinRequests = DefaultMinRequests
+	}
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = DefaultFailureThreshold
+	}
+	if c.RecoverChecks == 0 {
+		c.RecoverChecks = DefaultRecoverChecks
+	}
+	if c.Burst == 0 {
+		c.Burst = max(1, int(math.Ceil(c.RateLimit)))
+	}
+}
+
+// Validate checks the config values
+func (c *Config) Validate() error {
+	if c.HealthInterval < 0 {
+		return fmt.Errorf("health-interval cannot be negative")
+	}
+	if c.MaxErrorRate < 0 || c.MaxErrorRate > 1 {
+		return fmt.Errorf("max-error-rate must be between 0 and 1")
+	}
+	if c.RecoverChecks < 0 {
+		return fmt.Errorf("recover-checks cannot be negative")
+	}
+	if c.RateLimit < 0 {
+		return fmt.Errorf("rate-limit cannot be negative")
+	}
+	if c.Burst < 0 {
+		return fmt.Errorf("burst cannot be negative")
+	}
+	return nil
+}
+
+// Probe returns the latest height of the chain seen by the endpoint, the
+// requests are sent with the auth of the pool
+type Probe func(ctx context.Context, auth *rpcauth.Client, url string) (uint64, error)
+
+// Status is the snapshot of an endpoint of the pool
+type Status struct {
+	Endpoint  string    `json:"endpoint"`
+	Active    bool      `json:"active"`
+	Healthy   bool      `json:"healthy"`
+	Height    uint64    `json:"height"`
+	Requests  uint64    `json:"requests"`
+	Errors    uint64    `json:"errors"`
+	LastError string    `json:"lastError,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+	// RateLimit is the configured requests per second, AllowedRate the rate
+	// after the backoff and ObservedRate the rate since the previous check
+	RateLimit    float64 `json:"rateLimit"`
+	AllowedRate  float64 `json:"allowedRate"`
+	ObservedRate float64 `json:"observedRate"`
+}
+
+type endpoint struct {
+	url    string
+	prefix string
+	label  string
+
+	healthy bool
+	passes  int
+	height  uint64
+	// requests and errors since the last health check
+	requests uint64
+	errors   uint64
+	// failures is the number of consecutive failed requests
+	failures  uint64
+	lastErr   error
+	checkedAt time.Time
+	observed  float64
+	limiter   *limiter
+}
+
+// Pool is an ordered list of endpoints of a chain, the requests are sent to the
+// first healthy one, so the pool fails over to the next endpoints when it turns
+// unhealthy and fails back once it recovers
+type Pool struct {
+	chain string
+	kind  string
+	cfg   Config
+	probe Probe
+	log   *zap.Logger
+	auth  *rpcauth.Client
+
+	mu        sync.RWMutex
+	endpoints []*endpoint
+	current   int
+	onChange  []func(url string)
+	// transports are the transports of the clients built on the pool
+	transports []*transport
+}
+
+// New returns a pool of the endpoints in the order of preference, kind names the
+// pool in the metrics when the chain has more than one, like rpc and websocket
+func New(chain, kind string, urls []string, cfg Config, probe Probe, log *zap.Logger) (*Pool, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("%s: no %s endpoint", chain, kind)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.sanitize()
+	p := &Pool{
+		chain: chain,
+		kind:  kind,
+		cfg:   cfg,
+		probe: probe,
+		log:   log.With(zap.String("pool", kind)),
+	}
+	labels := make(map[string]bool)
+	for i, u := range urls {
+		if u == "" {
+			return nil, fmt.Errorf("%s: empty %s endpoint", chain, kind)
+		}
+		label := endpointLabel(u, i)
+		if labels[label] {
+			label = fmt.Sprintf("%s#%d", label, i)
+		}
+		labels[label] = true
+		p.endpoints = append(p.endpoints, &endpoint{
+			url:     u,
+			prefix:  strings.TrimRight(u, "/"),
+			label:   label,
+			healthy: true,
+			limiter: newLimiter(chain, kind, label, cfg.RateLimit, cfg.Burst),
+		})
+	}
+	for i, e := range p.endpoints {
+		metrics.RPCHealthy.WithLabelValues(p.chain, p.kind, e.label).Set(1)
+		metrics.RPCActive.WithLabelValues(p.chain, p.kind, e.label).Set(boolGauge(i == 0))
+	}
+	return p, nil
+}
+
+// endpointLabel returns the host of the endpoint, the path and credentials
+// are left out of the metrics as they often carry api keys
+func endpointLabel(raw string, index int) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Sprintf("endpoint-%d", index)
+	}
+	return u.Host
+}
+
+// Merge returns the primary endpoint followed by the fallback ones, skipping empty values
+func Merge(primary string, fallbacks []string) []string {
+	var urls []string
+	for _, u := range append([]string{primary}, fallbacks...) {
+		if u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// URL returns the first endpoint, the clients are built with it and the
+// transport of the pool redirects their requests to the current endpoint
+func (p *Pool) URL() string {
+	return p.endpoints[0].url
+}
+
+// Endpoints returns the endpoints in the order of preference
+func (p *Pool) Endpoints() []string {
+	urls := make([]string, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		urls = append(urls, e.url)
+	}
+	return urls
+}
+
+// Label returns the name of the first endpoint in the logs and metrics, without credentials
+func (p *Pool) Label() string {
+	return p.endpoints[0].label
+}
+
+// Pin returns a pool sending every request to the endpoint, without health
+// checks, the requests share the rate limit of the endpoint in the pool
+func (p *Pool) Pin(url string) (*Pool, error) {
+	pinned, err := New(p.chain, "pinned", []string{url}, p.cfg, nil, p.log)
+	if err != nil {
+		return nil, err
+	}
+	if e := p.endpoint(url); e != nil {
+		pinned.endpoints[0].limiter = e.limiter
+	}
+	pinned.auth = p.auth
+	return pinned, nil
+}
+
+// SetAuth sets the auth and http options of the requests and probes, it must
+// be called before the pool is started and its clients are built
+func (p *Pool) SetAuth(auth *rpcauth.Client) {
+	p.auth = auth
+}
+
+// Auth returns the auth and http options of the pool, nil when not set
+func (p *Pool) Auth() *rpcauth.Client {
+	return p.auth
+}
+
+// Current returns the endpoint the requests are sent to
+func (p *Pool) Current() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.endpoints[p.current].url
+}
+
+// OnChange registers a function called with the new endpoint when the pool fails over or back
+func (p *Pool) OnChange(fn func(url string)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onChange = append(p.onChange, fn)
+}
+
+// Status returns the state of the endpoints in the order of preference
+func (p *Pool) Status() []Status {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	status := make([]Status, 0, len(p.endpoints))
+	for i, e := range p.endpoints {
+		s := Status{
+			Endpoint:  e.label,
+			Active:    i == p.current,
+			Healthy:   e.healthy,
+			Height:    e.height,
+			Requests:  e.requests,
+			Errors:    e.errors,
+			CheckedAt: e.checkedAt,
+
+			RateLimit:    p.cfg.RateLimit,
+			AllowedRate:  e.limiter.allowed(),
+			ObservedRate: e.observed,
+		}
+		if e.lastErr != nil {
+			s.LastError = e.lastErr.Error()
+		}
+		status = append(status, s)
+	}
+	return status
+}
+
+// Start checks the health of the endpoints on every interval until the context is done
+func (p *Pool) Start(ctx context.Context) {
+	if p.probe == nil {
+		return
+	}
+	ticker := time.NewTicker(p.cfg.HealthInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.Check(ctx)
+		}
+	}
+}
+
+// Close closes the idle connections of the clients built on the pool, the
+// health checks stop with the context given to Start
+func (p *Pool) Close() {
+	p.mu.RLock()
+	transports := append([]*transport{}, p.transports...)
+	p.mu.RUnlock()
+	for _, t := range transports {
+		t.CloseIdleConnections()
+	}
+}
+
+// Check probes the endpoints and selects the first healthy one, an endpoint is
+// unhealthy when the probe fails, when its height lags behind the highest one
+// or when too many of its requests failed since the last check
+func (p *Pool) Check(ctx context.Context) {
+	timeout := min(p.cfg.HealthInterval, maxProbeTimeout)
+	type result struct {
+		height uint64
+		err    error
+	}
+	results := make([]result, len(p.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			results[i].height, results[i].err = p.probe(probeCtx, p.auth, url)
+		}(i, e.url)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	var maxHeight uint64
+	for _, r := range results {
+		if r.err == nil {
+			maxHeight = max(maxHeight, r.height)
+		}
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	for i, e := range p.endpoints {
+		r := results[i]
+		if !e.checkedAt.IsZero() {
+			e.observed = float64(e.requests) / now.Sub(e.checkedAt).Seconds()
+			metrics.RPCRateObserved.WithLabelValues(p.chain, p.kind, e.label).Set(e.observed)
+		}
+		e.checkedAt = now
+		err := r.err
+		if rateLimited(err) {
+			// the health of a rate limited endpoint is unknown, it is kept
+			p.log.Warn("rpc endpoint rate limited", zap.String("endpoint", e.label), zap.Duration("retry_after", DefaultRetryAfter))
+			e.limiter.throttled(DefaultRetryAfter)
+			e.requests, e.errors = 0, 0
+			continue
+		}
+		if err == nil {
+			e.height = r.height
+			metrics.RPCHeight.WithLabelValues(p.chain, p.kind, e.label).Set(float64(r.height))
+			if maxHeight-r.height > p.cfg.MaxLag {
+				err = fmt.Errorf("height %d lags %d blocks behind %d", r.height, maxHeight-r.height, maxHeight)
+			} else if e.requests >= p.cfg.MinRequests && float64(e.errors)/float64(e.requests) > p.cfg.MaxErrorRate {
+				err = fmt.Errorf("%d of %d requests failed", e.errors, e.requests)
+			}
+		}
+		e.requests, e.errors = 0, 0
+		if err != nil {
+			e.lastErr = err
+			e.passes = 0
+			p.setHealthy(e, false, err)
+			continue
+		}
+		e.passes++
+		if !e.healthy && e.passes >= p.cfg.RecoverChecks {
+			e.failures = 0
+			p.setHealthy(e, true, nil)
+		}
+	}
+	changed := p.selectEndpoint()
+	p.mu.Unlock()
+	p.notify(changed)
+}
+
+// Report records the result of a request sent to the endpoint
+func (p *Pool) Report(url string, err error) {
+	p.mu.Lock()
+	e := p.endpoint(url)
+	if e == nil {
+		p.mu.Unlock()
+		return
+	}
+	e.requests++
+	metrics.RPCRequests.WithLabelValues(p.chain, p.kind, e.label).Inc()
+	if err == nil {
+		e.failures = 0
+		p.mu.Unlock()
+		return
+	}
+	e.errors++
+	e.failures++
+	e.lastErr = err
+	metrics.RPCErrors.WithLabelValues(p.chain, p.kind, e.label).Inc()
+	var changed bool
+	if e.healthy && e.failures >= p.cfg.FailureThreshold && len(p.endpoints) > 1 {
+		e.passes = 0
+		p.setHealthy(e, false, err)
+		changed = p.selectEndpoint()
+	}
+	p.mu.Unlock()
+	p.notify(changed)
+}
+
+// wait blocks until the rate limit of the endpoint allows a request
+func (p *Pool) wait(ctx context.Context, url string) error {
+	if e := p.endpoint(url); e != nil {
+		return e.limiter.wait(ctx)
+	}
+	return nil
+}
+
+// throttled backs off the endpoint after a rate limited response
+func (p *Pool) throttled(url string, retryAfter time.Duration) {
+	p.mu.Lock()
+	e := p.endpoint(url)
+	if e == nil {
+		p.mu.Unlock()
+		return
+	}
+	// counted as a request, but neither as a failure nor as an error
+	e.requests++
+	metrics.RPCRequests.WithLabelValues(p.chain, p.kind, e.label).Inc()
+	p.mu.Unlock()
+	p.log.Warn("rpc endpoint rate limited", zap.String("endpoint", e.label), zap.Duration("retry_after", retryAfter))
+	e.limiter.throttled(retryAfter)
+}
+
+func (p *Pool) endpoint(url string) *endpoint {
+	for _, e := range p.endpoints {
+		if e.url == url {
+			return e
+		}
+	}
+	return nil
+}
+
+func (p *Pool) setHealthy(e *endpoint, healthy bool, err error) {
+	if e.healthy != healthy {
+		if healthy {
+			p.log.Info("rpc endpoint recovered", zap.String("endpoint", e.label))
+		} else {
+			p.log.Warn("rpc endpoint unhealthy", zap.String("endpoint", e.label), zap.Error(err))
+		}
+	}
+	e.healthy = healthy
+	metrics.RPCHealthy.WithLabelValues(p.chain, p.kind, e.label).Set(boolGauge(healthy))
+}
+
+// selectEndpoint sets the first healthy endpoint as the current one, the current
+// one is kept when none is healthy, it returns true if the endpoint changed
+func (p *Pool) selectEndpoint() bool {
+	next := p.current
+	for i, e := range p.endpoints {
+		if e.healthy {
+			next = i
+			break
+		}
+	}
+	if next == p.current {
+		return false
+	}
+	p.log.Warn("switching rpc endpoint",
+		zap.String("from", p.endpoints[p.current].label),
+		zap.String("to", p.endpoints[next].label),
+	)
+	metrics.RPCActive.WithLabelValues(p.chain, p.kind, p.endpoints[p.current].label).Set(0)
+	metrics.RPCActive.WithLabelValues(p.chain, p.kind, p.endpoints[next].label).Set(1)
+	p.current = next
+	return true
+}
+
+func (p *Pool) notify(changed bool) {
+	if !changed {
+		return
+	}
+	p.mu.RLock()
+	url := p.endpoints[p.current].url
+	callbacks := append([]func(string){}, p.onChange...)
+	p.mu.RUnlock()
+	for _, fn := range callbacks {
+		fn(url)
+	}
+}
+
+// candidates returns the current endpoint followed by the next healthy one
+func (p *Pool) candidates() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	urls := []string{p.endpoints[p.current].url}
+	for i, e := range p.endpoints {
+		if i != p.current && e.healthy {
+			return append(urls, e.url)
+		}
+	}
+	return urls
+}
+
+// relative returns the part of the url after the endpoint it starts with
+func (p *Pool) relative(raw string) (string, bool) {
+	var match *endpoint
+	for _, e := range p.endpoints {
+		if strings.HasPrefix(raw, e.prefix) && (match == nil || len(e.prefix) > len(match.prefix)) {
+			match = e
+		}
+	}
+	if match == nil {
+		return "", false
+	}
+	return raw[len(match.prefix):], true
+}
+
+func boolGauge(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
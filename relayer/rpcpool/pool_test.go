@@ -0,0 +1,262 @@
+package rpcpool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/rpcauth"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type node struct {
+	*httptest.Server
+	name   string
+	height atomic.Uint64
+	down   atomic.Bool
+}
+
+func newNode(t *testing.T, name string, height uint64) *node {
+	n := &node{name: name}
+	n.height.Store(height)
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n.down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s %s", n.name, r.URL.Path, body)
+	}))
+	t.Cleanup(n.Close)
+	return n
+}
+
+func (n *node) probe(ctx context.Context, auth *rpcauth.Client, url string) (uint64, error) {
+	if n.down.Load() {
+		return 0, fmt.Errorf("%s down", n.name)
+	}
+	return n.height.Load(), nil
+}
+
+func TestPool(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := newNode(t, "primary", 100), newNode(t, "secondary", 100)
+	nodes := map[string]*node{primary.URL + "/rpc": primary, secondary.URL + "/rpc": secondary}
+	probe := func(ctx context.Context, auth *rpcauth.Client, url string) (uint64, error) {
+		return nodes[url].probe(ctx, auth, url)
+	}
+	pool, err := New("test", "rpc", []string{primary.URL + "/rpc", secondary.URL + "/rpc"}, Config{MaxLag: 5}, probe, zap.NewNop())
+	assert.NoError(t, err)
+
+	var changes []string
+	pool.OnChange(func(url string) { changes = append(changes, url) })
+
+	client := pool.HTTPClient(nil)
+	post := func() string {
+		resp, err := client.Post(pool.URL()+"/v1", "text/plain", strings.NewReader("ping"))
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	t.Run("primary", func(t *testing.T) {
+		assert.Equal(t, "primary /rpc/v1 ping", post())
+	})
+
+	t.Run("fails over after consecutive failed requests", func(t *testing.T) {
+		primary.down.Store(true)
+		defer primary.down.Store(false)
+		// the request reached the endpoint, so it is not sent again
+		assert.Equal(t, "", post())
+		assert.Equal(t, primary.URL+"/rpc", pool.Current())
+		post()
+		post()
+		assert.Equal(t, secondary.URL+"/rpc", pool.Current())
+		assert.Equal(t, []string{secondary.URL + "/rpc"}, changes)
+		assert.Equal(t, "secondary /rpc/v1 ping", post())
+	})
+
+	t.Run("fails back after the recover checks", func(t *testing.T) {
+		pool.Check(ctx)
+		assert.Equal(t, secondary.URL+"/rpc", pool.Current())
+		pool.Check(ctx)
+		assert.Equal(t, primary.URL+"/rpc", pool.Current())
+		assert.Equal(t, "primary /rpc/v1 ping", post())
+	})
+
+	t.Run("lagging endpoint", func(t *testing.T) {
+		secondary.height.Store(120)
+		pool.Check(ctx)
+		assert.Equal(t, secondary.URL+"/rpc", pool.Current())
+		status := pool.Status()
+		assert.False(t, status[0].Healthy)
+		assert.Contains(t, status[0].LastError, "lags 20 blocks")
+		assert.True(t, status[1].Active)
+	})
+
+	t.Run("keeps the current endpoint when none is healthy", func(t *testing.T) {
+		primary.down.Store(true)
+		secondary.down.Store(true)
+		defer primary.down.Store(false)
+		defer secondary.down.Store(false)
+		pool.Check(ctx)
+		assert.Equal(t, secondary.URL+"/rpc", pool.Current())
+	})
+}
+
+func TestTransportRetry(t *testing.T) {
+	unreachable, secondary := newNode(t, "unreachable", 100), newNode(t, "secondary", 100)
+	unreachable.Close()
+	var received atomic.Int32
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(failing.Close)
+	probe := func(ctx context.Context, auth *rpcauth.Client, url string) (uint64, error) { return 100, nil }
+	post := func(pool *Pool) (*http.Response, error) {
+		resp, err := pool.HTTPClient(nil).Post(pool.URL(), "application/json", strings.NewReader(`{"method":"eth_sendRawTransaction"}`))
+		if err == nil {
+			resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	t.Run("retries requests which did not reach the endpoint", func(t *testing.T) {
+		pool, err := New("test", "rpc", []string{unreachable.URL, secondary.URL}, Config{}, probe, zap.NewNop())
+		assert.NoError(t, err)
+		resp, err := post(pool)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("does not send again a request answered with an error", func(t *testing.T) {
+		pool, err := New("test", "rpc", []string{failing.URL, secondary.URL}, Config{}, probe, zap.NewNop())
+		assert.NoError(t, err)
+		resp, err := post(pool)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, int32(1), received.Load())
+		assert.Equal(t, uint64(1), pool.Status()[0].Errors)
+	})
+}
+
+func TestRateLimitedEndpoint(t *testing.T) {
+	secondary := newNode(t, "secondary", 100)
+	limiting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(limiting.Close)
+	probe := func(ctx context.Context, auth *rpcauth.Client, url string) (uint64, error) {
+		if url == limiting.URL {
+			return 0, fmt.Errorf("429 Too Many Requests: rate limited")
+		}
+		return 100, nil
+	}
+	pool, err := New("test", "rpc", []string{limiting.URL, secondary.URL}, Config{FailureThreshold: 1, RecoverChecks: 1}, probe, zap.NewNop())
+	assert.NoError(t, err)
+
+	resp, err := pool.HTTPClient(nil).Post(pool.URL(), "text/plain", strings.NewReader("ping"))
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	status := pool.Status()[0]
+	assert.Equal(t, uint64(1), status.Requests)
+	assert.Equal(t, uint64(0), status.Errors)
+	assert.True(t, status.Healthy)
+	assert.Equal(t, limiting.URL, pool.Current())
+
+	// a rate limited probe keeps the health of the endpoint
+	pool.Check(context.Background())
+	assert.True(t, pool.Status()[0].Healthy)
+	assert.Equal(t, limiting.URL, pool.Current())
+}
+
+func TestErrorRate(t *testing.T) {
+	primary, secondary := newNode(t, "primary", 100), newNode(t, "secondary", 100)
+	pool, err := New("test", "rpc", []string{primary.URL, secondary.URL}, Config{MinRequests: 4, FailureThreshold: 100},
+		func(ctx context.Context, auth *rpcauth.Client, url string) (uint64, error) { return 100, nil }, zap.NewNop())
+	assert.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		var err error
+		if i%4 != 0 {
+			err = fmt.Errorf("failed")
+		}
+		pool.Report(primary.URL, err)
+	}
+	pool.Check(context.Background())
+	assert.Equal(t, secondary.URL, pool.Current())
+}
+
+func TestParseNumber(t *testing.T) {
+	for raw, want := range map[string]uint64{`"0x10"`: 16, `"42"`: 42, `7`: 7} {
+		got, err := ParseNumber([]byte(raw))
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("burst then rate", func(t *testing.T) {
+		l := newLimiter("test", "rpc", "a", 50, 2)
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, l.wait(ctx))
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+	})
+
+	t.Run("listener first", func(t *testing.T) {
+		l := newLimiter("test", "rpc", "a", 20, 1)
+		assert.NoError(t, l.wait(ctx))
+		order := make(chan Priority, 2)
+		go func() {
+			l.wait(ctx)
+			order <- PriorityNormal
+		}()
+		time.Sleep(5 * time.Millisecond)
+		go func() {
+			l.wait(WithPriority(ctx, PriorityListener))
+			order <- PriorityListener
+		}()
+		assert.Equal(t, PriorityListener, <-order)
+		assert.Equal(t, PriorityNormal, <-order)
+	})
+
+	t.Run("backoff on rate limited responses", func(t *testing.T) {
+		l := newLimiter("test", "rpc", "a", 100, 1)
+		l.throttled(10 * time.Millisecond)
+		assert.Equal(t, 50.0, l.allowed())
+		l.throttled(10 * time.Millisecond)
+		l.throttled(10 * time.Millisecond)
+		l.throttled(10 * time.Millisecond)
+		assert.Equal(t, 10.0, l.allowed())
+		start := time.Now()
+		assert.NoError(t, l.wait(ctx))
+		assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		l := newLimiter("test", "rpc", "a", 0, 1)
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, l.wait(ctx))
+		}
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, DefaultRetryAfter, retryAfter(http.Header{}))
+	assert.Equal(t, 5*time.Second, retryAfter(http.Header{"Retry-After": []string{"5"}}))
+	assert.Equal(t, maxRetryAfter, retryAfter(http.Header{"Retry-After": []string{"3600"}}))
+}
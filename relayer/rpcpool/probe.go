@@ -0,0 +1,106 @@
+package rpcpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/icon-project/centralized-relay/relayer/rpcauth"
+)
+
+// JSONRPCProbe returns a probe calling the json-rpc method on the endpoint,
+// the params are left out when nil, parse extracts the height from the result
+func JSONRPCProbe(method string, params interface{}, parse func(result json.RawMessage) (uint64, error)) Probe {
+	return func(ctx context.Context, auth *rpcauth.Client, url string) (uint64, error) {
+		request := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  method,
+		}
+		if params != nil {
+			request["params"] = params
+		}
+		body, err := json.Marshal(request)
+		if err != nil {
+			return 0, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		data, err := do(auth, req)
+		if err != nil {
+			return 0, err
+		}
+		var res struct {
+			Result json.RawMessage `json:"result"`
+			Error  *struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(data, &res); err != nil {
+			return 0, err
+		}
+		if res.Error != nil {
+			return 0, fmt.Errorf("%s: %d %s", method, res.Error.Code, res.Error.Message)
+		}
+		return parse(res.Result)
+	}
+}
+
+// HTTPProbe returns a probe getting the path of the endpoint, parse extracts
+// the height from the response body
+func HTTPProbe(path string, parse func(body []byte) (uint64, error)) Probe {
+	return func(ctx context.Context, auth *rpcauth.Client, url string) (uint64, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(url, "/")+path, nil)
+		if err != nil {
+			return 0, err
+		}
+		data, err := do(auth, req)
+		if err != nil {
+			return 0, err
+		}
+		return parse(data)
+	}
+}
+
+// ParseNumber parses a json number, a decimal string or a 0x prefixed hex string
+func ParseNumber(value json.RawMessage) (uint64, error) {
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		s = string(value)
+	}
+	if strings.HasPrefix(s, "0x") {
+		return strconv.ParseUint(s[2:], 16, 64)
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// rateLimited returns if the probe was answered 429 Too Many Requests, the
+// status is in the errors of do and of the go-ethereum rpc clients
+func rateLimited(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "429 Too Many Requests")
+}
+
+func do(auth *rpcauth.Client, req *http.Request) ([]byte, error) {
+	resp, err := auth.HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http status %s", resp.Status)
+	}
+	return data, nil
+}
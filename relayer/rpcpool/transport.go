@@ -0,0 +1,112 @@
+package rpcpool
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// transport sends the requests addressed to any endpoint of the pool to the
+// current one under its rate limit and retries a request which could not reach
+// it once on the next healthy endpoint
+type transport struct {
+	pool *Pool
+	base http.RoundTripper
+}
+
+// Transport returns a round tripper over base redirecting the requests to the
+// current endpoint with the auth of the pool, base defaults to http.DefaultTransport
+func (p *Pool) Transport(base http.RoundTripper) http.RoundTripper {
+	t := &transport{pool: p, base: p.auth.Wrap(base)}
+	p.mu.Lock()
+	p.transports = append(p.transports, t)
+	p.mu.Unlock()
+	return t
+}
+
+// HTTPClient returns a http client over the transport of the pool
+func (p *Pool) HTTPClient(base http.RoundTripper) *http.Client {
+	return &http.Client{Transport: p.Transport(base)}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path, ok := t.pool.relative(req.URL.String())
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	candidates := t.pool.candidates()
+	// the request can only be sent again when its body can be read again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		candidates = candidates[:1]
+	}
+	for i, target := range candidates {
+		out, err := rewrite(req, target, path, i > 0)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.pool.wait(req.Context(), target); err != nil {
+			return nil, err
+		}
+		resp, err := t.base.RoundTrip(out)
+		if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+			// a rate limited endpoint is slowed down, it is not failed over
+			t.pool.throttled(target, retryAfter(resp.Header))
+		} else {
+			failure := err
+			if err == nil && resp.StatusCode >= http.StatusInternalServerError {
+				failure = fmt.Errorf("http status %s", resp.Status)
+			}
+			t.pool.Report(target, failure)
+		}
+		// a request answered with an error may have been applied, as a
+		// transaction sent, so only the ones which were never sent are retried
+		if !notSent(err) || i == len(candidates)-1 || req.Context().Err() != nil {
+			return resp, err
+		}
+	}
+	// unreachable, there is always a candidate
+	return nil, fmt.Errorf("no rpc endpoint")
+}
+
+// CloseIdleConnections closes the idle connections of the base transport,
+// the default transport shared by the other clients is left open
+func (t *transport) CloseIdleConnections() {
+	if t.base == http.DefaultTransport {
+		return
+	}
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// notSent returns if the request failed to connect to the endpoint, before
+// any of it was sent
+func notSent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// rewrite returns a copy of the request addressed to the target endpoint,
+// the body is read again when the request is retried
+func rewrite(req *http.Request, target, path string, retry bool) (*http.Request, error) {
+	u, err := url.Parse(strings.TrimRight(target, "/") + path)
+	if err != nil {
+		return nil, err
+	}
+	out := req.Clone(req.Context())
+	out.URL = u
+	out.Host = ""
+	if u.User != nil {
+		password, _ := u.User.Password()
+		out.SetBasicAuth(u.User.Username(), password)
+	}
+	if retry && req.GetBody != nil {
+		if out.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
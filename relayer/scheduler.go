@@ -0,0 +1,75 @@
+package relayer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/metrics"
+	"github.com/icon-project/centralized-relay/relayer/scheduler"
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// queuedMessage is a message ready to be routed with its src chain
+type queuedMessage struct {
+	*types.RouteMessage
+	src  *ChainRuntime
+	rank scheduler.Rank
+}
+
+// SetScheduler replaces the priority classes of the router
+func (r *Relayer) SetScheduler(cfg *scheduler.Config) error {
+	s, err := scheduler.New(cfg)
+	if err != nil {
+		return err
+	}
+	r.scheduler = s
+	return nil
+}
+
+// QueueDepth returns the number of queued messages per priority class
+func (r *Relayer) QueueDepth() []*scheduler.QueueDepth {
+	return r.scheduler.Depth()
+}
+
+// pendingMessages returns the messages of all the chains ready to be routed
+// in the scheduling order and records the queue depth of the classes
+func (r *Relayer) pendingMessages() []*queuedMessage {
+	now := time.Now()
+	depth := make(map[string]int)
+	var queued []*queuedMessage
+	for _, src := range r.GetAllChainsRuntime() {
+		src.MessageCache.RLock()
+		messages := make([]*types.RouteMessage, 0, len(src.MessageCache.Messages))
+		for _, message := range src.MessageCache.Messages {
+			messages = append(messages, message)
+		}
+		src.MessageCache.RUnlock()
+
+		for _, message := range messages {
+			if message.QueuedAt.IsZero() {
+				message.QueuedAt = now
+			}
+			if message.IsProcessing() {
+				if !message.Processing {
+					depth[r.scheduler.Class(message.Message).Name]++
+				}
+				continue
+			}
+			if r.policy != nil && !r.applyPolicy(message, src) {
+				continue
+			}
+			rank := r.scheduler.Rank(message, now)
+			depth[rank.Class]++
+			queued = append(queued, &queuedMessage{RouteMessage: message, src: src, rank: rank})
+		}
+	}
+	sort.SliceStable(queued, func(i, j int) bool {
+		return queued[i].rank.Before(queued[j].rank)
+	})
+
+	r.scheduler.SetDepth(depth)
+	for _, d := range r.scheduler.Depth() {
+		metrics.QueueDepth.WithLabelValues(d.Class).Set(float64(d.Depth))
+	}
+	return queued
+}
@@ -0,0 +1,206 @@
+package scheduler
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+const (
+	DefaultClass   = "default"
+	DefaultMaxWait = 5 * time.Minute
+)
+
+// Route matches the messages of a src -> dst route, empty side matches any chain
+type Route struct {
+	Src string `yaml:"src" json:"src"`
+	Dst string `yaml:"dst" json:"dst"`
+}
+
+func (r *Route) Match(src, dst string) bool {
+	return (r.Src == "" || r.Src == src) && (r.Dst == "" || r.Dst == dst)
+}
+
+// Class is a priority class of the messages matching both the event types and the routes,
+// an empty list matches all the messages
+type Class struct {
+	Name       string   `yaml:"name" json:"name"`
+	Priority   int      `yaml:"priority" json:"priority"`
+	EventTypes []string `yaml:"event-types" json:"event-types"`
+	Routes     []*Route `yaml:"routes" json:"routes"`
+}
+
+func (c *Class) Match(msg *types.Message) bool {
+	if len(c.EventTypes) > 0 && !slices.Contains(c.EventTypes, msg.EventType) {
+		return false
+	}
+	if len(c.Routes) == 0 {
+		return true
+	}
+	for _, route := range c.Routes {
+		if route.Match(msg.Src, msg.Dst) {
+			return true
+		}
+	}
+	return false
+}
+
+// Config of the router scheduler, the first matching class wins
+type Config struct {
+	Classes []*Class `yaml:"classes" json:"classes"`
+	// MaxWait is the wait after which a message is routed ahead of every class
+	MaxWait time.Duration `yaml:"max-wait" json:"max-wait"`
+	// MaxDispatch limits the messages dispatched to a destination per route
+	// cycle, unlimited when 0
+	MaxDispatch int `yaml:"max-dispatch" json:"max-dispatch"`
+}
+
+// DefaultClasses routes the admin events first and the executions last
+func DefaultClasses() []*Class {
+	return []*Class{
+		{Name: "admin", Priority: 30, EventTypes: []string{events.RevertMessage, events.SetAdmin, events.SetFee, events.ClaimFee}},
+		{Name: "delivery", Priority: 20, EventTypes: []string{events.EmitMessage, events.PacketRegistered, events.PacketAcknowledged}},
+		{Name: "rollback", Priority: 15, EventTypes: []string{events.RollbackMessage}},
+		{Name: "execution", Priority: 10, EventTypes: []string{events.CallMessage}},
+	}
+}
+
+func (c *Config) Validate() error {
+	names := make(map[string]bool, len(c.Classes))
+	for _, class := range c.Classes {
+		if class.Name == "" {
+			return fmt.Errorf("scheduler: class name is required")
+		}
+		if class.Name == DefaultClass || names[class.Name] {
+			return fmt.Errorf("scheduler: duplicate class %s", class.Name)
+		}
+		names[class.Name] = true
+	}
+	if c.MaxWait < 0 {
+		return fmt.Errorf("scheduler: max-wait cannot be negative")
+	}
+	if c.MaxDispatch < 0 {
+		return fmt.Errorf("scheduler: max-dispatch cannot be negative")
+	}
+	return nil
+}
+
+func (c *Config) sanitize() {
+	if len(c.Classes) == 0 {
+		c.Classes = DefaultClasses()
+	}
+	if c.MaxWait == 0 {
+		c.MaxWait = DefaultMaxWait
+	}
+}
+
+// Rank is the position of a message in the routing order
+type Rank struct {
+	Class    string
+	Priority int
+	Waiting  time.Duration
+	// Starving is set once the message waited longer than max wait
+	Starving bool
+}
+
+// Before returns true if the message ranked r is routed before o,
+// starving messages come first, each group ordered by priority then wait
+func (r Rank) Before(o Rank) bool {
+	if r.Starving != o.Starving {
+		return r.Starving
+	}
+	if r.Priority != o.Priority {
+		return r.Priority > o.Priority
+	}
+	return r.Waiting > o.Waiting
+}
+
+// QueueDepth is the number of queued messages of a class
+type QueueDepth struct {
+	Class    string `json:"class"`
+	Priority int    `json:"priority"`
+	Depth    int    `json:"depth"`
+}
+
+type Scheduler struct {
+	cfg *Config
+
+	mu    sync.RWMutex
+	depth map[string]int
+}
+
+func New(cfg *Config) (*Scheduler, error) {
+	if cfg == nil {
+		cfg = new(Config)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.sanitize()
+	return &Scheduler{cfg: cfg, depth: make(map[string]int)}, nil
+}
+
+// Default returns the scheduler with the default classes
+func Default() *Scheduler {
+	s, _ := New(nil)
+	return s
+}
+
+func (s *Scheduler) Config() *Config {
+	return s.cfg
+}
+
+// Class returns the first class matching the message
+func (s *Scheduler) Class(msg *types.Message) *Class {
+	for _, class := range s.cfg.Classes {
+		if class.Match(msg) {
+			return class
+		}
+	}
+	return &Class{Name: DefaultClass}
+}
+
+// Rank ranks the message, the priority set by the routing policy is added to the class priority
+func (s *Scheduler) Rank(msg *types.RouteMessage, now time.Time) Rank {
+	class := s.Class(msg.Message)
+	waiting := now.Sub(msg.QueuedAt)
+	return Rank{
+		Class:    class.Name,
+		Priority: class.Priority + msg.Priority,
+		Waiting:  waiting,
+		Starving: waiting > s.cfg.MaxWait,
+	}
+}
+
+// CanDispatch returns false once the dispatched count reached max dispatch
+func (s *Scheduler) CanDispatch(dispatched int) bool {
+	return s.cfg.MaxDispatch == 0 || dispatched < s.cfg.MaxDispatch
+}
+
+// SetDepth records the queue depth of the classes
+func (s *Scheduler) SetDepth(depth map[string]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.depth = depth
+}
+
+// Depth returns the queue depth of every class ordered by priority
+func (s *Scheduler) Depth() []*QueueDepth {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	depths := make([]*QueueDepth, 0, len(s.cfg.Classes)+1)
+	for _, class := range s.cfg.Classes {
+		depths = append(depths, &QueueDepth{Class: class.Name, Priority: class.Priority, Depth: s.depth[class.Name]})
+	}
+	depths = append(depths, &QueueDepth{Class: DefaultClass, Depth: s.depth[DefaultClass]})
+	sort.SliceStable(depths, func(i, j int) bool {
+		return depths[i].Priority > depths[j].Priority
+	})
+	return depths
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func routeMessage(src, dst, eventType string, queuedAt time.Time) *types.RouteMessage {
+	m := types.NewRouteMessage(&types.Message{Src: src, Dst: dst, EventType: eventType, Sn: big.NewInt(1)})
+	m.QueuedAt = queuedAt
+	return m
+}
+
+func TestScheduler(t *testing.T) {
+	s, err := New(&Config{
+		Classes: []*Class{
+			{Name: "vip-route", Priority: 50, Routes: []*Route{{Src: "0x2.icon", Dst: "archway"}}},
+			{Name: "admin", Priority: 30, EventTypes: []string{events.RevertMessage}},
+			{Name: "delivery", Priority: 20, EventTypes: []string{events.EmitMessage}},
+			{Name: "execution", Priority: 10, EventTypes: []string{events.CallMessage}},
+		},
+		MaxWait:     time.Minute,
+		MaxDispatch: 2,
+	})
+	assert.NoError(t, err)
+	now := time.Now()
+
+	t.Run("class", func(t *testing.T) {
+		assert.Equal(t, "vip-route", s.Class(&types.Message{Src: "0x2.icon", Dst: "archway", EventType: events.CallMessage}).Name)
+		assert.Equal(t, "execution", s.Class(&types.Message{Src: "archway", Dst: "0x2.icon", EventType: events.CallMessage}).Name)
+		assert.Equal(t, DefaultClass, s.Class(&types.Message{EventType: events.RollbackMessage}).Name)
+	})
+
+	t.Run("order", func(t *testing.T) {
+		call := routeMessage("archway", "0x2.icon", events.CallMessage, now.Add(-30*time.Second))
+		emit := routeMessage("archway", "0x2.icon", events.EmitMessage, now)
+		revert := routeMessage("archway", "0x2.icon", events.RevertMessage, now)
+		starving := routeMessage("archway", "0x2.icon", events.CallMessage, now.Add(-2*time.Minute))
+		oldest := routeMessage("archway", "0x2.icon", events.CallMessage, now.Add(-3*time.Minute))
+		starvingRevert := routeMessage("archway", "0x2.icon", events.RevertMessage, now.Add(-90*time.Second))
+		prioritised := routeMessage("archway", "0x2.icon", events.EmitMessage, now)
+		prioritised.Priority = 20
+
+		messages := []*types.RouteMessage{call, emit, revert, starving, oldest, starvingRevert, prioritised}
+		sort.SliceStable(messages, func(i, j int) bool {
+			return s.Rank(messages[i], now).Before(s.Rank(messages[j], now))
+		})
+		assert.Equal(t, []*types.RouteMessage{starvingRevert, oldest, starving, prioritised, revert, emit, call}, messages)
+	})
+
+	t.Run("dispatch limit", func(t *testing.T) {
+		assert.True(t, s.CanDispatch(1))
+		assert.False(t, s.CanDispatch(2))
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		s.SetDepth(map[string]int{"execution": 3, DefaultClass: 1})
+		depths := s.Depth()
+		assert.Len(t, depths, 5)
+		assert.Equal(t, "vip-route", depths[0].Class)
+		assert.Equal(t, &QueueDepth{Class: "execution", Priority: 10, Depth: 3}, depths[3])
+		assert.Equal(t, &QueueDepth{Class: DefaultClass, Depth: 1}, depths[4])
+	})
+
+	t.Run("unlimited dispatch by default", func(t *testing.T) {
+		assert.Zero(t, Default().Config().MaxDispatch)
+		assert.True(t, Default().CanDispatch(1000))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := New(&Config{Classes: []*Class{{Name: DefaultClass}}})
+		assert.Error(t, err)
+	})
+}
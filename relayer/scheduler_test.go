@@ -0,0 +1,35 @@
+package relayer
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoredMessagesQueuedAt(t *testing.T) {
+	src, dst := newCostProvider(t, "mock-1", 0, 0), newCostProvider(t, "mock-2", 0, 0)
+	rly := newTestRelayer(t, filepath.Join(t.TempDir(), "db"), src, dst)
+
+	// the wait before the start of the relayer does not count
+	stored := types.NewRouteMessage(emitMessage(1))
+	stored.QueuedAt = rly.startedAt.Add(-time.Hour)
+	require.NoError(t, rly.messageStore.StoreMessage(stored))
+	queued := types.NewRouteMessage(emitMessage(2))
+	queued.QueuedAt = rly.startedAt.Add(time.Second)
+	require.NoError(t, rly.messageStore.StoreMessage(queued))
+
+	messages, err := rly.getActiveMessagesFromStore("mock-1", maxFlushMessage)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	for _, m := range messages {
+		if m.Sn.Int64() == 1 {
+			assert.True(t, m.QueuedAt.Equal(rly.startedAt))
+		} else {
+			assert.True(t, m.QueuedAt.Equal(queued.QueuedAt))
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package relayer
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/shard"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+var (
+	// ShardManifestInterval is the interval of the shard manifest refresh
+	ShardManifestInterval = time.Minute
+	// ShardStaleAfter is the age of the manifest entries which no longer count in the coverage
+	ShardStaleAfter = shard.DefaultStaleAfter
+)
+
+// ShardRoute is the event types owned by the shard on a route
+type ShardRoute struct {
+	Src        string   `json:"src"`
+	Dst        string   `json:"dst"`
+	EventTypes []string `json:"eventTypes"`
+}
+
+// ShardStatus is the ownership of the relayer and the coverage of the shared manifest
+type ShardStatus struct {
+	Shard    *shard.Config `json:"shard"`
+	Routes   []*ShardRoute `json:"routes"`
+	Manifest *shard.Report `json:"manifest,omitempty"`
+}
+
+// SetShard limits the routing to the routes and event types owned by the shard,
+// the listeners keep reading every chain
+func (r *Relayer) SetShard(cfg *shard.Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if cfg.Manifest != "" {
+		manifest, err := shard.NewManifest(cfg.Manifest)
+		if err != nil {
+			return err
+		}
+		r.shardManifest = manifest
+	}
+	r.shard = cfg
+	return nil
+}
+
+// ownsMessage returns true if the message is routed by the relayer,
+// it owns every message without shard
+func (r *Relayer) ownsMessage(msg *types.Message) bool {
+	return r.shard == nil || r.shard.Owns(msg.Src, msg.Dst, msg.EventType)
+}
+
+// StartShardManifest registers the shard in the shared manifest
+// and warns about the overlaps and gaps of the shards
+func (r *Relayer) StartShardManifest(ctx context.Context) {
+	if r.shardManifest == nil {
+		return
+	}
+	ticker := time.NewTicker(ShardManifestInterval)
+	defer ticker.Stop()
+	for {
+		r.refreshShardManifest()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relayer) refreshShardManifest() {
+	if err := r.shardManifest.Register(r.shard); err != nil {
+		r.log.Error("failed to register shard in manifest", zap.String("shard", r.shard.ID), zap.Error(err))
+		return
+	}
+	report, err := r.shardManifest.Check(r.chainNIDs(), ShardStaleAfter)
+	if err != nil {
+		r.log.Error("failed to check shard manifest", zap.String("shard", r.shard.ID), zap.Error(err))
+		return
+	}
+	if len(report.Stale) > 0 {
+		r.log.Warn("stale shards in manifest", zap.Strings("shards", report.Stale))
+	}
+	for _, overlap := range report.Overlaps {
+		r.log.Warn("route owned by several shards",
+			zap.String("src", overlap.Src),
+			zap.String("dst", overlap.Dst),
+			zap.String("event_type", overlap.EventType),
+			zap.Strings("shards", overlap.Owners),
+		)
+	}
+	for _, gap := range report.Gaps {
+		r.log.Warn("route not owned by any shard",
+			zap.String("src", gap.Src),
+			zap.String("dst", gap.Dst),
+			zap.String("event_type", gap.EventType),
+		)
+	}
+}
+
+// ShardStatus returns the routes owned by the relayer and the coverage
+// of the manifest, nil without shard
+func (r *Relayer) ShardStatus() (*ShardStatus, error) {
+	if r.shard == nil {
+		return nil, nil
+	}
+	nids := r.chainNIDs()
+	status := &ShardStatus{Shard: r.shard, Routes: make([]*ShardRoute, 0)}
+	for _, src := range nids {
+		for _, dst := range nids {
+			if src == dst {
+				continue
+			}
+			route := &ShardRoute{Src: src, Dst: dst}
+			for _, eventType := range shard.RoutedEventTypes {
+				if r.shard.Owns(src, dst, eventType) {
+					route.EventTypes = append(route.EventTypes, eventType)
+				}
+			}
+			if len(route.EventTypes) > 0 {
+				status.Routes = append(status.Routes, route)
+			}
+		}
+	}
+	if r.shardManifest != nil {
+		report, err := r.shardManifest.Check(nids, ShardStaleAfter)
+		if err != nil {
+			return nil, err
+		}
+		status.Manifest = report
+	}
+	return status, nil
+}
+
+func (r *Relayer) chainNIDs() []string {
+	var nids []string
+	for _, chainRuntime := range r.GetAllChainsRuntime() {
+		nids = append(nids, chainRuntime.Provider.NID())
+	}
+	slices.Sort(nids)
+	return nids
+}
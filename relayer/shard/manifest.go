@@ -0,0 +1,125 @@
+package shard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+// DefaultStaleAfter is the age after which a shard which stopped refreshing
+// its manifest entry no longer counts in the coverage
+const DefaultStaleAfter = 10 * time.Minute
+
+// Entry is the registration of a shard in the manifest
+type Entry struct {
+	*Config
+	Host      string    `json:"host"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Manifest is the file shared by the relayer instances listing their shards
+type Manifest struct {
+	path string
+	lock *flock.Flock
+}
+
+func NewManifest(path string) (*Manifest, error) {
+	if path == "" {
+		return nil, fmt.Errorf("shard manifest path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return &Manifest{path: path, lock: flock.New(path + ".lock")}, nil
+}
+
+// Register adds or refreshes the entry of the shard
+func (m *Manifest) Register(cfg *Config) error {
+	if err := m.lock.Lock(); err != nil {
+		return fmt.Errorf("failed to lock shard manifest: %w", err)
+	}
+	defer m.lock.Unlock()
+	entries, err := m.read()
+	if err != nil {
+		return err
+	}
+	host, _ := os.Hostname()
+	entries[cfg.ID] = &Entry{Config: cfg, Host: host, UpdatedAt: time.Now()}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, m.path)
+}
+
+// Entries returns the registered shards sorted by id
+func (m *Manifest) Entries() ([]*Entry, error) {
+	if err := m.lock.RLock(); err != nil {
+		return nil, fmt.Errorf("failed to lock shard manifest: %w", err)
+	}
+	defer m.lock.Unlock()
+	entries, err := m.read()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*Entry, 0, len(entries))
+	for _, entry := range entries {
+		list = append(list, entry)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+func (m *Manifest) read() (map[string]*Entry, error) {
+	entries := make(map[string]*Entry)
+	data, err := os.ReadFile(m.path)
+	if errors.Is(err, os.ErrNotExist) || len(data) == 0 {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid shard manifest %s: %w", m.path, err)
+	}
+	return entries, nil
+}
+
+// Report is the coverage of the live shards of the manifest
+type Report struct {
+	*Coverage
+	Shards []*Entry `json:"shards"`
+	// Stale are the shards which stopped refreshing their entry
+	Stale []string `json:"stale"`
+}
+
+// Check computes the coverage of the routes between the chains by the live shards
+func (m *Manifest) Check(chains []string, staleAfter time.Duration) (*Report, error) {
+	entries, err := m.Entries()
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Shards: entries, Stale: make([]string, 0)}
+	var live []*Config
+	for _, entry := range entries {
+		if time.Since(entry.UpdatedAt) > staleAfter {
+			report.Stale = append(report.Stale, entry.ID)
+			continue
+		}
+		live = append(live, entry.Config)
+	}
+	report.Coverage = CheckCoverage(live, chains)
+	return report, nil
+}
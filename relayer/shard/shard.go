@@ -0,0 +1,144 @@
+package shard
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/icon-project/centralized-relay/relayer/events"
+)
+
+// RoutedEventTypes are the event types of the messages the router delivers,
+// the coverage of the shards is checked for each of them
+var RoutedEventTypes = []string{
+	events.EmitMessage,
+	events.CallMessage,
+	events.RollbackMessage,
+	events.RevertMessage,
+	events.PacketRegistered,
+	events.PacketAcknowledged,
+}
+
+// Route matches the messages of a src -> dst route, empty side matches any chain
+type Route struct {
+	Src string `yaml:"src" json:"src"`
+	Dst string `yaml:"dst" json:"dst"`
+}
+
+func (r *Route) Match(src, dst string) bool {
+	return (r.Src == "" || r.Src == src) && (r.Dst == "" || r.Dst == dst)
+}
+
+func (r *Route) String() string {
+	src, dst := r.Src, r.Dst
+	if src == "" {
+		src = "*"
+	}
+	if dst == "" {
+		dst = "*"
+	}
+	return src + "->" + dst
+}
+
+// Config declares the routes and event types owned by the relayer instance,
+// empty lists own every route or event type
+type Config struct {
+	ID         string   `yaml:"id" json:"id"`
+	Routes     []*Route `yaml:"routes" json:"routes"`
+	EventTypes []string `yaml:"event-types" json:"event-types"`
+	// Manifest is the file shared by the instances to detect overlaps and gaps
+	Manifest string `yaml:"manifest" json:"manifest"`
+}
+
+func (c *Config) Validate() error {
+	if c.ID == "" {
+		return fmt.Errorf("shard id is required")
+	}
+	for _, route := range c.Routes {
+		if route.Src == "" && route.Dst == "" {
+			return fmt.Errorf("shard %s: route needs a src or a dst, leave routes empty to own every route", c.ID)
+		}
+	}
+	for _, eventType := range c.EventTypes {
+		if !slices.Contains(RoutedEventTypes, eventType) {
+			return fmt.Errorf("shard %s: event type %q is not routed", c.ID, eventType)
+		}
+	}
+	return nil
+}
+
+// Owns returns true if the message of the event type on the route belongs to the shard
+func (c *Config) Owns(src, dst, eventType string) bool {
+	if len(c.EventTypes) > 0 && !slices.Contains(c.EventTypes, eventType) {
+		return false
+	}
+	if len(c.Routes) == 0 {
+		return true
+	}
+	for _, route := range c.Routes {
+		if route.Match(src, dst) {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnsRoute returns true if the shard owns the route for at least an event type
+func (c *Config) OwnsRoute(src, dst string) bool {
+	for _, eventType := range RoutedEventTypes {
+		if c.Owns(src, dst, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ownership is the owners of an event type on a route
+type Ownership struct {
+	Src       string   `json:"src"`
+	Dst       string   `json:"dst"`
+	EventType string   `json:"eventType"`
+	Owners    []string `json:"owners"`
+}
+
+// Coverage lists the routes owned by more than one shard and the ones without owner
+type Coverage struct {
+	Overlaps []*Ownership `json:"overlaps"`
+	Gaps     []*Ownership `json:"gaps"`
+}
+
+func (c *Coverage) OK() bool {
+	return len(c.Overlaps) == 0 && len(c.Gaps) == 0
+}
+
+// CheckCoverage checks the shards against every route between the chains
+func CheckCoverage(shards []*Config, chains []string) *Coverage {
+	coverage := &Coverage{
+		Overlaps: make([]*Ownership, 0),
+		Gaps:     make([]*Ownership, 0),
+	}
+	sorted := slices.Clone(chains)
+	slices.Sort(sorted)
+	for _, src := range sorted {
+		for _, dst := range sorted {
+			if src == dst {
+				continue
+			}
+			for _, eventType := range RoutedEventTypes {
+				ownership := &Ownership{Src: src, Dst: dst, EventType: eventType, Owners: make([]string, 0)}
+				for _, shard := range shards {
+					if shard.Owns(src, dst, eventType) {
+						ownership.Owners = append(ownership.Owners, shard.ID)
+					}
+				}
+				switch len(ownership.Owners) {
+				case 0:
+					coverage.Gaps = append(coverage.Gaps, ownership)
+				case 1:
+				default:
+					coverage.Overlaps = append(coverage.Overlaps, ownership)
+				}
+			}
+		}
+	}
+	return coverage
+}
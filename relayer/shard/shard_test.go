@@ -0,0 +1,73 @@
+package shard
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShard(t *testing.T) {
+	a := &Config{ID: "a", Routes: []*Route{{Src: "0x2.icon"}}}
+	b := &Config{ID: "b", Routes: []*Route{{Src: "archway", Dst: "0x2.icon"}}, EventTypes: []string{events.EmitMessage}}
+
+	t.Run("owns", func(t *testing.T) {
+		assert.True(t, a.Owns("0x2.icon", "archway", events.EmitMessage))
+		assert.False(t, a.Owns("archway", "0x2.icon", events.EmitMessage))
+		assert.True(t, b.Owns("archway", "0x2.icon", events.EmitMessage))
+		assert.False(t, b.Owns("archway", "0x2.icon", events.CallMessage))
+		assert.True(t, b.OwnsRoute("archway", "0x2.icon"))
+		assert.True(t, (&Config{ID: "all"}).Owns("archway", "0x2.icon", events.CallMessage))
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		assert.NoError(t, a.Validate())
+		assert.Error(t, (&Config{}).Validate())
+		assert.Error(t, (&Config{ID: "c", Routes: []*Route{{}}}).Validate())
+		assert.Error(t, (&Config{ID: "c", EventTypes: []string{events.SetAdmin}}).Validate())
+	})
+
+	t.Run("coverage", func(t *testing.T) {
+		all := &Config{ID: "all", EventTypes: []string{events.EmitMessage}}
+		coverage := CheckCoverage([]*Config{a, b, all}, []string{"archway", "0x2.icon"})
+		assert.False(t, coverage.OK())
+		// emitMessage is owned twice on both routes
+		assert.Len(t, coverage.Overlaps, 2)
+		for _, overlap := range coverage.Overlaps {
+			assert.Equal(t, events.EmitMessage, overlap.EventType)
+		}
+		// archway -> 0x2.icon is only owned for emitMessage
+		assert.Len(t, coverage.Gaps, len(RoutedEventTypes)-1)
+		for _, gap := range coverage.Gaps {
+			assert.Equal(t, "archway", gap.Src)
+		}
+		assert.True(t, CheckCoverage([]*Config{a, {ID: "c", Routes: []*Route{{Src: "archway"}}}}, []string{"archway", "0x2.icon"}).OK())
+	})
+}
+
+func TestManifest(t *testing.T) {
+	manifest, err := NewManifest(filepath.Join(t.TempDir(), "shards.json"))
+	assert.NoError(t, err)
+	chains := []string{"archway", "0x2.icon"}
+
+	report, err := manifest.Check(chains, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, report.Gaps, 2*len(RoutedEventTypes))
+
+	assert.NoError(t, manifest.Register(&Config{ID: "a", Routes: []*Route{{Src: "0x2.icon"}}}))
+	assert.NoError(t, manifest.Register(&Config{ID: "b", Routes: []*Route{{Src: "archway"}}}))
+	report, err = manifest.Check(chains, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+	assert.Len(t, report.Shards, 2)
+	assert.Equal(t, "a", report.Shards[0].ID)
+
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, manifest.Register(&Config{ID: "a", Routes: []*Route{{Src: "0x2.icon"}}}))
+	report, err = manifest.Check(chains, 5*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, report.Stale)
+	assert.Len(t, report.Gaps, len(RoutedEventTypes))
+}
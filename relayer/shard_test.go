@@ -0,0 +1,36 @@
+package relayer
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/shard"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageNotOwnedKept(t *testing.T) {
+	rly := newTestRelayer(t, filepath.Join(t.TempDir(), "db"), newCostProvider(t, "mock-1", 0, 0), newCostProvider(t, "mock-2", 0, 0))
+	require.NoError(t, rly.SetShard(&shard.Config{ID: "inbound", Routes: []*shard.Route{{Src: "mock-2", Dst: "mock-1"}}}))
+	srcRuntime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// stored before the shard changed
+	message := types.NewRouteMessage(emitMessage(1))
+	srcRuntime.MessageCache.Add(message)
+	require.NoError(t, rly.messageStore.StoreMessage(message))
+
+	rly.processMessages(ctx)
+	_, ok := srcRuntime.MessageCache.Get(message.MessageKey())
+	assert.False(t, ok)
+	_, err = rly.messageStore.GetMessage(message.MessageKey())
+	assert.NoError(t, err)
+
+	// the flush does not queue it again
+	rly.flushMessages(ctx)
+	_, ok = srcRuntime.MessageCache.Get(message.MessageKey())
+	assert.False(t, ok)
+}
@@ -5,6 +5,10 @@ import (
 	"math/big"
 	"net"
 
+	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/icon-project/centralized-relay/relayer/circuit"
+	"github.com/icon-project/centralized-relay/relayer/fee"
+	"github.com/icon-project/centralized-relay/relayer/scheduler"
 	"github.com/icon-project/centralized-relay/relayer/types"
 	jsoniter "github.com/json-iterator/go"
 )
@@ -29,6 +33,17 @@ const (
 	EventRelayerInfo       Event = "RelayerInfo"
 	EventMessageReceived   Event = "MessageReceived"
 	EventGetBlockEvents    Event = "GetBlockEvents"
+	EventFeeReport         Event = "FeeReport"
+	EventSkippedMessages   Event = "GetSkippedMessages"
+	EventUnparkMessage     Event = "UnparkMessage"
+	EventCostReport        Event = "CostReport"
+	EventCircuitStatus     Event = "GetCircuitStatus"
+	EventQueueDepth        Event = "GetQueueDepth"
+	EventReloadConfig      Event = "ReloadConfig"
+	EventPause             Event = "Pause"
+	EventResume            Event = "Resume"
+	EventListPauses        Event = "ListPauses"
+	EventShardStatus       Event = "GetShardStatus"
 )
 
 var (
@@ -262,6 +277,193 @@ func (c *Client) ClaimFee(chain string) (*ResClaimFee, error) {
 	return resData, nil
 }
 
+// FeeReport sends FeeReport event to socket
+func (c *Client) FeeReport(apply bool) ([]*fee.Proposal, error) {
+	req := &ReqFeeReport{Apply: apply}
+	if err := c.send(&Request{Event: EventFeeReport, Data: req}); err != nil {
+		return nil, err
+	}
+	res, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	resData := []*fee.Proposal{}
+	if err := parseResData(res.Data, &resData); err != nil {
+		return nil, err
+	}
+
+	return resData, nil
+}
+
+// GetCircuitStatus sends GetCircuitStatus event to socket
+func (c *Client) GetCircuitStatus(chain string) ([]*circuit.Status, error) {
+	req := &ReqCircuitStatus{Chain: chain}
+	if err := c.send(&Request{Event: EventCircuitStatus, Data: req}); err != nil {
+		return nil, err
+	}
+	res, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	resData := []*circuit.Status{}
+	if err := parseResData(res.Data, &resData); err != nil {
+		return nil, err
+	}
+
+	return resData, nil
+}
+
+// GetQueueDepth sends GetQueueDepth event to socket
+func (c *Client) GetQueueDepth() ([]*scheduler.QueueDepth, error) {
+	if err := c.send(&Request{Event: EventQueueDepth}); err != nil {
+		return nil, err
+	}
+	res, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	resData := []*scheduler.QueueDepth{}
+	if err := parseResData(res.Data, &resData); err != nil {
+		return nil, err
+	}
+
+	return resData, nil
+}
+
+// GetShardStatus sends GetShardStatus event to socket
+func (c *Client) GetShardStatus() (*relayer.ShardStatus, error) {
+	if err := c.send(&Request{Event: EventShardStatus}); err != nil {
+		return nil, err
+	}
+	res, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	resData := new(relayer.ShardStatus)
+	if err := parseResData(res.Data, resData); err != nil {
+		return nil, err
+	}
+
+	return resData, nil
+}
+
+// ReloadConfig sends ReloadConfig event to socket
+func (c *Client) ReloadConfig() (*relayer.ReloadReport, error) {
+	if err := c.send(&Request{Event: EventReloadConfig}); err != nil {
+		return nil, err
+	}
+	res, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	resData := new(relayer.ReloadReport)
+	if err := parseResData(res.Data, resData); err != nil {
+		return nil, err
+	}
+
+	return resData, nil
+}
+
+// Pause sends Pause event to socket
+func (c *Client) Pause(pause *types.Pause) error {
+	req := &ReqPause{Pause: pause}
+	if err := c.send(&Request{Event: EventPause, Data: req}); err != nil {
+		return err
+	}
+	_, err := c.read()
+	return err
+}
+
+// Resume sends Resume event to socket
+func (c *Client) Resume(kind, src, dst string) error {
+	req := &ReqResume{Kind: kind, Src: src, Dst: dst}
+	if err := c.send(&Request{Event: EventResume, Data: req}); err != nil {
+		return err
+	}
+	_, err := c.read()
+	return err
+}
+
+// ListPauses sends ListPauses event to socket
+func (c *Client) ListPauses() ([]*types.Pause, error) {
+	if err := c.send(&Request{Event: EventListPauses}); err != nil {
+		return nil, err
+	}
+	res, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	resData := []*types.Pause{}
+	if err := parseResData(res.Data, &resData); err != nil {
+		return nil, err
+	}
+
+	return resData, nil
+}
+
+// CostReport sends CostReport event to socket
+func (c *Client) CostReport(from, to int64) (*fee.Report, error) {
+	req := &ReqCostReport{From: from, To: to}
+	if err := c.send(&Request{Event: EventCostReport, Data: req}); err != nil {
+		return nil, err
+	}
+	res, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	resData := new(fee.Report)
+	if err := parseResData(res.Data, &resData); err != nil {
+		return nil, err
+	}
+
+	return resData, nil
+}
+
+// UnparkMessage sends UnparkMessage event to socket
+func (c *Client) UnparkMessage(chain string, sn *big.Int) (*types.RouteMessage, error) {
+	req := &ReqUnparkMessage{Chain: chain, Sn: sn}
+	if err := c.send(&Request{Event: EventUnparkMessage, Data: req}); err != nil {
+		return nil, err
+	}
+	res, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	resData := new(types.RouteMessage)
+	if err := parseResData(res.Data, &resData); err != nil {
+		return nil, err
+	}
+
+	return resData, nil
+}
+
+// GetSkippedMessages sends GetSkippedMessages event to socket
+func (c *Client) GetSkippedMessages(chain string) ([]*fee.Decision, error) {
+	req := &ReqSkippedMessages{Chain: chain}
+	if err := c.send(&Request{Event: EventSkippedMessages, Data: req}); err != nil {
+		return nil, err
+	}
+	res, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	resData := []*fee.Decision{}
+	if err := parseResData(res.Data, &resData); err != nil {
+		return nil, err
+	}
+
+	return resData, nil
+}
+
 func (c *Client) GetLatestHeight(chain string) (*ResChainHeight, error) {
 	req := &ReqChainHeight{Chain: chain}
 	if err := c.send(&Request{Event: EventGetLatestHeight, Data: req}); err != nil {
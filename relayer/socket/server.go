@@ -12,6 +12,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 
 	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/icon-project/centralized-relay/relayer/circuit"
 	"github.com/icon-project/centralized-relay/relayer/store"
 	"github.com/icon-project/centralized-relay/relayer/types"
 )
@@ -19,6 +20,8 @@ import (
 var (
 	SocketPath = getEnvOrFallback("SOCKET_PATH", path.Join(os.TempDir(), "relayer.sock"))
 	network    = "unix"
+	// reloadTimeout covers the initialization of the reloaded providers
+	reloadTimeout = 2 * time.Minute
 )
 
 func NewSocket(rly *relayer.Relayer) (*Server, error) {
@@ -244,14 +247,110 @@ func (s *Server) parseEvent(msg *Request) *Response {
 		if err := jsoniter.Unmarshal(data, req); err != nil {
 			return response.SetError(err)
 		}
-		chain, err := s.rly.FindChainRuntime(req.Chain)
+		if err := s.rly.ClaimFee(ctx, req.Chain); err != nil {
+			return response.SetError(err)
+		}
+		return response.SetData(&ResClaimFee{"Success"})
+	case EventFeeReport:
+		req := new(ReqFeeReport)
+		if err := jsoniter.Unmarshal(data, req); err != nil {
+			return response.SetError(err)
+		}
+		proposals, err := s.rly.UpdateFees(ctx, req.Apply)
 		if err != nil {
 			return response.SetError(err)
 		}
-		if err := chain.Provider.ClaimFee(ctx); err != nil {
+		return response.SetData(proposals)
+	case EventCostReport:
+		req := new(ReqCostReport)
+		if err := jsoniter.Unmarshal(data, req); err != nil {
 			return response.SetError(err)
 		}
-		return response.SetData(&ResClaimFee{"Success"})
+		report, err := s.rly.CostReport(ctx, time.Unix(req.From, 0), time.Unix(req.To, 0))
+		if err != nil {
+			return response.SetError(err)
+		}
+		return response.SetData(report)
+	case EventCircuitStatus:
+		req := new(ReqCircuitStatus)
+		if err := jsoniter.Unmarshal(data, req); err != nil {
+			return response.SetError(err)
+		}
+		var statuses []*circuit.Status
+		if req.Chain != "" {
+			chain, err := s.rly.FindChainRuntime(req.Chain)
+			if err != nil {
+				return response.SetError(err)
+			}
+			statuses = append(statuses, chain.Circuit.Status())
+		} else {
+			for _, chain := range s.rly.GetAllChainsRuntime() {
+				statuses = append(statuses, chain.Circuit.Status())
+			}
+		}
+		return response.SetData(statuses)
+	case EventReloadConfig:
+		reloadCtx, cancel := context.WithTimeout(context.Background(), reloadTimeout)
+		defer cancel()
+		report, err := s.rly.ReloadConfig(reloadCtx)
+		if err != nil {
+			return response.SetError(err)
+		}
+		return response.SetData(report)
+	case EventPause:
+		req := new(ReqPause)
+		if err := jsoniter.Unmarshal(data, req); err != nil {
+			return response.SetError(err)
+		}
+		if req.Pause == nil {
+			return response.SetError(fmt.Errorf("pause is required"))
+		}
+		if err := s.rly.Pause(ctx, req.Pause); err != nil {
+			return response.SetError(err)
+		}
+		return response.SetData(req.Pause)
+	case EventResume:
+		req := new(ReqResume)
+		if err := jsoniter.Unmarshal(data, req); err != nil {
+			return response.SetError(err)
+		}
+		if err := s.rly.Resume(ctx, req.Kind, req.Src, req.Dst); err != nil {
+			return response.SetError(err)
+		}
+		return response.SetData(req)
+	case EventListPauses:
+		return response.SetData(s.rly.GetPauses())
+	case EventQueueDepth:
+		return response.SetData(s.rly.QueueDepth())
+	case EventShardStatus:
+		status, err := s.rly.ShardStatus()
+		if err != nil {
+			return response.SetError(err)
+		}
+		if status == nil {
+			return response.SetError(fmt.Errorf("relayer is not sharded"))
+		}
+		return response.SetData(status)
+	case EventSkippedMessages:
+		req := new(ReqSkippedMessages)
+		if err := jsoniter.Unmarshal(data, req); err != nil {
+			return response.SetError(err)
+		}
+		decisions, err := s.rly.GetSkippedMessages(req.Chain)
+		if err != nil {
+			return response.SetError(err)
+		}
+		return response.SetData(decisions)
+	case EventUnparkMessage:
+		req := new(ReqUnparkMessage)
+		if err := jsoniter.Unmarshal(data, req); err != nil {
+			return response.SetError(err)
+		}
+		message, err := s.rly.UnparkMessage(&types.MessageKey{Src: req.Chain, Sn: req.Sn})
+		if err != nil {
+			return response.SetError(err)
+		}
+		return response.SetData(message)
 	case EventGetConfig:
 		req := new(ReqChainHeight)
 		if err := jsoniter.Unmarshal(data, req); err != nil {
@@ -292,6 +391,7 @@ func (s *Server) parseEvent(msg *Request) *Response {
 				LatestHeight:   latestHeight,
 				LastCheckPoint: chain.LastSavedHeight,
 				Contracts:      chain.Provider.Config().ContractsAddress(),
+				Circuit:        chain.Circuit.Status(),
 			})
 		}
 		return response.SetData(chainNames)
@@ -333,7 +433,7 @@ func (s *Server) parseEvent(msg *Request) *Response {
 		if err := jsoniter.Unmarshal(data, req); err != nil {
 			return response.SetError(err)
 		}
-		return response.SetData(&ResRelayInfo{Version: relayer.Version, Uptime: s.startedAt})
+		return response.SetData(&ResRelayInfo{Version: relayer.Version, Uptime: s.startedAt, Election: s.rly.ElectionStatus()})
 	case EventGetBlockEvents:
 		req := new(ReqGetBlockEvents)
 		if err := jsoniter.Unmarshal(data, req); err != nil {
@@ -5,6 +5,8 @@ import (
 	"net"
 
 	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/icon-project/centralized-relay/relayer/circuit"
+	"github.com/icon-project/centralized-relay/relayer/election"
 	"github.com/icon-project/centralized-relay/relayer/types"
 )
 
@@ -142,6 +144,28 @@ type ResClaimFee struct {
 	Status string `json:"status"`
 }
 
+// ReqFeeReport sends FeeReport event to socket
+type ReqFeeReport struct {
+	Apply bool `json:"apply"`
+}
+
+// ReqCostReport sends CostReport event to socket, from and to are unix time
+type ReqCostReport struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// ReqSkippedMessages sends GetSkippedMessages event to socket
+type ReqSkippedMessages struct {
+	Chain string `json:"chain"`
+}
+
+// ReqUnparkMessage sends UnparkMessage event to socket
+type ReqUnparkMessage struct {
+	Chain string   `json:"chain"`
+	Sn    *big.Int `json:"sn"`
+}
+
 type ReqChainHeight struct {
 	Chain string `json:"chain"`
 }
@@ -178,6 +202,21 @@ type ResChainInfo struct {
 	Contracts      map[string]string `json:"contracts"`
 	LatestHeight   uint64            `json:"latestHeight"`
 	LastCheckPoint uint64            `json:"lastCheckPoint"`
+	Circuit        *circuit.Status   `json:"circuit"`
+}
+
+type ReqPause struct {
+	Pause *types.Pause `json:"pause"`
+}
+
+type ReqResume struct {
+	Kind string `json:"kind"`
+	Src  string `json:"src"`
+	Dst  string `json:"dst,omitempty"`
+}
+
+type ReqCircuitStatus struct {
+	Chain string `json:"chain"`
 }
 
 type ReqGetBalance struct {
@@ -197,6 +236,8 @@ type ReqRelayInfo struct{}
 type ResRelayInfo struct {
 	Version string `json:"version"`
 	Uptime  int64  `json:"uptime"`
+	// Election is the role of the relayer in the active/passive mode
+	Election *election.Status `json:"election,omitempty"`
 }
 
 type ReqMessageReceived struct {
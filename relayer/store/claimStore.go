@@ -0,0 +1,54 @@
+package store
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// ClaimStore keeps the history of the fees claimed by the relayer
+type ClaimStore struct {
+	db     Store
+	prefix string
+}
+
+func NewClaimStore(db Store, prefix string) *ClaimStore {
+	return &ClaimStore{
+		db:     db,
+		prefix: prefix,
+	}
+}
+
+func (cs *ClaimStore) StoreClaim(claim *types.FeeClaim) error {
+	if claim == nil {
+		return fmt.Errorf("error while storing claim: claim cannot be nil")
+	}
+	key := GetKey([]string{cs.prefix, claim.Chain, fmt.Sprintf("%020d", claim.Timestamp)})
+	data, err := jsoniter.Marshal(claim)
+	if err != nil {
+		return err
+	}
+	return cs.db.SetByKey(key, data)
+}
+
+// GetClaims returns the claims of all the chains made between from and to unix time inclusive
+func (cs *ClaimStore) GetClaims(from, to int64) ([]*types.FeeClaim, error) {
+	var claims []*types.FeeClaim
+
+	iter := cs.db.NewIterator(GetKey([]string{cs.prefix, ""}))
+	defer iter.Release()
+
+	for iter.Next() {
+		claim := new(types.FeeClaim)
+		if err := jsoniter.Unmarshal(iter.Value(), claim); err != nil {
+			return nil, err
+		}
+		if claim.Timestamp < from || claim.Timestamp > to {
+			continue
+		}
+		claims = append(claims, claim)
+	}
+	return claims, iter.Error()
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// CostStore keeps the cost of every delivery made by the relayer per route
+type CostStore struct {
+	db     Store
+	prefix string
+}
+
+func NewCostStore(db Store, prefix string) *CostStore {
+	return &CostStore{
+		db:     db,
+		prefix: prefix,
+	}
+}
+
+// routeKey is terminated by the separator so that a route is never matched
+// as a prefix of another route sharing the same leading characters
+func (cs *CostStore) routeKey(src, dst string) []byte {
+	return GetKey([]string{cs.prefix, src, dst, ""})
+}
+
+func (cs *CostStore) StoreCost(cost *types.DeliveryCost) error {
+	if cost == nil {
+		return fmt.Errorf("error while storing cost: cost cannot be nil")
+	}
+	// zero padded timestamp keeps the entries of a route ordered by time
+	key := append(cs.routeKey(cost.Src, cost.Dst), []byte(fmt.Sprintf("%020d-%s", cost.Timestamp, cost.TxHash))...)
+	data, err := jsoniter.Marshal(cost)
+	if err != nil {
+		return err
+	}
+	return cs.db.SetByKey(key, data)
+}
+
+// GetCosts returns the costs of all the routes recorded between from and to unix time inclusive
+func (cs *CostStore) GetCosts(from, to int64) ([]*types.DeliveryCost, error) {
+	var costs []*types.DeliveryCost
+
+	iter := cs.db.NewIterator(GetKey([]string{cs.prefix, ""}))
+	defer iter.Release()
+
+	for iter.Next() {
+		cost := new(types.DeliveryCost)
+		if err := jsoniter.Unmarshal(iter.Value(), cost); err != nil {
+			return nil, err
+		}
+		if cost.Timestamp < from || cost.Timestamp > to {
+			continue
+		}
+		costs = append(costs, cost)
+	}
+	return costs, iter.Error()
+}
+
+// GetRecentCosts returns at most limit latest costs of the route, newest first
+func (cs *CostStore) GetRecentCosts(src, dst string, limit uint) ([]*types.DeliveryCost, error) {
+	var costs []*types.DeliveryCost
+
+	iter := cs.db.NewIterator(cs.routeKey(src, dst))
+	defer iter.Release()
+
+	for ok := iter.Last(); ok && uint(len(costs)) < limit; ok = iter.Prev() {
+		cost := new(types.DeliveryCost)
+		if err := jsoniter.Unmarshal(iter.Value(), cost); err != nil {
+			return nil, err
+		}
+		costs = append(costs, cost)
+	}
+	return costs, iter.Error()
+}
@@ -0,0 +1,61 @@
+package store
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/icon-project/centralized-relay/relayer/fee"
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// DecisionStore keeps the latest profitability decision of the skipped
+// messages across restarts
+type DecisionStore struct {
+	db     Store
+	prefix string
+}
+
+func NewDecisionStore(db Store, prefix string) *DecisionStore {
+	return &DecisionStore{
+		db:     db,
+		prefix: prefix,
+	}
+}
+
+func (ds *DecisionStore) StoreDecision(decision *fee.Decision) error {
+	if decision == nil {
+		return fmt.Errorf("error while storing decision: decision cannot be nil")
+	}
+	data, err := jsoniter.Marshal(decision)
+	if err != nil {
+		return err
+	}
+	return ds.db.SetByKey(GetKey([]string{ds.prefix, decision.Src, decision.Sn.String()}), data)
+}
+
+func (ds *DecisionStore) DeleteDecision(key *types.MessageKey) error {
+	return ds.db.DeleteByKey(GetKey([]string{ds.prefix, key.Src, key.Sn.String()}))
+}
+
+// GetDecisions returns the decisions of the messages emitted on src, all the
+// decisions when src is empty
+func (ds *DecisionStore) GetDecisions(src string) ([]*fee.Decision, error) {
+	var decisions []*fee.Decision
+
+	prefix := []string{ds.prefix, ""}
+	if src != "" {
+		prefix = []string{ds.prefix, src, ""}
+	}
+	iter := ds.db.NewIterator(GetKey(prefix))
+	defer iter.Release()
+
+	for iter.Next() {
+		decision := new(fee.Decision)
+		if err := jsoniter.Unmarshal(iter.Value(), decision); err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, decision)
+	}
+	return decisions, iter.Error()
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// PauseStore keeps the paused chains and routes across restarts
+type PauseStore struct {
+	db     Store
+	prefix string
+}
+
+func NewPauseStore(db Store, prefix string) *PauseStore {
+	return &PauseStore{
+		db:     db,
+		prefix: prefix,
+	}
+}
+
+func (ps *PauseStore) StorePause(pause *types.Pause) error {
+	if pause == nil {
+		return fmt.Errorf("error while storing pause: pause cannot be nil")
+	}
+	data, err := jsoniter.Marshal(pause)
+	if err != nil {
+		return err
+	}
+	return ps.db.SetByKey(GetKey([]string{ps.prefix, pause.ID()}), data)
+}
+
+func (ps *PauseStore) DeletePause(id string) error {
+	return ps.db.DeleteByKey(GetKey([]string{ps.prefix, id}))
+}
+
+// GetPauses returns all the stored pauses
+func (ps *PauseStore) GetPauses() ([]*types.Pause, error) {
+	var pauses []*types.Pause
+
+	iter := ps.db.NewIterator(GetKey([]string{ps.prefix, ""}))
+	defer iter.Release()
+
+	for iter.Next() {
+		pause := new(types.Pause)
+		if err := jsoniter.Unmarshal(iter.Value(), pause); err != nil {
+			return nil, err
+		}
+		pauses = append(pauses, pause)
+	}
+	return pauses, iter.Error()
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -41,6 +43,8 @@ type Message struct {
 	SignedData          []byte   `json:"signedData"`
 	Signatures          [][]byte `json:"signatures"`
 	XcallSn             *big.Int `json:"xcallSN,omitempty"`
+	// Fee paid on the src chain for the delivery
+	Fee *big.Int `json:"fee,omitempty"`
 
 	TxInfo []byte `json:"-"`
 }
@@ -66,6 +70,37 @@ func (c ContractConfigMap) Validate() error {
 	return nil
 }
 
+// Names returns the sorted names of the contracts
+func (c ContractConfigMap) Names() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UnknownKeys returns the sorted names of the contracts which are not used by the relayer
+func (c ContractConfigMap) UnknownKeys() []string {
+	var unknown []string
+	for _, name := range c.Names() {
+		if name != AggregationContract && !slices.Contains(SupportedContracts, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// ChainIDFromNID returns the chain id prefixed to the nid, 0xa869 of 0xa869.fuji,
+// false is returned when the nid has no hex prefix
+func ChainIDFromNID(nid string) (*big.Int, bool) {
+	prefix, _, found := strings.Cut(nid, ".")
+	if !found || !strings.HasPrefix(prefix, "0x") {
+		return nil, false
+	}
+	return new(big.Int).SetString(strings.TrimPrefix(prefix, "0x"), 16)
+}
+
 // GetWasmMsgType returns the wasm message type
 func (m *EventMap) GetWasmMsgType() string {
 	for wasmType := range m.SigType {
@@ -83,12 +118,27 @@ type RouteMessage struct {
 	Retry      uint8     `json:"retry"`
 	Processing bool      `json:"processing"`
 	LastTry    time.Time `json:"lastTry"`
+	// QueuedAt is the time the message entered the routing queue
+	QueuedAt time.Time `json:"queuedAt"`
+	// Priority and PolicyVersion are set by the routing policy, the
+	// message is evaluated again when the rules change
+	Priority      int    `json:"-"`
+	PolicyVersion uint64 `json:"-"`
+	// Confirmed is set once a quorum of the endpoints of the src chain saw the message
+	Confirmed bool `json:"-"`
+	// Defers counts the profitability checks which deferred the message
+	Defers uint8 `json:"defers,omitempty"`
+	// Unparked is set when the message is moved back from the parked store,
+	// it is routed without the profitability check
+	Unparked bool `json:"unparked,omitempty"`
 }
 
 func NewRouteMessage(m *Message) *RouteMessage {
+	now := time.Now()
 	return &RouteMessage{
-		Message: m,
-		LastTry: time.Now(),
+		Message:  m,
+		LastTry:  now,
+		QueuedAt: now,
 	}
 }
 
@@ -137,6 +187,14 @@ type TxResponse struct {
 	Codespace string
 	Code      ResponseCode
 	Data      string
+	// GasUsed and TxFee are the resources consumed by the transaction
+	// on the destination chain, TxFee is denominated in the smallest unit
+	// of the destination native coin.
+	GasUsed uint64
+	TxFee   *big.Int
+	// Replacements are the hashes of all the transactions sent with the
+	// same nonce when a stuck one was replaced, TxHash is the mined one
+	Replacements []string
 }
 
 type ResponseCode uint8
@@ -253,6 +311,90 @@ type Receipt struct {
 	Status bool
 }
 
+// DeliveryCost is the cost paid on the destination chain for relaying a message
+type DeliveryCost struct {
+	Src       string   `json:"src"`
+	Dst       string   `json:"dst"`
+	EventType string   `json:"eventType"`
+	TxHash    string   `json:"txHash"`
+	GasUsed   uint64   `json:"gasUsed"`
+	TxFee     *big.Int `json:"txFee"`
+	Failed    bool     `json:"failed,omitempty"`
+	// FeePaid is the fee paid on the src chain when known
+	FeePaid   *big.Int `json:"feePaid,omitempty"`
+	Timestamp int64    `json:"timestamp"`
+}
+
+func NewDeliveryCost(key *MessageKey, dst string, res *TxResponse) *DeliveryCost {
+	return &DeliveryCost{
+		Src:       key.Src,
+		Dst:       dst,
+		EventType: key.EventType,
+		TxHash:    res.TxHash,
+		GasUsed:   res.GasUsed,
+		TxFee:     res.TxFee,
+		Failed:    res.Code != Success,
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+// FeeClaim is the amount of fees claimed from the connection contract of a chain
+type FeeClaim struct {
+	Chain     string   `json:"chain"`
+	Amount    *big.Int `json:"amount"`
+	Timestamp int64    `json:"timestamp"`
+}
+
+const (
+	// PauseListen stops the listener of the chain
+	PauseListen = "listen"
+	// PauseRoute stops routing the messages of the route
+	PauseRoute = "route"
+)
+
+// Pause stops listening to a chain or routing on a route between From and Until unix time,
+// a route pause without Dst covers the messages to and from Src
+type Pause struct {
+	Kind   string `json:"kind"`
+	Src    string `json:"src"`
+	Dst    string `json:"dst,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// From is the start of the maintenance window, 0 pauses immediately
+	From int64 `json:"from,omitempty"`
+	// Until resumes automatically, 0 pauses until resumed
+	Until     int64 `json:"until,omitempty"`
+	CreatedAt int64 `json:"createdAt"`
+}
+
+// ID identifies the paused target
+func (p *Pause) ID() string {
+	if p.Dst == "" {
+		return p.Kind + "/" + p.Src
+	}
+	return p.Kind + "/" + p.Src + "->" + p.Dst
+}
+
+// IsActive returns true if the pause is in effect at the unix time
+func (p *Pause) IsActive(now int64) bool {
+	return p.From <= now && (p.Until == 0 || now < p.Until)
+}
+
+// IsExpired returns true once the pause resumed automatically
+func (p *Pause) IsExpired(now int64) bool {
+	return p.Until != 0 && now >= p.Until
+}
+
+// MatchRoute returns true if the route pause covers the src -> dst route
+func (p *Pause) MatchRoute(src, dst string) bool {
+	if p.Kind != PauseRoute {
+		return false
+	}
+	if p.Dst == "" {
+		return p.Src == src || p.Src == dst
+	}
+	return p.Src == src && p.Dst == dst
+}
+
 type EventLog struct {
 	Height uint64
 	Events []string
@@ -50,3 +50,53 @@ func TestMessageCache(t *testing.T) {
 		assert.Equal(t, messageCache.Len(), int(0))
 	})
 }
+
+func TestPause(t *testing.T) {
+	chain := &Pause{Kind: PauseRoute, Src: "mock-1"}
+	route := &Pause{Kind: PauseRoute, Src: "mock-1", Dst: "mock-2", From: 100, Until: 200}
+
+	t.Run("id", func(t *testing.T) {
+		assert.Equal(t, "route/mock-1", chain.ID())
+		assert.Equal(t, "route/mock-1->mock-2", route.ID())
+	})
+
+	t.Run("match route", func(t *testing.T) {
+		assert.True(t, chain.MatchRoute("mock-2", "mock-1"))
+		assert.True(t, route.MatchRoute("mock-1", "mock-2"))
+		assert.False(t, route.MatchRoute("mock-2", "mock-1"))
+		assert.False(t, (&Pause{Kind: PauseListen, Src: "mock-1"}).MatchRoute("mock-1", "mock-2"))
+	})
+
+	t.Run("maintenance window", func(t *testing.T) {
+		assert.True(t, chain.IsActive(50))
+		assert.False(t, route.IsActive(50))
+		assert.True(t, route.IsActive(150))
+		assert.False(t, route.IsActive(200))
+		assert.True(t, route.IsExpired(200))
+		assert.False(t, chain.IsExpired(200))
+	})
+}
+
+func TestContractConfigMap(t *testing.T) {
+	contracts := ContractConfigMap{
+		XcallContract:       "cx1",
+		ConnectionContract:  "cx2",
+		AggregationContract: "cx3",
+		"conection":         "cx4",
+		"dapp":              "cx5",
+	}
+	assert.Equal(t, []string{"aggregation", "conection", "connection", "dapp", "xcall"}, contracts.Names())
+	assert.Equal(t, []string{"conection", "dapp"}, contracts.UnknownKeys())
+	assert.Empty(t, ContractConfigMap{XcallContract: "cx1"}.UnknownKeys())
+}
+
+func TestChainIDFromNID(t *testing.T) {
+	id, ok := ChainIDFromNID("0xa869.fuji")
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(0xa869), id)
+
+	for _, nid := range []string{"archway-1", "sui", "0x.icon", "0xzz.icon", "0x1"} {
+		_, ok := ChainIDFromNID(nid)
+		assert.False(t, ok, nid)
+	}
+}